@@ -4,6 +4,7 @@ go 1.21.4
 
 require (
 	github.com/bufbuild/protoyaml-go v0.1.7
+	github.com/creack/pty v1.1.21
 	github.com/google/uuid v1.3.1
 	github.com/jedib0t/go-pretty/v6 v6.4.9
 	github.com/kralicky/protols v0.0.0-20231219004014-2c8bbe86a91e
@@ -41,6 +42,7 @@ require (
 	golang.org/x/net v0.19.0 // indirect
 	golang.org/x/sync v0.5.0 // indirect
 	golang.org/x/sys v0.15.0
+	golang.org/x/term v0.15.0
 	golang.org/x/text v0.14.0 // indirect
 	golang.org/x/tools v0.14.0 // indirect
 	google.golang.org/genproto v0.0.0-20231212172506-995d672761c0 // indirect
@@ -10,6 +10,7 @@ import (
 	_ "github.com/kralicky/jobserver/pkg/apis/rbac/v1"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
@@ -23,6 +24,59 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type RestartMode int32
+
+const (
+	// The process is never restarted.
+	RestartMode_NEVER RestartMode = 0
+	// The process is restarted if it fails to start, exits with a non-zero
+	// exit code, or is terminated by a signal.
+	RestartMode_ON_FAILURE RestartMode = 1
+	// The process is always restarted after it terminates.
+	RestartMode_ALWAYS RestartMode = 2
+)
+
+// Enum value maps for RestartMode.
+var (
+	RestartMode_name = map[int32]string{
+		0: "NEVER",
+		1: "ON_FAILURE",
+		2: "ALWAYS",
+	}
+	RestartMode_value = map[string]int32{
+		"NEVER":      0,
+		"ON_FAILURE": 1,
+		"ALWAYS":     2,
+	}
+)
+
+func (x RestartMode) Enum() *RestartMode {
+	p := new(RestartMode)
+	*p = x
+	return p
+}
+
+func (x RestartMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RestartMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_enumTypes[0].Descriptor()
+}
+
+func (RestartMode) Type() protoreflect.EnumType {
+	return &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_enumTypes[0]
+}
+
+func (x RestartMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RestartMode.Descriptor instead.
+func (RestartMode) EnumDescriptor() ([]byte, []int) {
+	return file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_rawDescGZIP(), []int{0}
+}
+
 // State describes the logical state of a job.
 //
 //	┌─────────────────────────────────────────┐
@@ -42,7 +96,9 @@ type State int32
 
 const (
 	State_UNKNOWN State = 0
-	// The job is waiting to be started, and is not yet running.
+	// The job is waiting to be started, and is not yet running. Jobs wait in
+	// this state while the server's limits on the number of concurrently
+	// running jobs are reached.
 	State_PENDING State = 1
 	// The job failed to start.
 	State_FAILED State = 2
@@ -50,6 +106,9 @@ const (
 	State_RUNNING State = 3
 	// The job is no longer running.
 	State_TERMINATED State = 4
+	// The job's process has terminated, and will be restarted according to
+	// the job's restart policy once its backoff delay has elapsed.
+	State_RESTARTING State = 5
 )
 
 // Enum value maps for State.
@@ -60,6 +119,7 @@ var (
 		2: "FAILED",
 		3: "RUNNING",
 		4: "TERMINATED",
+		5: "RESTARTING",
 	}
 	State_value = map[string]int32{
 		"UNKNOWN":    0,
@@ -67,6 +127,7 @@ var (
 		"FAILED":     2,
 		"RUNNING":    3,
 		"TERMINATED": 4,
+		"RESTARTING": 5,
 	}
 )
 
@@ -81,11 +142,11 @@ func (x State) String() string {
 }
 
 func (State) Descriptor() protoreflect.EnumDescriptor {
-	return file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_enumTypes[0].Descriptor()
+	return file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_enumTypes[1].Descriptor()
 }
 
 func (State) Type() protoreflect.EnumType {
-	return &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_enumTypes[0]
+	return &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_enumTypes[1]
 }
 
 func (x State) Number() protoreflect.EnumNumber {
@@ -94,7 +155,227 @@ func (x State) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use State.Descriptor instead.
 func (State) EnumDescriptor() ([]byte, []int) {
-	return file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_rawDescGZIP(), []int{0}
+	return file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_rawDescGZIP(), []int{1}
+}
+
+type ConcurrencyPolicy int32
+
+const (
+	// A new job is started even if jobs started earlier by the schedule are
+	// still running.
+	ConcurrencyPolicy_ALLOW ConcurrencyPolicy = 0
+	// No job is started if jobs started earlier by the schedule are still
+	// running.
+	ConcurrencyPolicy_FORBID ConcurrencyPolicy = 1
+	// Jobs started earlier by the schedule that are still running are stopped,
+	// and a new job is started once they have stopped.
+	ConcurrencyPolicy_REPLACE ConcurrencyPolicy = 2
+)
+
+// Enum value maps for ConcurrencyPolicy.
+var (
+	ConcurrencyPolicy_name = map[int32]string{
+		0: "ALLOW",
+		1: "FORBID",
+		2: "REPLACE",
+	}
+	ConcurrencyPolicy_value = map[string]int32{
+		"ALLOW":   0,
+		"FORBID":  1,
+		"REPLACE": 2,
+	}
+)
+
+func (x ConcurrencyPolicy) Enum() *ConcurrencyPolicy {
+	p := new(ConcurrencyPolicy)
+	*p = x
+	return p
+}
+
+func (x ConcurrencyPolicy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ConcurrencyPolicy) Descriptor() protoreflect.EnumDescriptor {
+	return file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_enumTypes[2].Descriptor()
+}
+
+func (ConcurrencyPolicy) Type() protoreflect.EnumType {
+	return &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_enumTypes[2]
+}
+
+func (x ConcurrencyPolicy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ConcurrencyPolicy.Descriptor instead.
+func (ConcurrencyPolicy) EnumDescriptor() ([]byte, []int) {
+	return file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_rawDescGZIP(), []int{2}
+}
+
+// WorkflowState describes the overall state of a workflow.
+type WorkflowState int32
+
+const (
+	// Some of the workflow's jobs are still running, or waiting to be started.
+	WorkflowState_WORKFLOW_RUNNING WorkflowState = 0
+	// All of the workflow's jobs completed successfully.
+	WorkflowState_WORKFLOW_SUCCEEDED WorkflowState = 1
+	// The workflow is done, and at least one of its jobs failed or was skipped.
+	WorkflowState_WORKFLOW_FAILED WorkflowState = 2
+)
+
+// Enum value maps for WorkflowState.
+var (
+	WorkflowState_name = map[int32]string{
+		0: "WORKFLOW_RUNNING",
+		1: "WORKFLOW_SUCCEEDED",
+		2: "WORKFLOW_FAILED",
+	}
+	WorkflowState_value = map[string]int32{
+		"WORKFLOW_RUNNING":   0,
+		"WORKFLOW_SUCCEEDED": 1,
+		"WORKFLOW_FAILED":    2,
+	}
+)
+
+func (x WorkflowState) Enum() *WorkflowState {
+	p := new(WorkflowState)
+	*p = x
+	return p
+}
+
+func (x WorkflowState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WorkflowState) Descriptor() protoreflect.EnumDescriptor {
+	return file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_enumTypes[3].Descriptor()
+}
+
+func (WorkflowState) Type() protoreflect.EnumType {
+	return &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_enumTypes[3]
+}
+
+func (x WorkflowState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WorkflowState.Descriptor instead.
+func (WorkflowState) EnumDescriptor() ([]byte, []int) {
+	return file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_rawDescGZIP(), []int{3}
+}
+
+// WorkflowJobState describes the state of a job within a workflow.
+type WorkflowJobState int32
+
+const (
+	// The job is waiting for its dependencies to complete.
+	WorkflowJobState_WORKFLOW_JOB_WAITING WorkflowJobState = 0
+	// The job was started, and has not completed yet.
+	WorkflowJobState_WORKFLOW_JOB_STARTED WorkflowJobState = 1
+	// The job completed successfully.
+	WorkflowJobState_WORKFLOW_JOB_SUCCEEDED WorkflowJobState = 2
+	// The job failed to start, or did not complete successfully.
+	WorkflowJobState_WORKFLOW_JOB_FAILED WorkflowJobState = 3
+	// The job was not started, because one of its dependencies did not
+	// complete successfully.
+	WorkflowJobState_WORKFLOW_JOB_SKIPPED WorkflowJobState = 4
+)
+
+// Enum value maps for WorkflowJobState.
+var (
+	WorkflowJobState_name = map[int32]string{
+		0: "WORKFLOW_JOB_WAITING",
+		1: "WORKFLOW_JOB_STARTED",
+		2: "WORKFLOW_JOB_SUCCEEDED",
+		3: "WORKFLOW_JOB_FAILED",
+		4: "WORKFLOW_JOB_SKIPPED",
+	}
+	WorkflowJobState_value = map[string]int32{
+		"WORKFLOW_JOB_WAITING":   0,
+		"WORKFLOW_JOB_STARTED":   1,
+		"WORKFLOW_JOB_SUCCEEDED": 2,
+		"WORKFLOW_JOB_FAILED":    3,
+		"WORKFLOW_JOB_SKIPPED":   4,
+	}
+)
+
+func (x WorkflowJobState) Enum() *WorkflowJobState {
+	p := new(WorkflowJobState)
+	*p = x
+	return p
+}
+
+func (x WorkflowJobState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WorkflowJobState) Descriptor() protoreflect.EnumDescriptor {
+	return file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_enumTypes[4].Descriptor()
+}
+
+func (WorkflowJobState) Type() protoreflect.EnumType {
+	return &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_enumTypes[4]
+}
+
+func (x WorkflowJobState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WorkflowJobState.Descriptor instead.
+func (WorkflowJobState) EnumDescriptor() ([]byte, []int) {
+	return file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_rawDescGZIP(), []int{4}
+}
+
+// Stream identifies one of a process's output streams.
+type Stream int32
+
+const (
+	Stream_STREAM_UNSPECIFIED Stream = 0
+	Stream_STDOUT             Stream = 1
+	Stream_STDERR             Stream = 2
+)
+
+// Enum value maps for Stream.
+var (
+	Stream_name = map[int32]string{
+		0: "STREAM_UNSPECIFIED",
+		1: "STDOUT",
+		2: "STDERR",
+	}
+	Stream_value = map[string]int32{
+		"STREAM_UNSPECIFIED": 0,
+		"STDOUT":             1,
+		"STDERR":             2,
+	}
+)
+
+func (x Stream) Enum() *Stream {
+	p := new(Stream)
+	*p = x
+	return p
+}
+
+func (x Stream) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Stream) Descriptor() protoreflect.EnumDescriptor {
+	return file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_enumTypes[5].Descriptor()
+}
+
+func (Stream) Type() protoreflect.EnumType {
+	return &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_enumTypes[5]
+}
+
+func (x Stream) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Stream.Descriptor instead.
+func (Stream) EnumDescriptor() ([]byte, []int) {
+	return file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_rawDescGZIP(), []int{5}
 }
 
 // JobSpec describes a command to be run, along with optional resource limits
@@ -106,6 +387,37 @@ type JobSpec struct {
 
 	Command *CommandSpec    `protobuf:"bytes,1,opt,name=command,proto3" json:"command,omitempty"`
 	Limits  *ResourceLimits `protobuf:"bytes,2,opt,name=limits,proto3" json:"limits,omitempty"`
+	Output  *OutputSpec     `protobuf:"bytes,3,opt,name=output,proto3" json:"output,omitempty"`
+	Stop    *StopPolicy     `protobuf:"bytes,4,opt,name=stop,proto3" json:"stop,omitempty"`
+	// Limits how long the job may run. If the job is still running once the
+	// limit is reached, it is stopped according to its stop policy, and its
+	// termination status will have 'deadline_exceeded' set.
+	//
+	// Types that are assignable to MaxRuntime:
+	//	*JobSpec_Timeout
+	//	*JobSpec_Deadline
+	MaxRuntime isJobSpec_MaxRuntime `protobuf_oneof:"max_runtime"`
+	// Controls whether the job's process is restarted after it terminates. If
+	// not set, the process is never restarted.
+	Restart *RestartPolicy `protobuf:"bytes,7,opt,name=restart,proto3" json:"restart,omitempty"`
+	// Arbitrary key/value pairs that can be used to find the job with a label
+	// selector. Keys may contain up to 63 letters, digits, '-', '_', '.', or
+	// '/'. Values may be empty, or contain up to 63 letters, digits, '-', '_',
+	// or '.'. Both must start and end with a letter or digit. A job can have at
+	// most 64 labels.
+	Labels map[string]string `protobuf:"bytes,8,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// An optional name for the job, which must be unique among the jobs owned
+	// by the same user. Names may contain up to 63 letters, digits, '-', '_',
+	// or '.', must start and end with a letter or digit, and must not have the
+	// same format as a job id. Anywhere a job id is accepted, the name of one
+	// of the caller's own jobs can be used instead.
+	Name string `protobuf:"bytes,9,opt,name=name,proto3" json:"name,omitempty"`
+	// An optional key, chosen by the client, that identifies this request to
+	// start a job. If the same user has already started a job with the same
+	// key, Start returns the id of the existing job instead of starting a new
+	// one. This allows clients to safely retry Start after an error, without
+	// creating duplicate jobs. The key must not be longer than 256 bytes.
+	IdempotencyKey string `protobuf:"bytes,10,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
 }
 
 func (x *JobSpec) Reset() {
@@ -154,16 +466,116 @@ func (x *JobSpec) GetLimits() *ResourceLimits {
 	return nil
 }
 
-type JobId struct {
+func (x *JobSpec) GetOutput() *OutputSpec {
+	if x != nil {
+		return x.Output
+	}
+	return nil
+}
+
+func (x *JobSpec) GetStop() *StopPolicy {
+	if x != nil {
+		return x.Stop
+	}
+	return nil
+}
+
+func (m *JobSpec) GetMaxRuntime() isJobSpec_MaxRuntime {
+	if m != nil {
+		return m.MaxRuntime
+	}
+	return nil
+}
+
+func (x *JobSpec) GetTimeout() *durationpb.Duration {
+	if x, ok := x.GetMaxRuntime().(*JobSpec_Timeout); ok {
+		return x.Timeout
+	}
+	return nil
+}
+
+func (x *JobSpec) GetDeadline() *timestamppb.Timestamp {
+	if x, ok := x.GetMaxRuntime().(*JobSpec_Deadline); ok {
+		return x.Deadline
+	}
+	return nil
+}
+
+func (x *JobSpec) GetRestart() *RestartPolicy {
+	if x != nil {
+		return x.Restart
+	}
+	return nil
+}
+
+func (x *JobSpec) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *JobSpec) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *JobSpec) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+type isJobSpec_MaxRuntime interface {
+	isJobSpec_MaxRuntime()
+}
+
+type JobSpec_Timeout struct {
+	// The maximum amount of time the job may run, measured from when it
+	// started (or, if it was restarted, from when its current attempt
+	// started). Each attempt gets the full timeout. Must be greater than 0.
+	Timeout *durationpb.Duration `protobuf:"bytes,5,opt,name=timeout,proto3,oneof"`
+}
+
+type JobSpec_Deadline struct {
+	// The time at which the job should be stopped. The deadline applies to
+	// every attempt, and a job that reaches it is not restarted. Must not be
+	// in the past when the job is started.
+	Deadline *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=deadline,proto3,oneof"`
+}
+
+func (*JobSpec_Timeout) isJobSpec_MaxRuntime() {}
+
+func (*JobSpec_Deadline) isJobSpec_MaxRuntime() {}
+
+// RestartPolicy describes when a job's process should be restarted after it
+// terminates. Each time the process is restarted, it is run from the same
+// spec under the same job id, as a new attempt. Processes that were stopped
+// with Stop(), or that reached the job's timeout or deadline, are never
+// restarted.
+type RestartPolicy struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Mode RestartMode `protobuf:"varint,1,opt,name=mode,proto3,enum=job.v1.RestartMode" json:"mode,omitempty"`
+	// The maximum number of times the process is restarted. If not set, there
+	// is no limit.
+	MaxRetries *uint32 `protobuf:"varint,2,opt,name=max_retries,json=maxRetries,proto3,oneof" json:"max_retries,omitempty"`
+	// How long to wait before the first restart. The delay doubles with each
+	// subsequent restart, up to 'max_backoff'. If not set, a default of 1
+	// second is used.
+	InitialBackoff *durationpb.Duration `protobuf:"bytes,3,opt,name=initial_backoff,json=initialBackoff,proto3" json:"initial_backoff,omitempty"`
+	// The maximum delay between restarts. If not set, a default of 5 minutes
+	// is used.
+	MaxBackoff *durationpb.Duration `protobuf:"bytes,4,opt,name=max_backoff,json=maxBackoff,proto3" json:"max_backoff,omitempty"`
 }
 
-func (x *JobId) Reset() {
-	*x = JobId{}
+func (x *RestartPolicy) Reset() {
+	*x = RestartPolicy{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -171,13 +583,13 @@ func (x *JobId) Reset() {
 	}
 }
 
-func (x *JobId) String() string {
+func (x *RestartPolicy) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*JobId) ProtoMessage() {}
+func (*RestartPolicy) ProtoMessage() {}
 
-func (x *JobId) ProtoReflect() protoreflect.Message {
+func (x *RestartPolicy) ProtoReflect() protoreflect.Message {
 	mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -189,28 +601,56 @@ func (x *JobId) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use JobId.ProtoReflect.Descriptor instead.
-func (*JobId) Descriptor() ([]byte, []int) {
+// Deprecated: Use RestartPolicy.ProtoReflect.Descriptor instead.
+func (*RestartPolicy) Descriptor() ([]byte, []int) {
 	return file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *JobId) GetId() string {
+func (x *RestartPolicy) GetMode() RestartMode {
 	if x != nil {
-		return x.Id
+		return x.Mode
 	}
-	return ""
+	return RestartMode_NEVER
+}
+
+func (x *RestartPolicy) GetMaxRetries() uint32 {
+	if x != nil && x.MaxRetries != nil {
+		return *x.MaxRetries
+	}
+	return 0
+}
+
+func (x *RestartPolicy) GetInitialBackoff() *durationpb.Duration {
+	if x != nil {
+		return x.InitialBackoff
+	}
+	return nil
+}
+
+func (x *RestartPolicy) GetMaxBackoff() *durationpb.Duration {
+	if x != nil {
+		return x.MaxBackoff
+	}
+	return nil
 }
 
-type JobIdList struct {
+// StopPolicy describes how a job should be stopped.
+type StopPolicy struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Items []*JobId `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	// The signal sent to the job's main process to ask it to exit. If not set,
+	// SIGTERM is used.
+	Signal int32 `protobuf:"varint,1,opt,name=signal,proto3" json:"signal,omitempty"`
+	// How long to wait for the job's main process to exit after sending the
+	// stop signal, before killing every process in the job. If not set, a
+	// default of 10 seconds is used.
+	GracePeriod *durationpb.Duration `protobuf:"bytes,2,opt,name=grace_period,json=gracePeriod,proto3" json:"grace_period,omitempty"`
 }
 
-func (x *JobIdList) Reset() {
-	*x = JobIdList{}
+func (x *StopPolicy) Reset() {
+	*x = StopPolicy{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -218,13 +658,13 @@ func (x *JobIdList) Reset() {
 	}
 }
 
-func (x *JobIdList) String() string {
+func (x *StopPolicy) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*JobIdList) ProtoMessage() {}
+func (*StopPolicy) ProtoMessage() {}
 
-func (x *JobIdList) ProtoReflect() protoreflect.Message {
+func (x *StopPolicy) ProtoReflect() protoreflect.Message {
 	mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -236,42 +676,46 @@ func (x *JobIdList) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use JobIdList.ProtoReflect.Descriptor instead.
-func (*JobIdList) Descriptor() ([]byte, []int) {
+// Deprecated: Use StopPolicy.ProtoReflect.Descriptor instead.
+func (*StopPolicy) Descriptor() ([]byte, []int) {
 	return file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *JobIdList) GetItems() []*JobId {
+func (x *StopPolicy) GetSignal() int32 {
 	if x != nil {
-		return x.Items
+		return x.Signal
+	}
+	return 0
+}
+
+func (x *StopPolicy) GetGracePeriod() *durationpb.Duration {
+	if x != nil {
+		return x.GracePeriod
 	}
 	return nil
 }
 
-type JobStatus struct {
+type StopRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// The job's logical state.
-	State State `protobuf:"varint,1,opt,name=state,proto3,enum=job.v1.State" json:"state,omitempty"`
-	// The job's original spec.
-	Spec *JobSpec `protobuf:"bytes,2,opt,name=spec,proto3" json:"spec,omitempty"`
-	// A human-readable message describing the job's state, or an error message
-	// if the job failed.
-	Message string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
-	// The PID of the job's process. Only present if the job is in the Running
-	// or Terminated state.
-	Pid int32 `protobuf:"varint,4,opt,name=pid,proto3" json:"pid,omitempty"`
-	// The time at which the job was started. Only present if the job is
-	// in the Running or Terminated state.
-	StartTime *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
-	// Termination details. Only present if the job is in the Terminated state.
-	Terminated *TerminationStatus `protobuf:"bytes,6,opt,name=terminated,proto3" json:"terminated,omitempty"`
+	// The job to stop. Exactly one of 'id' or 'label_selector' must be set.
+	Id *JobId `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// If set, stops all jobs matching the selector. See ListRequest for the
+	// selector syntax.
+	LabelSelector string `protobuf:"bytes,5,opt,name=label_selector,json=labelSelector,proto3" json:"label_selector,omitempty"`
+	// If set, overrides the stop signal in the job's spec.
+	Signal int32 `protobuf:"varint,2,opt,name=signal,proto3" json:"signal,omitempty"`
+	// If set, overrides the grace period in the job's spec.
+	GracePeriod *durationpb.Duration `protobuf:"bytes,3,opt,name=grace_period,json=gracePeriod,proto3" json:"grace_period,omitempty"`
+	// If true, every process in the job is killed immediately, without sending
+	// the stop signal first.
+	Force bool `protobuf:"varint,4,opt,name=force,proto3" json:"force,omitempty"`
 }
 
-func (x *JobStatus) Reset() {
-	*x = JobStatus{}
+func (x *StopRequest) Reset() {
+	*x = StopRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -279,13 +723,13 @@ func (x *JobStatus) Reset() {
 	}
 }
 
-func (x *JobStatus) String() string {
+func (x *StopRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*JobStatus) ProtoMessage() {}
+func (*StopRequest) ProtoMessage() {}
 
-func (x *JobStatus) ProtoReflect() protoreflect.Message {
+func (x *StopRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -297,74 +741,57 @@ func (x *JobStatus) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use JobStatus.ProtoReflect.Descriptor instead.
-func (*JobStatus) Descriptor() ([]byte, []int) {
+// Deprecated: Use StopRequest.ProtoReflect.Descriptor instead.
+func (*StopRequest) Descriptor() ([]byte, []int) {
 	return file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *JobStatus) GetState() State {
+func (x *StopRequest) GetId() *JobId {
 	if x != nil {
-		return x.State
+		return x.Id
 	}
-	return State_UNKNOWN
+	return nil
 }
 
-func (x *JobStatus) GetSpec() *JobSpec {
+func (x *StopRequest) GetLabelSelector() string {
 	if x != nil {
-		return x.Spec
+		return x.LabelSelector
 	}
-	return nil
+	return ""
 }
 
-func (x *JobStatus) GetMessage() string {
+func (x *StopRequest) GetSignal() int32 {
 	if x != nil {
-		return x.Message
-	}
-	return ""
-}
-
-func (x *JobStatus) GetPid() int32 {
-	if x != nil {
-		return x.Pid
+		return x.Signal
 	}
 	return 0
 }
 
-func (x *JobStatus) GetStartTime() *timestamppb.Timestamp {
+func (x *StopRequest) GetGracePeriod() *durationpb.Duration {
 	if x != nil {
-		return x.StartTime
+		return x.GracePeriod
 	}
 	return nil
 }
 
-func (x *JobStatus) GetTerminated() *TerminationStatus {
+func (x *StopRequest) GetForce() bool {
 	if x != nil {
-		return x.Terminated
+		return x.Force
 	}
-	return nil
+	return false
 }
 
-// Contains details about the cause of the process's termination.
-type TerminationStatus struct {
+type StopResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// If the process exited normally, this will contain its exit code. Otherwise,
-	// if it was terminated by a signal, it will not be present.
-	ExitCode int32 `protobuf:"varint,1,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
-	// If the process was terminated by a signal, this will contain the signal
-	// number. Otherwise, it will not be present.
-	Signal int32 `protobuf:"varint,2,opt,name=signal,proto3" json:"signal,omitempty"`
-	// If the process was stopped by the user with the Stop() method, this will
-	// be true. Otherwise, it will be false.
-	Stopped bool `protobuf:"varint,3,opt,name=stopped,proto3" json:"stopped,omitempty"`
-	// The time at which the process was terminated.
-	Time *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=time,proto3" json:"time,omitempty"`
+	// The ids of the jobs that were stopped.
+	Stopped []*JobId `protobuf:"bytes,1,rep,name=stopped,proto3" json:"stopped,omitempty"`
 }
 
-func (x *TerminationStatus) Reset() {
-	*x = TerminationStatus{}
+func (x *StopResponse) Reset() {
+	*x = StopResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -372,13 +799,13 @@ func (x *TerminationStatus) Reset() {
 	}
 }
 
-func (x *TerminationStatus) String() string {
+func (x *StopResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TerminationStatus) ProtoMessage() {}
+func (*StopResponse) ProtoMessage() {}
 
-func (x *TerminationStatus) ProtoReflect() protoreflect.Message {
+func (x *StopResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -390,55 +817,28 @@ func (x *TerminationStatus) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TerminationStatus.ProtoReflect.Descriptor instead.
-func (*TerminationStatus) Descriptor() ([]byte, []int) {
+// Deprecated: Use StopResponse.ProtoReflect.Descriptor instead.
+func (*StopResponse) Descriptor() ([]byte, []int) {
 	return file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *TerminationStatus) GetExitCode() int32 {
-	if x != nil {
-		return x.ExitCode
-	}
-	return 0
-}
-
-func (x *TerminationStatus) GetSignal() int32 {
-	if x != nil {
-		return x.Signal
-	}
-	return 0
-}
-
-func (x *TerminationStatus) GetStopped() bool {
+func (x *StopResponse) GetStopped() []*JobId {
 	if x != nil {
 		return x.Stopped
 	}
-	return false
-}
-
-func (x *TerminationStatus) GetTime() *timestamppb.Timestamp {
-	if x != nil {
-		return x.Time
-	}
 	return nil
 }
 
-type CommandSpec struct {
+type JobId struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// The command name to run. Required.
-	Command string `protobuf:"bytes,1,opt,name=command,proto3" json:"command,omitempty"`
-	// The command's arguments, not containing the command name itself.
-	Args []string `protobuf:"bytes,2,rep,name=args,proto3" json:"args,omitempty"`
-	// Optional additional environment variables to set for the command.
-	// These will be merged with the job server's environment variables.
-	Env []string `protobuf:"bytes,3,rep,name=env,proto3" json:"env,omitempty"`
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 }
 
-func (x *CommandSpec) Reset() {
-	*x = CommandSpec{}
+func (x *JobId) Reset() {
+	*x = JobId{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -446,13 +846,13 @@ func (x *CommandSpec) Reset() {
 	}
 }
 
-func (x *CommandSpec) String() string {
+func (x *JobId) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CommandSpec) ProtoMessage() {}
+func (*JobId) ProtoMessage() {}
 
-func (x *CommandSpec) ProtoReflect() protoreflect.Message {
+func (x *JobId) ProtoReflect() protoreflect.Message {
 	mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -464,49 +864,54 @@ func (x *CommandSpec) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CommandSpec.ProtoReflect.Descriptor instead.
-func (*CommandSpec) Descriptor() ([]byte, []int) {
+// Deprecated: Use JobId.ProtoReflect.Descriptor instead.
+func (*JobId) Descriptor() ([]byte, []int) {
 	return file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *CommandSpec) GetCommand() string {
+func (x *JobId) GetId() string {
 	if x != nil {
-		return x.Command
+		return x.Id
 	}
 	return ""
 }
 
-func (x *CommandSpec) GetArgs() []string {
-	if x != nil {
-		return x.Args
-	}
-	return nil
-}
-
-func (x *CommandSpec) GetEnv() []string {
-	if x != nil {
-		return x.Env
-	}
-	return nil
-}
-
-type ProcessOutput struct {
+type ListRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// A chunk of output from the process's combined stdout and stderr streams.
-	//
-	// This is a raw byte stream, and may contain arbitrary binary data,
-	// depending on the command being run. It is up to the client to interpret
-	// the output correctly based on the command being run. No guarantees are
-	// made about the size of each chunk, or the frequency at which they
-	// are sent.
-	Output []byte `protobuf:"bytes,1,opt,name=output,proto3" json:"output,omitempty"`
+	// If not empty, only include jobs in one of the given states.
+	States []State `protobuf:"varint,1,rep,packed,name=states,proto3,enum=job.v1.State" json:"states,omitempty"`
+	// If set, only include jobs owned by the given user.
+	Owner *string `protobuf:"bytes,2,opt,name=owner,proto3,oneof" json:"owner,omitempty"`
+	// If set, only include jobs whose command line (the command followed by its
+	// arguments, separated by spaces) contains the given substring.
+	Command *string `protobuf:"bytes,3,opt,name=command,proto3,oneof" json:"command,omitempty"`
+	// If set, only include jobs that were started at or after the given time.
+	StartedAfter *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=started_after,json=startedAfter,proto3" json:"started_after,omitempty"`
+	// If set, only include jobs that were started before the given time.
+	StartedBefore *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=started_before,json=startedBefore,proto3" json:"started_before,omitempty"`
+	// If set, only include jobs started by the schedule with the given id.
+	ScheduleId *string `protobuf:"bytes,8,opt,name=schedule_id,json=scheduleId,proto3,oneof" json:"schedule_id,omitempty"`
+	// If set, only include jobs whose labels match the selector. A selector is
+	// a comma-separated list of requirements, all of which must match:
+	//  - 'key=value' or 'key==value': the label is set to the given value
+	//  - 'key!=value': the label is not set, or is set to a different value
+	//  - 'key': the label is set, to any value
+	//  - '!key': the label is not set
+	LabelSelector string `protobuf:"bytes,9,opt,name=label_selector,json=labelSelector,proto3" json:"label_selector,omitempty"`
+	// The maximum number of jobs to return. If 0, a default page size of 100 is
+	// used. Values larger than 1000 are reduced to 1000.
+	PageSize int32 `protobuf:"varint,6,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// A page token returned in a previous response, used to request the next
+	// page of results. The remaining fields of the request should be the same
+	// as in the request that returned the token.
+	PageToken string `protobuf:"bytes,7,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
 }
 
-func (x *ProcessOutput) Reset() {
-	*x = ProcessOutput{}
+func (x *ListRequest) Reset() {
+	*x = ListRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -514,13 +919,13 @@ func (x *ProcessOutput) Reset() {
 	}
 }
 
-func (x *ProcessOutput) String() string {
+func (x *ListRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ProcessOutput) ProtoMessage() {}
+func (*ListRequest) ProtoMessage() {}
 
-func (x *ProcessOutput) ProtoReflect() protoreflect.Message {
+func (x *ListRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -532,33 +937,87 @@ func (x *ProcessOutput) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ProcessOutput.ProtoReflect.Descriptor instead.
-func (*ProcessOutput) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListRequest.ProtoReflect.Descriptor instead.
+func (*ListRequest) Descriptor() ([]byte, []int) {
 	return file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *ProcessOutput) GetOutput() []byte {
+func (x *ListRequest) GetStates() []State {
 	if x != nil {
-		return x.Output
+		return x.States
 	}
 	return nil
 }
 
-type ResourceLimits struct {
+func (x *ListRequest) GetOwner() string {
+	if x != nil && x.Owner != nil {
+		return *x.Owner
+	}
+	return ""
+}
+
+func (x *ListRequest) GetCommand() string {
+	if x != nil && x.Command != nil {
+		return *x.Command
+	}
+	return ""
+}
+
+func (x *ListRequest) GetStartedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAfter
+	}
+	return nil
+}
+
+func (x *ListRequest) GetStartedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedBefore
+	}
+	return nil
+}
+
+func (x *ListRequest) GetScheduleId() string {
+	if x != nil && x.ScheduleId != nil {
+		return *x.ScheduleId
+	}
+	return ""
+}
+
+func (x *ListRequest) GetLabelSelector() string {
+	if x != nil {
+		return x.LabelSelector
+	}
+	return ""
+}
+
+func (x *ListRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type JobList struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Process CPU limit defined in milli-cores (1000 = 1 core/vcpu)
-	Cpu *int64 `protobuf:"varint,1,opt,name=cpu,proto3,oneof" json:"cpu,omitempty"`
-	// Process memory limit defined in bytes
-	Memory *MemoryLimits `protobuf:"bytes,2,opt,name=memory,proto3" json:"memory,omitempty"`
-	// Process IO limits for storage devices
-	Io []*IODeviceLimits `protobuf:"bytes,3,rep,name=io,proto3" json:"io,omitempty"`
+	Items []*JobInfo `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	// If there are more results, a token that can be used to request the next
+	// page. Otherwise, empty.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 }
 
-func (x *ResourceLimits) Reset() {
-	*x = ResourceLimits{}
+func (x *JobList) Reset() {
+	*x = JobList{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -566,13 +1025,13 @@ func (x *ResourceLimits) Reset() {
 	}
 }
 
-func (x *ResourceLimits) String() string {
+func (x *JobList) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ResourceLimits) ProtoMessage() {}
+func (*JobList) ProtoMessage() {}
 
-func (x *ResourceLimits) ProtoReflect() protoreflect.Message {
+func (x *JobList) ProtoReflect() protoreflect.Message {
 	mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -584,46 +1043,38 @@ func (x *ResourceLimits) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ResourceLimits.ProtoReflect.Descriptor instead.
-func (*ResourceLimits) Descriptor() ([]byte, []int) {
+// Deprecated: Use JobList.ProtoReflect.Descriptor instead.
+func (*JobList) Descriptor() ([]byte, []int) {
 	return file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *ResourceLimits) GetCpu() int64 {
-	if x != nil && x.Cpu != nil {
-		return *x.Cpu
-	}
-	return 0
-}
-
-func (x *ResourceLimits) GetMemory() *MemoryLimits {
+func (x *JobList) GetItems() []*JobInfo {
 	if x != nil {
-		return x.Memory
+		return x.Items
 	}
 	return nil
 }
 
-func (x *ResourceLimits) GetIo() []*IODeviceLimits {
+func (x *JobList) GetNextPageToken() string {
 	if x != nil {
-		return x.Io
+		return x.NextPageToken
 	}
-	return nil
+	return ""
 }
 
-type MemoryLimits struct {
+type JobInfo struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Memory soft limit defined in bytes. Processes exceeding the limit are
-	// not OOM-killed, but may be throttled by the kernel.
-	SoftLimit *int64 `protobuf:"varint,1,opt,name=soft_limit,json=softLimit,proto3,oneof" json:"soft_limit,omitempty"`
-	// Memory limit defined in bytes. If exceeded, the process will be OOM-killed.
-	Limit *int64 `protobuf:"varint,2,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
+	Id *JobId `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// The name of the user that started the job.
+	Owner  string     `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`
+	Status *JobStatus `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
 }
 
-func (x *MemoryLimits) Reset() {
-	*x = MemoryLimits{}
+func (x *JobInfo) Reset() {
+	*x = JobInfo{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -631,13 +1082,13 @@ func (x *MemoryLimits) Reset() {
 	}
 }
 
-func (x *MemoryLimits) String() string {
+func (x *JobInfo) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MemoryLimits) ProtoMessage() {}
+func (*JobInfo) ProtoMessage() {}
 
-func (x *MemoryLimits) ProtoReflect() protoreflect.Message {
+func (x *JobInfo) ProtoReflect() protoreflect.Message {
 	mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -649,38 +1100,43 @@ func (x *MemoryLimits) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MemoryLimits.ProtoReflect.Descriptor instead.
-func (*MemoryLimits) Descriptor() ([]byte, []int) {
+// Deprecated: Use JobInfo.ProtoReflect.Descriptor instead.
+func (*JobInfo) Descriptor() ([]byte, []int) {
 	return file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *MemoryLimits) GetSoftLimit() int64 {
-	if x != nil && x.SoftLimit != nil {
-		return *x.SoftLimit
+func (x *JobInfo) GetId() *JobId {
+	if x != nil {
+		return x.Id
 	}
-	return 0
+	return nil
 }
 
-func (x *MemoryLimits) GetLimit() int64 {
-	if x != nil && x.Limit != nil {
-		return *x.Limit
+func (x *JobInfo) GetOwner() string {
+	if x != nil {
+		return x.Owner
 	}
-	return 0
+	return ""
 }
 
-type IODeviceLimits struct {
+func (x *JobInfo) GetStatus() *JobStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+type WatchRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Device path (e.g. /dev/sda)
-	Device string `protobuf:"bytes,1,opt,name=device,proto3" json:"device,omitempty"`
-	// Limits for the device
-	Limits *IOLimits `protobuf:"bytes,2,opt,name=limits,proto3" json:"limits,omitempty"`
+	// The job to watch. If not set, all jobs visible to the caller are watched.
+	Id *JobId `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 }
 
-func (x *IODeviceLimits) Reset() {
-	*x = IODeviceLimits{}
+func (x *WatchRequest) Reset() {
+	*x = WatchRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -688,13 +1144,13 @@ func (x *IODeviceLimits) Reset() {
 	}
 }
 
-func (x *IODeviceLimits) String() string {
+func (x *WatchRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IODeviceLimits) ProtoMessage() {}
+func (*WatchRequest) ProtoMessage() {}
 
-func (x *IODeviceLimits) ProtoReflect() protoreflect.Message {
+func (x *WatchRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -706,42 +1162,31 @@ func (x *IODeviceLimits) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IODeviceLimits.ProtoReflect.Descriptor instead.
-func (*IODeviceLimits) Descriptor() ([]byte, []int) {
+// Deprecated: Use WatchRequest.ProtoReflect.Descriptor instead.
+func (*WatchRequest) Descriptor() ([]byte, []int) {
 	return file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *IODeviceLimits) GetDevice() string {
-	if x != nil {
-		return x.Device
-	}
-	return ""
-}
-
-func (x *IODeviceLimits) GetLimits() *IOLimits {
+func (x *WatchRequest) GetId() *JobId {
 	if x != nil {
-		return x.Limits
+		return x.Id
 	}
 	return nil
 }
 
-type IOLimits struct {
+type WatchEvent struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Limit for read operations in bytes per second
-	ReadBps *int64 `protobuf:"varint,2,opt,name=read_bps,json=readBps,proto3,oneof" json:"read_bps,omitempty"`
-	// Limit for write operations in bytes per second
-	WriteBps *int64 `protobuf:"varint,3,opt,name=write_bps,json=writeBps,proto3,oneof" json:"write_bps,omitempty"`
-	// Limit for read operations in IOPS
-	ReadIops *int64 `protobuf:"varint,4,opt,name=read_iops,json=readIops,proto3,oneof" json:"read_iops,omitempty"`
-	// Limit for write operations in IOPS
-	WriteIops *int64 `protobuf:"varint,5,opt,name=write_iops,json=writeIops,proto3,oneof" json:"write_iops,omitempty"`
+	// The id of the job whose status changed.
+	Id *JobId `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// The job's new status.
+	Status *JobStatus `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
 }
 
-func (x *IOLimits) Reset() {
-	*x = IOLimits{}
+func (x *WatchEvent) Reset() {
+	*x = WatchEvent{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -749,13 +1194,13 @@ func (x *IOLimits) Reset() {
 	}
 }
 
-func (x *IOLimits) String() string {
+func (x *WatchEvent) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IOLimits) ProtoMessage() {}
+func (*WatchEvent) ProtoMessage() {}
 
-func (x *IOLimits) ProtoReflect() protoreflect.Message {
+func (x *WatchEvent) ProtoReflect() protoreflect.Message {
 	mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -767,16 +1212,2218 @@ func (x *IOLimits) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IOLimits.ProtoReflect.Descriptor instead.
-func (*IOLimits) Descriptor() ([]byte, []int) {
+// Deprecated: Use WatchEvent.ProtoReflect.Descriptor instead.
+func (*WatchEvent) Descriptor() ([]byte, []int) {
 	return file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *IOLimits) GetReadBps() int64 {
-	if x != nil && x.ReadBps != nil {
-		return *x.ReadBps
+func (x *WatchEvent) GetId() *JobId {
+	if x != nil {
+		return x.Id
 	}
-	return 0
+	return nil
+}
+
+func (x *WatchEvent) GetStatus() *JobStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+type JobStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The job's logical state.
+	State State `protobuf:"varint,1,opt,name=state,proto3,enum=job.v1.State" json:"state,omitempty"`
+	// The job's original spec.
+	Spec *JobSpec `protobuf:"bytes,2,opt,name=spec,proto3" json:"spec,omitempty"`
+	// A human-readable message describing the job's state, or an error message
+	// if the job failed.
+	Message string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	// The PID of the job's process. Only present if the job is in the Running
+	// or Terminated state.
+	Pid int32 `protobuf:"varint,4,opt,name=pid,proto3" json:"pid,omitempty"`
+	// The time at which the job was started. Only present if the job is
+	// in the Running or Terminated state.
+	StartTime *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	// Termination details. Only present if the job is in the Terminated state.
+	Terminated *TerminationStatus `protobuf:"bytes,6,opt,name=terminated,proto3" json:"terminated,omitempty"`
+	// Signals sent to the job by users with the Signal() method, in the order
+	// in which they were delivered.
+	Signals []*SignalEvent `protobuf:"bytes,7,rep,name=signals,proto3" json:"signals,omitempty"`
+	// The number of the job's current attempt, starting at 0. It is
+	// incremented each time the job's process is restarted according to its
+	// restart policy. The fields above describe the current attempt.
+	Attempt uint32 `protobuf:"varint,8,opt,name=attempt,proto3" json:"attempt,omitempty"`
+	// The status of the job's previous attempts, oldest first. Only the most
+	// recent attempts are kept.
+	PreviousAttempts []*AttemptStatus `protobuf:"bytes,9,rep,name=previous_attempts,json=previousAttempts,proto3" json:"previous_attempts,omitempty"`
+	// The time at which the job's process will be restarted. Only present if
+	// the job is in the Restarting state.
+	NextRestartTime *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=next_restart_time,json=nextRestartTime,proto3" json:"next_restart_time,omitempty"`
+	// The job's position in the server's admission queue, starting at 1 for
+	// the next job to be started. Only present if the job is in the Pending
+	// state and is waiting for other jobs to complete.
+	QueuePosition uint32 `protobuf:"varint,11,opt,name=queue_position,json=queuePosition,proto3" json:"queue_position,omitempty"`
+	// The id of the schedule that started the job. Only present if the job was
+	// started by a schedule.
+	ScheduleId string `protobuf:"bytes,12,opt,name=schedule_id,json=scheduleId,proto3" json:"schedule_id,omitempty"`
+	// The resource limits currently applied to the job. These are the limits
+	// in the job's spec, unless they were changed with UpdateLimits().
+	Limits *ResourceLimits `protobuf:"bytes,13,opt,name=limits,proto3" json:"limits,omitempty"`
+	// The number of times the current attempt failed to create a process
+	// because it reached its process limit (the 'max' count in pids.events).
+	PidsLimitHits uint64 `protobuf:"varint,14,opt,name=pids_limit_hits,json=pidsLimitHits,proto3" json:"pids_limit_hits,omitempty"`
+}
+
+func (x *JobStatus) Reset() {
+	*x = JobStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JobStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobStatus) ProtoMessage() {}
+
+func (x *JobStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobStatus.ProtoReflect.Descriptor instead.
+func (*JobStatus) Descriptor() ([]byte, []int) {
+	return file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *JobStatus) GetState() State {
+	if x != nil {
+		return x.State
+	}
+	return State_UNKNOWN
+}
+
+func (x *JobStatus) GetSpec() *JobSpec {
+	if x != nil {
+		return x.Spec
+	}
+	return nil
+}
+
+func (x *JobStatus) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *JobStatus) GetPid() int32 {
+	if x != nil {
+		return x.Pid
+	}
+	return 0
+}
+
+func (x *JobStatus) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *JobStatus) GetTerminated() *TerminationStatus {
+	if x != nil {
+		return x.Terminated
+	}
+	return nil
+}
+
+func (x *JobStatus) GetSignals() []*SignalEvent {
+	if x != nil {
+		return x.Signals
+	}
+	return nil
+}
+
+func (x *JobStatus) GetAttempt() uint32 {
+	if x != nil {
+		return x.Attempt
+	}
+	return 0
+}
+
+func (x *JobStatus) GetPreviousAttempts() []*AttemptStatus {
+	if x != nil {
+		return x.PreviousAttempts
+	}
+	return nil
+}
+
+func (x *JobStatus) GetNextRestartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextRestartTime
+	}
+	return nil
+}
+
+func (x *JobStatus) GetQueuePosition() uint32 {
+	if x != nil {
+		return x.QueuePosition
+	}
+	return 0
+}
+
+func (x *JobStatus) GetScheduleId() string {
+	if x != nil {
+		return x.ScheduleId
+	}
+	return ""
+}
+
+func (x *JobStatus) GetLimits() *ResourceLimits {
+	if x != nil {
+		return x.Limits
+	}
+	return nil
+}
+
+func (x *JobStatus) GetPidsLimitHits() uint64 {
+	if x != nil {
+		return x.PidsLimitHits
+	}
+	return 0
+}
+
+// AttemptStatus is the final status of one of a job's previous attempts.
+type AttemptStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The number of the attempt, starting at 0.
+	Attempt uint32 `protobuf:"varint,1,opt,name=attempt,proto3" json:"attempt,omitempty"`
+	// The state of the attempt (Failed or Terminated).
+	State State `protobuf:"varint,2,opt,name=state,proto3,enum=job.v1.State" json:"state,omitempty"`
+	// A human-readable message describing the attempt's state.
+	Message string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	// The PID of the attempt's process, if it was started.
+	Pid int32 `protobuf:"varint,4,opt,name=pid,proto3" json:"pid,omitempty"`
+	// The time at which the attempt's process was started, if it was started.
+	StartTime *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	// Termination details, if the attempt's process was started.
+	Terminated *TerminationStatus `protobuf:"bytes,6,opt,name=terminated,proto3" json:"terminated,omitempty"`
+	// The number of times the attempt failed to create a process because it
+	// reached its process limit.
+	PidsLimitHits uint64 `protobuf:"varint,7,opt,name=pids_limit_hits,json=pidsLimitHits,proto3" json:"pids_limit_hits,omitempty"`
+}
+
+func (x *AttemptStatus) Reset() {
+	*x = AttemptStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AttemptStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttemptStatus) ProtoMessage() {}
+
+func (x *AttemptStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttemptStatus.ProtoReflect.Descriptor instead.
+func (*AttemptStatus) Descriptor() ([]byte, []int) {
+	return file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *AttemptStatus) GetAttempt() uint32 {
+	if x != nil {
+		return x.Attempt
+	}
+	return 0
+}
+
+func (x *AttemptStatus) GetState() State {
+	if x != nil {
+		return x.State
+	}
+	return State_UNKNOWN
+}
+
+func (x *AttemptStatus) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *AttemptStatus) GetPid() int32 {
+	if x != nil {
+		return x.Pid
+	}
+	return 0
+}
+
+func (x *AttemptStatus) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *AttemptStatus) GetTerminated() *TerminationStatus {
+	if x != nil {
+		return x.Terminated
+	}
+	return nil
+}
+
+func (x *AttemptStatus) GetPidsLimitHits() uint64 {
+	if x != nil {
+		return x.PidsLimitHits
+	}
+	return 0
+}
+
+// ScheduleSpec describes a job that should be started periodically.
+type ScheduleSpec struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// A cron expression with five fields: minute, hour, day of month, month,
+	// and day of week (for example, '*/15 9-17 * * mon-fri'). The macros
+	// @yearly, @monthly, @weekly, @daily, and @hourly can be used instead.
+	// Required.
+	Cron string `protobuf:"bytes,1,opt,name=cron,proto3" json:"cron,omitempty"`
+	// The spec of each job started by the schedule. Required. The template
+	// must not set a deadline; use a timeout instead.
+	JobTemplate *JobSpec `protobuf:"bytes,2,opt,name=job_template,json=jobTemplate,proto3" json:"job_template,omitempty"`
+	// Controls what happens when the schedule fires while jobs it started
+	// earlier are still running.
+	ConcurrencyPolicy ConcurrencyPolicy `protobuf:"varint,3,opt,name=concurrency_policy,json=concurrencyPolicy,proto3,enum=job.v1.ConcurrencyPolicy" json:"concurrency_policy,omitempty"`
+	// The number of completed jobs started by the schedule to keep. Once
+	// exceeded, the oldest completed jobs are deleted. If 0, completed jobs are
+	// only deleted according to the server's retention policy.
+	HistoryLimit uint32 `protobuf:"varint,4,opt,name=history_limit,json=historyLimit,proto3" json:"history_limit,omitempty"`
+}
+
+func (x *ScheduleSpec) Reset() {
+	*x = ScheduleSpec{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ScheduleSpec) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleSpec) ProtoMessage() {}
+
+func (x *ScheduleSpec) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleSpec.ProtoReflect.Descriptor instead.
+func (*ScheduleSpec) Descriptor() ([]byte, []int) {
+	return file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ScheduleSpec) GetCron() string {
+	if x != nil {
+		return x.Cron
+	}
+	return ""
+}
+
+func (x *ScheduleSpec) GetJobTemplate() *JobSpec {
+	if x != nil {
+		return x.JobTemplate
+	}
+	return nil
+}
+
+func (x *ScheduleSpec) GetConcurrencyPolicy() ConcurrencyPolicy {
+	if x != nil {
+		return x.ConcurrencyPolicy
+	}
+	return ConcurrencyPolicy_ALLOW
+}
+
+func (x *ScheduleSpec) GetHistoryLimit() uint32 {
+	if x != nil {
+		return x.HistoryLimit
+	}
+	return 0
+}
+
+type ScheduleId struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *ScheduleId) Reset() {
+	*x = ScheduleId{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ScheduleId) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleId) ProtoMessage() {}
+
+func (x *ScheduleId) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleId.ProtoReflect.Descriptor instead.
+func (*ScheduleId) Descriptor() ([]byte, []int) {
+	return file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ScheduleId) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListSchedulesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// If set, only include schedules owned by the given user.
+	Owner *string `protobuf:"bytes,1,opt,name=owner,proto3,oneof" json:"owner,omitempty"`
+}
+
+func (x *ListSchedulesRequest) Reset() {
+	*x = ListSchedulesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListSchedulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSchedulesRequest) ProtoMessage() {}
+
+func (x *ListSchedulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSchedulesRequest.ProtoReflect.Descriptor instead.
+func (*ListSchedulesRequest) Descriptor() ([]byte, []int) {
+	return file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListSchedulesRequest) GetOwner() string {
+	if x != nil && x.Owner != nil {
+		return *x.Owner
+	}
+	return ""
+}
+
+type ScheduleList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Items []*ScheduleInfo `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+}
+
+func (x *ScheduleList) Reset() {
+	*x = ScheduleList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ScheduleList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleList) ProtoMessage() {}
+
+func (x *ScheduleList) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleList.ProtoReflect.Descriptor instead.
+func (*ScheduleList) Descriptor() ([]byte, []int) {
+	return file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ScheduleList) GetItems() []*ScheduleInfo {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type ScheduleInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id *ScheduleId `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// The name of the user that created the schedule.
+	Owner string        `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`
+	Spec  *ScheduleSpec `protobuf:"bytes,3,opt,name=spec,proto3" json:"spec,omitempty"`
+	// The last time at which the schedule fired, if any.
+	LastScheduleTime *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=last_schedule_time,json=lastScheduleTime,proto3" json:"last_schedule_time,omitempty"`
+	// The next time at which the schedule will fire. Not present if the
+	// schedule will never fire again.
+	NextScheduleTime *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=next_schedule_time,json=nextScheduleTime,proto3" json:"next_schedule_time,omitempty"`
+	// The jobs started by the schedule that have not yet completed.
+	ActiveJobs []*JobId `protobuf:"bytes,6,rep,name=active_jobs,json=activeJobs,proto3" json:"active_jobs,omitempty"`
+	// A human-readable message describing the outcome of the last time the
+	// schedule fired.
+	Message string `protobuf:"bytes,7,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *ScheduleInfo) Reset() {
+	*x = ScheduleInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ScheduleInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleInfo) ProtoMessage() {}
+
+func (x *ScheduleInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleInfo.ProtoReflect.Descriptor instead.
+func (*ScheduleInfo) Descriptor() ([]byte, []int) {
+	return file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ScheduleInfo) GetId() *ScheduleId {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *ScheduleInfo) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *ScheduleInfo) GetSpec() *ScheduleSpec {
+	if x != nil {
+		return x.Spec
+	}
+	return nil
+}
+
+func (x *ScheduleInfo) GetLastScheduleTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastScheduleTime
+	}
+	return nil
+}
+
+func (x *ScheduleInfo) GetNextScheduleTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextScheduleTime
+	}
+	return nil
+}
+
+func (x *ScheduleInfo) GetActiveJobs() []*JobId {
+	if x != nil {
+		return x.ActiveJobs
+	}
+	return nil
+}
+
+func (x *ScheduleInfo) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// WorkflowSpec describes a set of jobs with dependencies on each other.
+type WorkflowSpec struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The jobs in the workflow. At least one job is required.
+	Jobs []*WorkflowJob `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"`
+}
+
+func (x *WorkflowSpec) Reset() {
+	*x = WorkflowSpec{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WorkflowSpec) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkflowSpec) ProtoMessage() {}
+
+func (x *WorkflowSpec) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkflowSpec.ProtoReflect.Descriptor instead.
+func (*WorkflowSpec) Descriptor() ([]byte, []int) {
+	return file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *WorkflowSpec) GetJobs() []*WorkflowJob {
+	if x != nil {
+		return x.Jobs
+	}
+	return nil
+}
+
+type WorkflowJob struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The name of the job, which must be unique within the workflow. Names may
+	// contain up to 63 letters, digits, '-', '_', or '.', and must start and
+	// end with a letter or digit. Required.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The names of the jobs that must complete successfully before this job
+	// is started.
+	DependsOn []string `protobuf:"bytes,2,rep,name=depends_on,json=dependsOn,proto3" json:"depends_on,omitempty"`
+	// The job's spec. Required.
+	Spec *JobSpec `protobuf:"bytes,3,opt,name=spec,proto3" json:"spec,omitempty"`
+}
+
+func (x *WorkflowJob) Reset() {
+	*x = WorkflowJob{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WorkflowJob) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkflowJob) ProtoMessage() {}
+
+func (x *WorkflowJob) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkflowJob.ProtoReflect.Descriptor instead.
+func (*WorkflowJob) Descriptor() ([]byte, []int) {
+	return file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *WorkflowJob) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *WorkflowJob) GetDependsOn() []string {
+	if x != nil {
+		return x.DependsOn
+	}
+	return nil
+}
+
+func (x *WorkflowJob) GetSpec() *JobSpec {
+	if x != nil {
+		return x.Spec
+	}
+	return nil
+}
+
+type WorkflowId struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *WorkflowId) Reset() {
+	*x = WorkflowId{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WorkflowId) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkflowId) ProtoMessage() {}
+
+func (x *WorkflowId) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkflowId.ProtoReflect.Descriptor instead.
+func (*WorkflowId) Descriptor() ([]byte, []int) {
+	return file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *WorkflowId) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type WorkflowStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	State WorkflowState `protobuf:"varint,1,opt,name=state,proto3,enum=job.v1.WorkflowState" json:"state,omitempty"`
+	// The name of the user that started the workflow.
+	Owner string `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`
+	// The state of each of the workflow's jobs, in the order in which they
+	// appear in the workflow's spec.
+	Jobs []*WorkflowJobStatus `protobuf:"bytes,3,rep,name=jobs,proto3" json:"jobs,omitempty"`
+}
+
+func (x *WorkflowStatus) Reset() {
+	*x = WorkflowStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WorkflowStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkflowStatus) ProtoMessage() {}
+
+func (x *WorkflowStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkflowStatus.ProtoReflect.Descriptor instead.
+func (*WorkflowStatus) Descriptor() ([]byte, []int) {
+	return file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *WorkflowStatus) GetState() WorkflowState {
+	if x != nil {
+		return x.State
+	}
+	return WorkflowState_WORKFLOW_RUNNING
+}
+
+func (x *WorkflowStatus) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *WorkflowStatus) GetJobs() []*WorkflowJobStatus {
+	if x != nil {
+		return x.Jobs
+	}
+	return nil
+}
+
+type WorkflowJobStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The name of the job within the workflow.
+	Name  string           `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	State WorkflowJobState `protobuf:"varint,2,opt,name=state,proto3,enum=job.v1.WorkflowJobState" json:"state,omitempty"`
+	// The id of the job. Only present once the job has been started.
+	Id *JobId `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"`
+	// A human-readable message describing the job's state.
+	Message string `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *WorkflowJobStatus) Reset() {
+	*x = WorkflowJobStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WorkflowJobStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkflowJobStatus) ProtoMessage() {}
+
+func (x *WorkflowJobStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkflowJobStatus.ProtoReflect.Descriptor instead.
+func (*WorkflowJobStatus) Descriptor() ([]byte, []int) {
+	return file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *WorkflowJobStatus) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *WorkflowJobStatus) GetState() WorkflowJobState {
+	if x != nil {
+		return x.State
+	}
+	return WorkflowJobState_WORKFLOW_JOB_WAITING
+}
+
+func (x *WorkflowJobStatus) GetId() *JobId {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *WorkflowJobStatus) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type SignalRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id *JobId `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// The signal number to send (e.g. 1 for SIGHUP).
+	Signal int32 `protobuf:"varint,2,opt,name=signal,proto3" json:"signal,omitempty"`
+	// If true, the signal is sent to every process in the job, instead of only
+	// the job's main process.
+	AllProcesses bool `protobuf:"varint,3,opt,name=all_processes,json=allProcesses,proto3" json:"all_processes,omitempty"`
+}
+
+func (x *SignalRequest) Reset() {
+	*x = SignalRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignalRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignalRequest) ProtoMessage() {}
+
+func (x *SignalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignalRequest.ProtoReflect.Descriptor instead.
+func (*SignalRequest) Descriptor() ([]byte, []int) {
+	return file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *SignalRequest) GetId() *JobId {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *SignalRequest) GetSignal() int32 {
+	if x != nil {
+		return x.Signal
+	}
+	return 0
+}
+
+func (x *SignalRequest) GetAllProcesses() bool {
+	if x != nil {
+		return x.AllProcesses
+	}
+	return false
+}
+
+type UpdateLimitsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id *JobId `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// The limits to change. At least one limit must be set.
+	Limits *ResourceLimits `protobuf:"bytes,2,opt,name=limits,proto3" json:"limits,omitempty"`
+}
+
+func (x *UpdateLimitsRequest) Reset() {
+	*x = UpdateLimitsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateLimitsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateLimitsRequest) ProtoMessage() {}
+
+func (x *UpdateLimitsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateLimitsRequest.ProtoReflect.Descriptor instead.
+func (*UpdateLimitsRequest) Descriptor() ([]byte, []int) {
+	return file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *UpdateLimitsRequest) GetId() *JobId {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *UpdateLimitsRequest) GetLimits() *ResourceLimits {
+	if x != nil {
+		return x.Limits
+	}
+	return nil
+}
+
+// SignalEvent records a signal sent to a job by a user.
+type SignalEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The signal number.
+	Signal int32 `protobuf:"varint,1,opt,name=signal,proto3" json:"signal,omitempty"`
+	// Whether the signal was sent to every process in the job.
+	AllProcesses bool `protobuf:"varint,2,opt,name=all_processes,json=allProcesses,proto3" json:"all_processes,omitempty"`
+	// The name of the user that sent the signal.
+	User string `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`
+	// The time at which the signal was delivered.
+	Time *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=time,proto3" json:"time,omitempty"`
+}
+
+func (x *SignalEvent) Reset() {
+	*x = SignalEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignalEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignalEvent) ProtoMessage() {}
+
+func (x *SignalEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignalEvent.ProtoReflect.Descriptor instead.
+func (*SignalEvent) Descriptor() ([]byte, []int) {
+	return file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *SignalEvent) GetSignal() int32 {
+	if x != nil {
+		return x.Signal
+	}
+	return 0
+}
+
+func (x *SignalEvent) GetAllProcesses() bool {
+	if x != nil {
+		return x.AllProcesses
+	}
+	return false
+}
+
+func (x *SignalEvent) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+func (x *SignalEvent) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+// Contains details about the cause of the process's termination.
+type TerminationStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// If the process exited normally, this will contain its exit code. Otherwise,
+	// if it was terminated by a signal, it will not be present.
+	ExitCode int32 `protobuf:"varint,1,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	// If the process was terminated by a signal, this will contain the signal
+	// number. Otherwise, it will not be present.
+	Signal int32 `protobuf:"varint,2,opt,name=signal,proto3" json:"signal,omitempty"`
+	// If the process was stopped by the user with the Stop() method, this will
+	// be true. Otherwise, it will be false.
+	Stopped bool `protobuf:"varint,3,opt,name=stopped,proto3" json:"stopped,omitempty"`
+	// The time at which the process was terminated.
+	Time *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=time,proto3" json:"time,omitempty"`
+	// If the process was stopped because it reached the timeout or deadline
+	// in its spec, this will be true. Otherwise, it will be false.
+	DeadlineExceeded bool `protobuf:"varint,5,opt,name=deadline_exceeded,json=deadlineExceeded,proto3" json:"deadline_exceeded,omitempty"`
+}
+
+func (x *TerminationStatus) Reset() {
+	*x = TerminationStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TerminationStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TerminationStatus) ProtoMessage() {}
+
+func (x *TerminationStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TerminationStatus.ProtoReflect.Descriptor instead.
+func (*TerminationStatus) Descriptor() ([]byte, []int) {
+	return file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *TerminationStatus) GetExitCode() int32 {
+	if x != nil {
+		return x.ExitCode
+	}
+	return 0
+}
+
+func (x *TerminationStatus) GetSignal() int32 {
+	if x != nil {
+		return x.Signal
+	}
+	return 0
+}
+
+func (x *TerminationStatus) GetStopped() bool {
+	if x != nil {
+		return x.Stopped
+	}
+	return false
+}
+
+func (x *TerminationStatus) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *TerminationStatus) GetDeadlineExceeded() bool {
+	if x != nil {
+		return x.DeadlineExceeded
+	}
+	return false
+}
+
+type CommandSpec struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The command name to run. Required.
+	Command string `protobuf:"bytes,1,opt,name=command,proto3" json:"command,omitempty"`
+	// The command's arguments, not containing the command name itself.
+	Args []string `protobuf:"bytes,2,rep,name=args,proto3" json:"args,omitempty"`
+	// Optional additional environment variables to set for the command.
+	// These will be merged with the job server's environment variables.
+	Env []string `protobuf:"bytes,3,rep,name=env,proto3" json:"env,omitempty"`
+	// If true, the command's stdin will be kept open, and input can be sent to
+	// it using the Attach() method. Otherwise, the command's stdin is connected
+	// to the null device.
+	Stdin bool `protobuf:"varint,4,opt,name=stdin,proto3" json:"stdin,omitempty"`
+	// If true, the command is run in a new session with a pseudo-terminal as
+	// its controlling terminal. The command's stdin, stdout, and stderr are all
+	// connected to the terminal, so its output is not split into stdout and
+	// stderr. Input can be sent to the terminal using the Attach() method, as
+	// if 'stdin' were also set. Closing stdin sends an end-of-file character
+	// (Ctrl-D) to the terminal instead of closing it.
+	Tty bool `protobuf:"varint,5,opt,name=tty,proto3" json:"tty,omitempty"`
+	// The working directory of the command. Must be an absolute path if set.
+	// Otherwise, the command is run in the job server's working directory.
+	Workdir string `protobuf:"bytes,6,opt,name=workdir,proto3" json:"workdir,omitempty"`
+	// The user and groups to run the command as. Otherwise, the command is run
+	// as the same user as the job server. The identity must be allowed by the
+	// server's RBAC configuration.
+	Credential *Credential `protobuf:"bytes,7,opt,name=credential,proto3" json:"credential,omitempty"`
+	// The file mode creation mask of the command (for example, 0o022). If not
+	// set, the command inherits the job server's umask. The umask is set by
+	// the job server's own executable before it executes the command, so it
+	// must be executable by the user the command runs as.
+	Umask *uint32 `protobuf:"varint,8,opt,name=umask,proto3,oneof" json:"umask,omitempty"`
+}
+
+func (x *CommandSpec) Reset() {
+	*x = CommandSpec{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CommandSpec) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommandSpec) ProtoMessage() {}
+
+func (x *CommandSpec) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommandSpec.ProtoReflect.Descriptor instead.
+func (*CommandSpec) Descriptor() ([]byte, []int) {
+	return file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *CommandSpec) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *CommandSpec) GetArgs() []string {
+	if x != nil {
+		return x.Args
+	}
+	return nil
+}
+
+func (x *CommandSpec) GetEnv() []string {
+	if x != nil {
+		return x.Env
+	}
+	return nil
+}
+
+func (x *CommandSpec) GetStdin() bool {
+	if x != nil {
+		return x.Stdin
+	}
+	return false
+}
+
+func (x *CommandSpec) GetTty() bool {
+	if x != nil {
+		return x.Tty
+	}
+	return false
+}
+
+func (x *CommandSpec) GetWorkdir() string {
+	if x != nil {
+		return x.Workdir
+	}
+	return ""
+}
+
+func (x *CommandSpec) GetCredential() *Credential {
+	if x != nil {
+		return x.Credential
+	}
+	return nil
+}
+
+func (x *CommandSpec) GetUmask() uint32 {
+	if x != nil && x.Umask != nil {
+		return *x.Umask
+	}
+	return 0
+}
+
+// Credential is the identity that a command is run as.
+type Credential struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The user id.
+	Uid uint32 `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	// The primary group id.
+	Gid uint32 `protobuf:"varint,2,opt,name=gid,proto3" json:"gid,omitempty"`
+	// Supplementary group ids. If empty, the command has no supplementary
+	// groups.
+	Groups []uint32 `protobuf:"varint,3,rep,packed,name=groups,proto3" json:"groups,omitempty"`
+}
+
+func (x *Credential) Reset() {
+	*x = Credential{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Credential) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Credential) ProtoMessage() {}
+
+func (x *Credential) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Credential.ProtoReflect.Descriptor instead.
+func (*Credential) Descriptor() ([]byte, []int) {
+	return file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *Credential) GetUid() uint32 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *Credential) GetGid() uint32 {
+	if x != nil {
+		return x.Gid
+	}
+	return 0
+}
+
+func (x *Credential) GetGroups() []uint32 {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+// TerminalSize is the size of a terminal, in characters.
+type TerminalSize struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rows uint32 `protobuf:"varint,1,opt,name=rows,proto3" json:"rows,omitempty"`
+	Cols uint32 `protobuf:"varint,2,opt,name=cols,proto3" json:"cols,omitempty"`
+}
+
+func (x *TerminalSize) Reset() {
+	*x = TerminalSize{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TerminalSize) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TerminalSize) ProtoMessage() {}
+
+func (x *TerminalSize) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TerminalSize.ProtoReflect.Descriptor instead.
+func (*TerminalSize) Descriptor() ([]byte, []int) {
+	return file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *TerminalSize) GetRows() uint32 {
+	if x != nil {
+		return x.Rows
+	}
+	return 0
+}
+
+func (x *TerminalSize) GetCols() uint32 {
+	if x != nil {
+		return x.Cols
+	}
+	return 0
+}
+
+type AttachRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Request:
+	//	*AttachRequest_Id
+	//	*AttachRequest_Input
+	//	*AttachRequest_CloseStdin
+	//	*AttachRequest_Resize
+	Request isAttachRequest_Request `protobuf_oneof:"request"`
+}
+
+func (x *AttachRequest) Reset() {
+	*x = AttachRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AttachRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttachRequest) ProtoMessage() {}
+
+func (x *AttachRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttachRequest.ProtoReflect.Descriptor instead.
+func (*AttachRequest) Descriptor() ([]byte, []int) {
+	return file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_rawDescGZIP(), []int{30}
+}
+
+func (m *AttachRequest) GetRequest() isAttachRequest_Request {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+func (x *AttachRequest) GetId() *JobId {
+	if x, ok := x.GetRequest().(*AttachRequest_Id); ok {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *AttachRequest) GetInput() []byte {
+	if x, ok := x.GetRequest().(*AttachRequest_Input); ok {
+		return x.Input
+	}
+	return nil
+}
+
+func (x *AttachRequest) GetCloseStdin() bool {
+	if x, ok := x.GetRequest().(*AttachRequest_CloseStdin); ok {
+		return x.CloseStdin
+	}
+	return false
+}
+
+func (x *AttachRequest) GetResize() *TerminalSize {
+	if x, ok := x.GetRequest().(*AttachRequest_Resize); ok {
+		return x.Resize
+	}
+	return nil
+}
+
+type isAttachRequest_Request interface {
+	isAttachRequest_Request()
+}
+
+type AttachRequest_Id struct {
+	// The job to attach to. Must be set in the first message on the stream,
+	// and only in the first message.
+	Id *JobId `protobuf:"bytes,1,opt,name=id,proto3,oneof"`
+}
+
+type AttachRequest_Input struct {
+	// Input to write to the job's stdin.
+	Input []byte `protobuf:"bytes,2,opt,name=input,proto3,oneof"`
+}
+
+type AttachRequest_CloseStdin struct {
+	// If true, closes the job's stdin. Any input sent afterwards is rejected.
+	CloseStdin bool `protobuf:"varint,3,opt,name=close_stdin,json=closeStdin,proto3,oneof"`
+}
+
+type AttachRequest_Resize struct {
+	// Resizes the job's terminal. Only valid for jobs started with the 'tty'
+	// option enabled in their command spec.
+	Resize *TerminalSize `protobuf:"bytes,4,opt,name=resize,proto3,oneof"`
+}
+
+func (*AttachRequest_Id) isAttachRequest_Request() {}
+
+func (*AttachRequest_Input) isAttachRequest_Request() {}
+
+func (*AttachRequest_CloseStdin) isAttachRequest_Request() {}
+
+func (*AttachRequest_Resize) isAttachRequest_Request() {}
+
+// OutputSpec describes how the server should store the output of a job.
+type OutputSpec struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The maximum number of bytes of output to retain in memory. Once the limit
+	// is reached, the oldest output is discarded. If the server is configured
+	// with its own limit, this can only be used to lower it.
+	//
+	// This has no effect if the server stores job output on disk.
+	RetainBytes *int64 `protobuf:"varint,1,opt,name=retain_bytes,json=retainBytes,proto3,oneof" json:"retain_bytes,omitempty"`
+}
+
+func (x *OutputSpec) Reset() {
+	*x = OutputSpec{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OutputSpec) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OutputSpec) ProtoMessage() {}
+
+func (x *OutputSpec) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OutputSpec.ProtoReflect.Descriptor instead.
+func (*OutputSpec) Descriptor() ([]byte, []int) {
+	return file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *OutputSpec) GetRetainBytes() int64 {
+	if x != nil && x.RetainBytes != nil {
+		return *x.RetainBytes
+	}
+	return 0
+}
+
+type OutputRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id *JobId `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// If not empty, only output written to one of the given streams is sent.
+	Streams []Stream `protobuf:"varint,2,rep,packed,name=streams,proto3,enum=job.v1.Stream" json:"streams,omitempty"`
+	// If set, the output starts at the given byte offset. This is typically
+	// the offset of the end of the last output received by a client (i.e. the
+	// offset of the last message plus the length of its output), so that the
+	// client can resume streaming without receiving any output twice. Must not
+	// be larger than the total size of the job's output.
+	Offset int64 `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	// If set, the output starts with the last N lines of output written to the
+	// selected streams when the request was received. If 0, only new output
+	// is sent.
+	TailLines *int64 `protobuf:"varint,4,opt,name=tail_lines,json=tailLines,proto3,oneof" json:"tail_lines,omitempty"`
+	// If set, only output received by the server at or after the given time
+	// is sent.
+	Since *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=since,proto3" json:"since,omitempty"`
+	// If set, the output of the given attempt is sent instead of the output of
+	// the job's latest attempt. Only the output of the attempts listed in the
+	// job's status is available.
+	Attempt *uint32 `protobuf:"varint,6,opt,name=attempt,proto3,oneof" json:"attempt,omitempty"`
+}
+
+func (x *OutputRequest) Reset() {
+	*x = OutputRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OutputRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OutputRequest) ProtoMessage() {}
+
+func (x *OutputRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OutputRequest.ProtoReflect.Descriptor instead.
+func (*OutputRequest) Descriptor() ([]byte, []int) {
+	return file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *OutputRequest) GetId() *JobId {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *OutputRequest) GetStreams() []Stream {
+	if x != nil {
+		return x.Streams
+	}
+	return nil
+}
+
+func (x *OutputRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *OutputRequest) GetTailLines() int64 {
+	if x != nil && x.TailLines != nil {
+		return *x.TailLines
+	}
+	return 0
+}
+
+func (x *OutputRequest) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+func (x *OutputRequest) GetAttempt() uint32 {
+	if x != nil && x.Attempt != nil {
+		return *x.Attempt
+	}
+	return 0
+}
+
+type ProcessOutput struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// A chunk of output from one of the process's output streams.
+	//
+	// This is a raw byte stream, and may contain arbitrary binary data,
+	// depending on the command being run. It is up to the client to interpret
+	// the output correctly based on the command being run. No guarantees are
+	// made about the size of each chunk, or the frequency at which they
+	// are sent.
+	Output []byte `protobuf:"bytes,1,opt,name=output,proto3" json:"output,omitempty"`
+	// If non-zero, this many bytes of output immediately preceding this
+	// message's output were discarded by the server (according to its output
+	// retention limits) before they could be sent to the client.
+	//
+	// Dropped output is counted regardless of which stream it was written to.
+	DroppedBytes int64 `protobuf:"varint,2,opt,name=dropped_bytes,json=droppedBytes,proto3" json:"dropped_bytes,omitempty"`
+	// The stream that the output was written to. Output from jobs that were
+	// started with a terminal is always reported as stdout.
+	Stream Stream `protobuf:"varint,3,opt,name=stream,proto3,enum=job.v1.Stream" json:"stream,omitempty"`
+	// The time at which the server received the output.
+	Time *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=time,proto3" json:"time,omitempty"`
+	// The offset of the start of this message's output within the job's
+	// output, i.e. the total number of bytes of output (from all streams)
+	// written by the job before it. If the message only reports dropped
+	// output, this is the offset at which the remaining output resumes.
+	Offset int64 `protobuf:"varint,5,opt,name=offset,proto3" json:"offset,omitempty"`
+}
+
+func (x *ProcessOutput) Reset() {
+	*x = ProcessOutput{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProcessOutput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProcessOutput) ProtoMessage() {}
+
+func (x *ProcessOutput) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProcessOutput.ProtoReflect.Descriptor instead.
+func (*ProcessOutput) Descriptor() ([]byte, []int) {
+	return file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ProcessOutput) GetOutput() []byte {
+	if x != nil {
+		return x.Output
+	}
+	return nil
+}
+
+func (x *ProcessOutput) GetDroppedBytes() int64 {
+	if x != nil {
+		return x.DroppedBytes
+	}
+	return 0
+}
+
+func (x *ProcessOutput) GetStream() Stream {
+	if x != nil {
+		return x.Stream
+	}
+	return Stream_STREAM_UNSPECIFIED
+}
+
+func (x *ProcessOutput) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *ProcessOutput) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type ResourceLimits struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Process CPU limit defined in milli-cores (1000 = 1 core/vcpu)
+	Cpu *int64 `protobuf:"varint,1,opt,name=cpu,proto3,oneof" json:"cpu,omitempty"`
+	// Process memory limit defined in bytes
+	Memory *MemoryLimits `protobuf:"bytes,2,opt,name=memory,proto3" json:"memory,omitempty"`
+	// Process IO limits for storage devices
+	Io []*IODeviceLimits `protobuf:"bytes,3,rep,name=io,proto3" json:"io,omitempty"`
+	// Relative CPU weight (cpu.weight), from 1 to 10000. When CPUs are
+	// contended, the job receives CPU time in proportion to its weight
+	// relative to other jobs. If not set, the kernel's default of 100 is used.
+	CpuWeight *uint32 `protobuf:"varint,4,opt,name=cpu_weight,json=cpuWeight,proto3,oneof" json:"cpu_weight,omitempty"`
+	// The period over which the CPU limit is enforced (the CFS period in
+	// cpu.max), from 1ms to 1s. Shorter periods reduce the time a job may
+	// be throttled at once, at the cost of more overhead. If not set, a
+	// default of 100ms is used.
+	CpuPeriod *durationpb.Duration `protobuf:"bytes,5,opt,name=cpu_period,json=cpuPeriod,proto3" json:"cpu_period,omitempty"`
+	// Restricts the job to specific CPUs and memory nodes.
+	Cpuset *CpusetLimits `protobuf:"bytes,6,opt,name=cpuset,proto3" json:"cpuset,omitempty"`
+	// Maximum number of processes (and threads) in the job (pids.max). Once
+	// reached, attempts to create new processes fail. If not set, the
+	// server's default limit is used, if any.
+	Pids *int64 `protobuf:"varint,7,opt,name=pids,proto3,oneof" json:"pids,omitempty"`
+}
+
+func (x *ResourceLimits) Reset() {
+	*x = ResourceLimits{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResourceLimits) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResourceLimits) ProtoMessage() {}
+
+func (x *ResourceLimits) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResourceLimits.ProtoReflect.Descriptor instead.
+func (*ResourceLimits) Descriptor() ([]byte, []int) {
+	return file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ResourceLimits) GetCpu() int64 {
+	if x != nil && x.Cpu != nil {
+		return *x.Cpu
+	}
+	return 0
+}
+
+func (x *ResourceLimits) GetMemory() *MemoryLimits {
+	if x != nil {
+		return x.Memory
+	}
+	return nil
+}
+
+func (x *ResourceLimits) GetIo() []*IODeviceLimits {
+	if x != nil {
+		return x.Io
+	}
+	return nil
+}
+
+func (x *ResourceLimits) GetCpuWeight() uint32 {
+	if x != nil && x.CpuWeight != nil {
+		return *x.CpuWeight
+	}
+	return 0
+}
+
+func (x *ResourceLimits) GetCpuPeriod() *durationpb.Duration {
+	if x != nil {
+		return x.CpuPeriod
+	}
+	return nil
+}
+
+func (x *ResourceLimits) GetCpuset() *CpusetLimits {
+	if x != nil {
+		return x.Cpuset
+	}
+	return nil
+}
+
+func (x *ResourceLimits) GetPids() int64 {
+	if x != nil && x.Pids != nil {
+		return *x.Pids
+	}
+	return 0
+}
+
+type CpusetLimits struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The CPUs the job may run on (cpuset.cpus), as a comma-separated list of
+	// CPU numbers or ranges (e.g. "0-3,6").
+	Cpus string `protobuf:"bytes,1,opt,name=cpus,proto3" json:"cpus,omitempty"`
+	// The memory nodes the job may allocate memory from (cpuset.mems), in the
+	// same format as 'cpus'.
+	Mems string `protobuf:"bytes,2,opt,name=mems,proto3" json:"mems,omitempty"`
+}
+
+func (x *CpusetLimits) Reset() {
+	*x = CpusetLimits{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CpusetLimits) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CpusetLimits) ProtoMessage() {}
+
+func (x *CpusetLimits) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CpusetLimits.ProtoReflect.Descriptor instead.
+func (*CpusetLimits) Descriptor() ([]byte, []int) {
+	return file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *CpusetLimits) GetCpus() string {
+	if x != nil {
+		return x.Cpus
+	}
+	return ""
+}
+
+func (x *CpusetLimits) GetMems() string {
+	if x != nil {
+		return x.Mems
+	}
+	return ""
+}
+
+type MemoryLimits struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Memory soft limit defined in bytes. Processes exceeding the limit are
+	// not OOM-killed, but may be throttled by the kernel.
+	SoftLimit *int64 `protobuf:"varint,1,opt,name=soft_limit,json=softLimit,proto3,oneof" json:"soft_limit,omitempty"`
+	// Memory limit defined in bytes. If exceeded, the process will be OOM-killed.
+	Limit *int64 `protobuf:"varint,2,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
+	// Swap limit defined in bytes (memory.swap.max). If set to 0, the job
+	// cannot use swap.
+	SwapLimit *int64 `protobuf:"varint,3,opt,name=swap_limit,json=swapLimit,proto3,oneof" json:"swap_limit,omitempty"`
+	// Best-effort memory protection defined in bytes (memory.low). Memory
+	// below this amount is only reclaimed from the job if there is no
+	// unprotected memory to reclaim elsewhere.
+	Low *int64 `protobuf:"varint,4,opt,name=low,proto3,oneof" json:"low,omitempty"`
+	// Hard memory protection defined in bytes (memory.min). Memory below this
+	// amount is never reclaimed from the job.
+	Min *int64 `protobuf:"varint,5,opt,name=min,proto3,oneof" json:"min,omitempty"`
+	// If true, all processes in the job are killed together when the OOM
+	// killer selects any one of them (memory.oom.group), instead of only the
+	// selected process.
+	OomGroup *bool `protobuf:"varint,6,opt,name=oom_group,json=oomGroup,proto3,oneof" json:"oom_group,omitempty"`
+}
+
+func (x *MemoryLimits) Reset() {
+	*x = MemoryLimits{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MemoryLimits) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MemoryLimits) ProtoMessage() {}
+
+func (x *MemoryLimits) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MemoryLimits.ProtoReflect.Descriptor instead.
+func (*MemoryLimits) Descriptor() ([]byte, []int) {
+	return file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *MemoryLimits) GetSoftLimit() int64 {
+	if x != nil && x.SoftLimit != nil {
+		return *x.SoftLimit
+	}
+	return 0
+}
+
+func (x *MemoryLimits) GetLimit() int64 {
+	if x != nil && x.Limit != nil {
+		return *x.Limit
+	}
+	return 0
+}
+
+func (x *MemoryLimits) GetSwapLimit() int64 {
+	if x != nil && x.SwapLimit != nil {
+		return *x.SwapLimit
+	}
+	return 0
+}
+
+func (x *MemoryLimits) GetLow() int64 {
+	if x != nil && x.Low != nil {
+		return *x.Low
+	}
+	return 0
+}
+
+func (x *MemoryLimits) GetMin() int64 {
+	if x != nil && x.Min != nil {
+		return *x.Min
+	}
+	return 0
+}
+
+func (x *MemoryLimits) GetOomGroup() bool {
+	if x != nil && x.OomGroup != nil {
+		return *x.OomGroup
+	}
+	return false
+}
+
+type IODeviceLimits struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Device path (e.g. /dev/sda)
+	Device string `protobuf:"bytes,1,opt,name=device,proto3" json:"device,omitempty"`
+	// Limits for the device
+	Limits *IOLimits `protobuf:"bytes,2,opt,name=limits,proto3" json:"limits,omitempty"`
+}
+
+func (x *IODeviceLimits) Reset() {
+	*x = IODeviceLimits{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IODeviceLimits) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IODeviceLimits) ProtoMessage() {}
+
+func (x *IODeviceLimits) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IODeviceLimits.ProtoReflect.Descriptor instead.
+func (*IODeviceLimits) Descriptor() ([]byte, []int) {
+	return file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *IODeviceLimits) GetDevice() string {
+	if x != nil {
+		return x.Device
+	}
+	return ""
+}
+
+func (x *IODeviceLimits) GetLimits() *IOLimits {
+	if x != nil {
+		return x.Limits
+	}
+	return nil
+}
+
+type IOLimits struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Limit for read operations in bytes per second
+	ReadBps *int64 `protobuf:"varint,2,opt,name=read_bps,json=readBps,proto3,oneof" json:"read_bps,omitempty"`
+	// Limit for write operations in bytes per second
+	WriteBps *int64 `protobuf:"varint,3,opt,name=write_bps,json=writeBps,proto3,oneof" json:"write_bps,omitempty"`
+	// Limit for read operations in IOPS
+	ReadIops *int64 `protobuf:"varint,4,opt,name=read_iops,json=readIops,proto3,oneof" json:"read_iops,omitempty"`
+	// Limit for write operations in IOPS
+	WriteIops *int64 `protobuf:"varint,5,opt,name=write_iops,json=writeIops,proto3,oneof" json:"write_iops,omitempty"`
+}
+
+func (x *IOLimits) Reset() {
+	*x = IOLimits{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IOLimits) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IOLimits) ProtoMessage() {}
+
+func (x *IOLimits) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IOLimits.ProtoReflect.Descriptor instead.
+func (*IOLimits) Descriptor() ([]byte, []int) {
+	return file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *IOLimits) GetReadBps() int64 {
+	if x != nil && x.ReadBps != nil {
+		return *x.ReadBps
+	}
+	return 0
 }
 
 func (x *IOLimits) GetWriteBps() int64 {
@@ -786,16 +3433,451 @@ func (x *IOLimits) GetWriteBps() int64 {
 	return 0
 }
 
-func (x *IOLimits) GetReadIops() int64 {
-	if x != nil && x.ReadIops != nil {
-		return *x.ReadIops
+func (x *IOLimits) GetReadIops() int64 {
+	if x != nil && x.ReadIops != nil {
+		return *x.ReadIops
+	}
+	return 0
+}
+
+func (x *IOLimits) GetWriteIops() int64 {
+	if x != nil && x.WriteIops != nil {
+		return *x.WriteIops
+	}
+	return 0
+}
+
+// ResourceUsage describes the resources used by a job, read from the files of
+// its cgroup.
+type ResourceUsage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The time at which the usage was measured.
+	Time   *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	Cpu    *CpuUsage              `protobuf:"bytes,2,opt,name=cpu,proto3" json:"cpu,omitempty"`
+	Memory *MemoryUsage           `protobuf:"bytes,3,opt,name=memory,proto3" json:"memory,omitempty"`
+	// IO usage for each device the job has accessed.
+	Io []*DeviceIOUsage `protobuf:"bytes,4,rep,name=io,proto3" json:"io,omitempty"`
+	// Not set if the pids controller is not enabled for the job's cgroup.
+	Pids *PidsUsage `protobuf:"bytes,5,opt,name=pids,proto3" json:"pids,omitempty"`
+}
+
+func (x *ResourceUsage) Reset() {
+	*x = ResourceUsage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResourceUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResourceUsage) ProtoMessage() {}
+
+func (x *ResourceUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResourceUsage.ProtoReflect.Descriptor instead.
+func (*ResourceUsage) Descriptor() ([]byte, []int) {
+	return file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ResourceUsage) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *ResourceUsage) GetCpu() *CpuUsage {
+	if x != nil {
+		return x.Cpu
+	}
+	return nil
+}
+
+func (x *ResourceUsage) GetMemory() *MemoryUsage {
+	if x != nil {
+		return x.Memory
+	}
+	return nil
+}
+
+func (x *ResourceUsage) GetIo() []*DeviceIOUsage {
+	if x != nil {
+		return x.Io
+	}
+	return nil
+}
+
+func (x *ResourceUsage) GetPids() *PidsUsage {
+	if x != nil {
+		return x.Pids
+	}
+	return nil
+}
+
+// CPU usage, from cpu.stat. All times are in microseconds.
+type CpuUsage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Total CPU time used.
+	UsageUsec uint64 `protobuf:"varint,1,opt,name=usage_usec,json=usageUsec,proto3" json:"usage_usec,omitempty"`
+	// CPU time spent in user mode.
+	UserUsec uint64 `protobuf:"varint,2,opt,name=user_usec,json=userUsec,proto3" json:"user_usec,omitempty"`
+	// CPU time spent in kernel mode.
+	SystemUsec uint64 `protobuf:"varint,3,opt,name=system_usec,json=systemUsec,proto3" json:"system_usec,omitempty"`
+	// The number of CFS periods that have elapsed while the job was runnable.
+	NrPeriods uint64 `protobuf:"varint,4,opt,name=nr_periods,json=nrPeriods,proto3" json:"nr_periods,omitempty"`
+	// The number of periods in which the job was throttled by its CPU limit.
+	NrThrottled uint64 `protobuf:"varint,5,opt,name=nr_throttled,json=nrThrottled,proto3" json:"nr_throttled,omitempty"`
+	// Total time the job was throttled by its CPU limit.
+	ThrottledUsec uint64 `protobuf:"varint,6,opt,name=throttled_usec,json=throttledUsec,proto3" json:"throttled_usec,omitempty"`
+}
+
+func (x *CpuUsage) Reset() {
+	*x = CpuUsage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CpuUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CpuUsage) ProtoMessage() {}
+
+func (x *CpuUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CpuUsage.ProtoReflect.Descriptor instead.
+func (*CpuUsage) Descriptor() ([]byte, []int) {
+	return file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *CpuUsage) GetUsageUsec() uint64 {
+	if x != nil {
+		return x.UsageUsec
+	}
+	return 0
+}
+
+func (x *CpuUsage) GetUserUsec() uint64 {
+	if x != nil {
+		return x.UserUsec
+	}
+	return 0
+}
+
+func (x *CpuUsage) GetSystemUsec() uint64 {
+	if x != nil {
+		return x.SystemUsec
+	}
+	return 0
+}
+
+func (x *CpuUsage) GetNrPeriods() uint64 {
+	if x != nil {
+		return x.NrPeriods
+	}
+	return 0
+}
+
+func (x *CpuUsage) GetNrThrottled() uint64 {
+	if x != nil {
+		return x.NrThrottled
+	}
+	return 0
+}
+
+func (x *CpuUsage) GetThrottledUsec() uint64 {
+	if x != nil {
+		return x.ThrottledUsec
+	}
+	return 0
+}
+
+// Memory usage, from memory.current, memory.peak and memory.stat. All values
+// are in bytes.
+type MemoryUsage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Total memory currently used (memory.current).
+	Current uint64 `protobuf:"varint,1,opt,name=current,proto3" json:"current,omitempty"`
+	// The highest memory usage recorded (memory.peak). Not set if the kernel
+	// does not support memory.peak (before Linux 5.19).
+	Peak *uint64 `protobuf:"varint,2,opt,name=peak,proto3,oneof" json:"peak,omitempty"`
+	// Memory used by anonymous mappings, such as the heap and stack.
+	Anon uint64 `protobuf:"varint,3,opt,name=anon,proto3" json:"anon,omitempty"`
+	// Memory used to cache files, including tmpfs and shared memory.
+	File uint64 `protobuf:"varint,4,opt,name=file,proto3" json:"file,omitempty"`
+	// Memory used by kernel data structures on behalf of the job.
+	Kernel uint64 `protobuf:"varint,5,opt,name=kernel,proto3" json:"kernel,omitempty"`
+	// Memory used by shared memory and tmpfs (included in 'file').
+	Shmem uint64 `protobuf:"varint,6,opt,name=shmem,proto3" json:"shmem,omitempty"`
+	// Memory used by network transmission buffers.
+	Sock uint64 `protobuf:"varint,7,opt,name=sock,proto3" json:"sock,omitempty"`
+}
+
+func (x *MemoryUsage) Reset() {
+	*x = MemoryUsage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MemoryUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MemoryUsage) ProtoMessage() {}
+
+func (x *MemoryUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MemoryUsage.ProtoReflect.Descriptor instead.
+func (*MemoryUsage) Descriptor() ([]byte, []int) {
+	return file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *MemoryUsage) GetCurrent() uint64 {
+	if x != nil {
+		return x.Current
+	}
+	return 0
+}
+
+func (x *MemoryUsage) GetPeak() uint64 {
+	if x != nil && x.Peak != nil {
+		return *x.Peak
 	}
 	return 0
 }
 
-func (x *IOLimits) GetWriteIops() int64 {
-	if x != nil && x.WriteIops != nil {
-		return *x.WriteIops
+func (x *MemoryUsage) GetAnon() uint64 {
+	if x != nil {
+		return x.Anon
+	}
+	return 0
+}
+
+func (x *MemoryUsage) GetFile() uint64 {
+	if x != nil {
+		return x.File
+	}
+	return 0
+}
+
+func (x *MemoryUsage) GetKernel() uint64 {
+	if x != nil {
+		return x.Kernel
+	}
+	return 0
+}
+
+func (x *MemoryUsage) GetShmem() uint64 {
+	if x != nil {
+		return x.Shmem
+	}
+	return 0
+}
+
+func (x *MemoryUsage) GetSock() uint64 {
+	if x != nil {
+		return x.Sock
+	}
+	return 0
+}
+
+// IO usage of a single device, from io.stat.
+type DeviceIOUsage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The device's major and minor numbers (e.g. "8:16").
+	Device string `protobuf:"bytes,1,opt,name=device,proto3" json:"device,omitempty"`
+	// Bytes read.
+	ReadBytes uint64 `protobuf:"varint,2,opt,name=read_bytes,json=readBytes,proto3" json:"read_bytes,omitempty"`
+	// Bytes written.
+	WriteBytes uint64 `protobuf:"varint,3,opt,name=write_bytes,json=writeBytes,proto3" json:"write_bytes,omitempty"`
+	// Read operations.
+	ReadIos uint64 `protobuf:"varint,4,opt,name=read_ios,json=readIos,proto3" json:"read_ios,omitempty"`
+	// Write operations.
+	WriteIos uint64 `protobuf:"varint,5,opt,name=write_ios,json=writeIos,proto3" json:"write_ios,omitempty"`
+	// Bytes discarded.
+	DiscardBytes uint64 `protobuf:"varint,6,opt,name=discard_bytes,json=discardBytes,proto3" json:"discard_bytes,omitempty"`
+	// Discard operations.
+	DiscardIos uint64 `protobuf:"varint,7,opt,name=discard_ios,json=discardIos,proto3" json:"discard_ios,omitempty"`
+}
+
+func (x *DeviceIOUsage) Reset() {
+	*x = DeviceIOUsage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeviceIOUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeviceIOUsage) ProtoMessage() {}
+
+func (x *DeviceIOUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeviceIOUsage.ProtoReflect.Descriptor instead.
+func (*DeviceIOUsage) Descriptor() ([]byte, []int) {
+	return file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *DeviceIOUsage) GetDevice() string {
+	if x != nil {
+		return x.Device
+	}
+	return ""
+}
+
+func (x *DeviceIOUsage) GetReadBytes() uint64 {
+	if x != nil {
+		return x.ReadBytes
+	}
+	return 0
+}
+
+func (x *DeviceIOUsage) GetWriteBytes() uint64 {
+	if x != nil {
+		return x.WriteBytes
+	}
+	return 0
+}
+
+func (x *DeviceIOUsage) GetReadIos() uint64 {
+	if x != nil {
+		return x.ReadIos
+	}
+	return 0
+}
+
+func (x *DeviceIOUsage) GetWriteIos() uint64 {
+	if x != nil {
+		return x.WriteIos
+	}
+	return 0
+}
+
+func (x *DeviceIOUsage) GetDiscardBytes() uint64 {
+	if x != nil {
+		return x.DiscardBytes
+	}
+	return 0
+}
+
+func (x *DeviceIOUsage) GetDiscardIos() uint64 {
+	if x != nil {
+		return x.DiscardIos
+	}
+	return 0
+}
+
+// Process usage, from pids.current.
+type PidsUsage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The number of processes (and threads) currently in the job.
+	Current uint64 `protobuf:"varint,1,opt,name=current,proto3" json:"current,omitempty"`
+}
+
+func (x *PidsUsage) Reset() {
+	*x = PidsUsage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PidsUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PidsUsage) ProtoMessage() {}
+
+func (x *PidsUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PidsUsage.ProtoReflect.Descriptor instead.
+func (*PidsUsage) Descriptor() ([]byte, []int) {
+	return file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *PidsUsage) GetCurrent() uint64 {
+	if x != nil {
+		return x.Current
 	}
 	return 0
 }
@@ -810,114 +3892,574 @@ var file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_rawDesc = []byt
 	0x31, 0x1a, 0x39, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6b, 0x72,
 	0x61, 0x6c, 0x69, 0x63, 0x6b, 0x79, 0x2f, 0x6a, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
 	0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x61, 0x70, 0x69, 0x73, 0x2f, 0x72, 0x62, 0x61, 0x63, 0x2f, 0x76,
-	0x31, 0x2f, 0x72, 0x62, 0x61, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b, 0x67, 0x6f,
+	0x31, 0x2f, 0x72, 0x62, 0x61, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b, 0x67, 0x6f,
 	0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d,
 	0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
 	0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73,
-	0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x68, 0x0a, 0x07, 0x4a, 0x6f,
-	0x62, 0x53, 0x70, 0x65, 0x63, 0x12, 0x2d, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e,
-	0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x53, 0x70, 0x65, 0x63, 0x52, 0x07, 0x63, 0x6f, 0x6d,
-	0x6d, 0x61, 0x6e, 0x64, 0x12, 0x2e, 0x0a, 0x06, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65,
-	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x52, 0x06, 0x6c, 0x69,
-	0x6d, 0x69, 0x74, 0x73, 0x22, 0x17, 0x0a, 0x05, 0x4a, 0x6f, 0x62, 0x49, 0x64, 0x12, 0x0e, 0x0a,
-	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x30, 0x0a,
-	0x09, 0x4a, 0x6f, 0x62, 0x49, 0x64, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x05, 0x69, 0x74,
-	0x65, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6a, 0x6f, 0x62, 0x2e,
-	0x76, 0x31, 0x2e, 0x4a, 0x6f, 0x62, 0x49, 0x64, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x22,
-	0xf7, 0x01, 0x0a, 0x09, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x23, 0x0a,
-	0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0d, 0x2e, 0x6a,
-	0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61,
-	0x74, 0x65, 0x12, 0x23, 0x0a, 0x04, 0x73, 0x70, 0x65, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x0f, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x4a, 0x6f, 0x62, 0x53, 0x70, 0x65,
-	0x63, 0x52, 0x04, 0x73, 0x70, 0x65, 0x63, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03,
-	0x70, 0x69, 0x64, 0x12, 0x39, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x74, 0x69, 0x6d,
-	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
-	0x61, 0x6d, 0x70, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x39,
-	0x0a, 0x0a, 0x74, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x65, 0x72, 0x6d,
-	0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x0a, 0x74,
-	0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x64, 0x22, 0x92, 0x01, 0x0a, 0x11, 0x54, 0x65,
-	0x72, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
-	0x1b, 0x0a, 0x09, 0x65, 0x78, 0x69, 0x74, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x05, 0x52, 0x08, 0x65, 0x78, 0x69, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x16, 0x0a, 0x06,
-	0x73, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x73, 0x69,
-	0x67, 0x6e, 0x61, 0x6c, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x74, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x74, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x12, 0x2e,
-	0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
-	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
-	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x22, 0x4d,
-	0x0a, 0x0b, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x53, 0x70, 0x65, 0x63, 0x12, 0x18, 0x0a,
-	0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
-	0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x72, 0x67, 0x73, 0x18,
-	0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x61, 0x72, 0x67, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x65,
-	0x6e, 0x76, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x03, 0x65, 0x6e, 0x76, 0x22, 0x27, 0x0a,
-	0x0d, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x16,
-	0x0a, 0x06, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06,
-	0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x22, 0x85, 0x01, 0x0a, 0x0e, 0x52, 0x65, 0x73, 0x6f, 0x75,
-	0x72, 0x63, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x15, 0x0a, 0x03, 0x63, 0x70, 0x75,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x03, 0x63, 0x70, 0x75, 0x88, 0x01, 0x01,
-	0x12, 0x2c, 0x0a, 0x06, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x14, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79,
-	0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x52, 0x06, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x12, 0x26,
-	0x0a, 0x02, 0x69, 0x6f, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6a, 0x6f, 0x62,
-	0x2e, 0x76, 0x31, 0x2e, 0x49, 0x4f, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x4c, 0x69, 0x6d, 0x69,
-	0x74, 0x73, 0x52, 0x02, 0x69, 0x6f, 0x42, 0x06, 0x0a, 0x04, 0x5f, 0x63, 0x70, 0x75, 0x22, 0x66,
-	0x0a, 0x0c, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x22,
-	0x0a, 0x0a, 0x73, 0x6f, 0x66, 0x74, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x03, 0x48, 0x00, 0x52, 0x09, 0x73, 0x6f, 0x66, 0x74, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x88,
-	0x01, 0x01, 0x12, 0x19, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x03, 0x48, 0x01, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x88, 0x01, 0x01, 0x42, 0x0d, 0x0a,
-	0x0b, 0x5f, 0x73, 0x6f, 0x66, 0x74, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x42, 0x08, 0x0a, 0x06,
-	0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x52, 0x0a, 0x0e, 0x49, 0x4f, 0x44, 0x65, 0x76, 0x69,
-	0x63, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x65, 0x76, 0x69,
-	0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65,
-	0x12, 0x28, 0x0a, 0x06, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x10, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x4f, 0x4c, 0x69, 0x6d, 0x69,
-	0x74, 0x73, 0x52, 0x06, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x22, 0xca, 0x01, 0x0a, 0x08, 0x49,
-	0x4f, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x1e, 0x0a, 0x08, 0x72, 0x65, 0x61, 0x64, 0x5f,
-	0x62, 0x70, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x07, 0x72, 0x65, 0x61,
-	0x64, 0x42, 0x70, 0x73, 0x88, 0x01, 0x01, 0x12, 0x20, 0x0a, 0x09, 0x77, 0x72, 0x69, 0x74, 0x65,
-	0x5f, 0x62, 0x70, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x48, 0x01, 0x52, 0x08, 0x77, 0x72,
-	0x69, 0x74, 0x65, 0x42, 0x70, 0x73, 0x88, 0x01, 0x01, 0x12, 0x20, 0x0a, 0x09, 0x72, 0x65, 0x61,
-	0x64, 0x5f, 0x69, 0x6f, 0x70, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x48, 0x02, 0x52, 0x08,
-	0x72, 0x65, 0x61, 0x64, 0x49, 0x6f, 0x70, 0x73, 0x88, 0x01, 0x01, 0x12, 0x22, 0x0a, 0x0a, 0x77,
-	0x72, 0x69, 0x74, 0x65, 0x5f, 0x69, 0x6f, 0x70, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x48,
-	0x03, 0x52, 0x09, 0x77, 0x72, 0x69, 0x74, 0x65, 0x49, 0x6f, 0x70, 0x73, 0x88, 0x01, 0x01, 0x42,
-	0x0b, 0x0a, 0x09, 0x5f, 0x72, 0x65, 0x61, 0x64, 0x5f, 0x62, 0x70, 0x73, 0x42, 0x0c, 0x0a, 0x0a,
-	0x5f, 0x77, 0x72, 0x69, 0x74, 0x65, 0x5f, 0x62, 0x70, 0x73, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x72,
-	0x65, 0x61, 0x64, 0x5f, 0x69, 0x6f, 0x70, 0x73, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x77, 0x72, 0x69,
-	0x74, 0x65, 0x5f, 0x69, 0x6f, 0x70, 0x73, 0x2a, 0x4a, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x74, 0x65,
-	0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x0b, 0x0a,
-	0x07, 0x50, 0x45, 0x4e, 0x44, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x46, 0x41,
-	0x49, 0x4c, 0x45, 0x44, 0x10, 0x02, 0x12, 0x0b, 0x0a, 0x07, 0x52, 0x55, 0x4e, 0x4e, 0x49, 0x4e,
-	0x47, 0x10, 0x03, 0x12, 0x0e, 0x0a, 0x0a, 0x54, 0x45, 0x52, 0x4d, 0x49, 0x4e, 0x41, 0x54, 0x45,
-	0x44, 0x10, 0x04, 0x32, 0x8e, 0x02, 0x0a, 0x03, 0x4a, 0x6f, 0x62, 0x12, 0x27, 0x0a, 0x05, 0x53,
-	0x74, 0x61, 0x72, 0x74, 0x12, 0x0f, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x4a, 0x6f,
-	0x62, 0x53, 0x70, 0x65, 0x63, 0x1a, 0x0d, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x4a,
-	0x6f, 0x62, 0x49, 0x64, 0x12, 0x35, 0x0a, 0x04, 0x53, 0x74, 0x6f, 0x70, 0x12, 0x0d, 0x2e, 0x6a,
-	0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x4a, 0x6f, 0x62, 0x49, 0x64, 0x1a, 0x16, 0x2e, 0x67, 0x6f,
-	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d,
-	0x70, 0x74, 0x79, 0x22, 0x06, 0x82, 0xb5, 0x18, 0x02, 0x08, 0x01, 0x12, 0x32, 0x0a, 0x06, 0x53,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x0d, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x4a,
-	0x6f, 0x62, 0x49, 0x64, 0x1a, 0x11, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x4a, 0x6f,
-	0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x06, 0x82, 0xb5, 0x18, 0x02, 0x08, 0x01, 0x12,
-	0x39, 0x0a, 0x04, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a,
-	0x11, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x4a, 0x6f, 0x62, 0x49, 0x64, 0x4c, 0x69,
-	0x73, 0x74, 0x22, 0x06, 0x82, 0xb5, 0x18, 0x02, 0x08, 0x01, 0x12, 0x38, 0x0a, 0x06, 0x4f, 0x75,
-	0x74, 0x70, 0x75, 0x74, 0x12, 0x0d, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x4a, 0x6f,
-	0x62, 0x49, 0x64, 0x1a, 0x15, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f,
-	0x63, 0x65, 0x73, 0x73, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x22, 0x06, 0x82, 0xb5, 0x18, 0x02,
-	0x08, 0x01, 0x30, 0x01, 0x42, 0x35, 0x5a, 0x33, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
-	0x6f, 0x6d, 0x2f, 0x6b, 0x72, 0x61, 0x6c, 0x69, 0x63, 0x6b, 0x79, 0x2f, 0x6a, 0x6f, 0x62, 0x73,
-	0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x61, 0x70, 0x69, 0x73, 0x2f, 0x6a,
-	0x6f, 0x62, 0x2f, 0x76, 0x31, 0x3b, 0x6a, 0x6f, 0x62, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x33,
+	0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x9a, 0x04, 0x0a, 0x07, 0x4a,
+	0x6f, 0x62, 0x53, 0x70, 0x65, 0x63, 0x12, 0x2d, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31,
+	0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x53, 0x70, 0x65, 0x63, 0x52, 0x07, 0x63, 0x6f,
+	0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x2e, 0x0a, 0x06, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x52,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x52, 0x06, 0x6c,
+	0x69, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x2a, 0x0a, 0x06, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x4f,
+	0x75, 0x74, 0x70, 0x75, 0x74, 0x53, 0x70, 0x65, 0x63, 0x52, 0x06, 0x6f, 0x75, 0x74, 0x70, 0x75,
+	0x74, 0x12, 0x26, 0x0a, 0x04, 0x73, 0x74, 0x6f, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x12, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x6f, 0x70, 0x50, 0x6f, 0x6c,
+	0x69, 0x63, 0x79, 0x52, 0x04, 0x73, 0x74, 0x6f, 0x70, 0x12, 0x35, 0x0a, 0x07, 0x74, 0x69, 0x6d,
+	0x65, 0x6f, 0x75, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74,
+	0x12, 0x38, 0x0a, 0x08, 0x64, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x48, 0x00,
+	0x52, 0x08, 0x64, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x2f, 0x0a, 0x07, 0x72, 0x65,
+	0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6a, 0x6f,
+	0x62, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x50, 0x6f, 0x6c, 0x69,
+	0x63, 0x79, 0x52, 0x07, 0x72, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x33, 0x0a, 0x06, 0x6c,
+	0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6a, 0x6f,
+	0x62, 0x2e, 0x76, 0x31, 0x2e, 0x4a, 0x6f, 0x62, 0x53, 0x70, 0x65, 0x63, 0x2e, 0x4c, 0x61, 0x62,
+	0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65,
+	0x6e, 0x63, 0x79, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x69,
+	0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4b, 0x65, 0x79, 0x1a, 0x39, 0x0a,
+	0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x0d, 0x0a, 0x0b, 0x6d, 0x61, 0x78, 0x5f,
+	0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x22, 0xee, 0x01, 0x0a, 0x0d, 0x52, 0x65, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x27, 0x0a, 0x04, 0x6d, 0x6f, 0x64,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31,
+	0x2e, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x6d, 0x6f,
+	0x64, 0x65, 0x12, 0x24, 0x0a, 0x0b, 0x6d, 0x61, 0x78, 0x5f, 0x72, 0x65, 0x74, 0x72, 0x69, 0x65,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x00, 0x52, 0x0a, 0x6d, 0x61, 0x78, 0x52, 0x65,
+	0x74, 0x72, 0x69, 0x65, 0x73, 0x88, 0x01, 0x01, 0x12, 0x42, 0x0a, 0x0f, 0x69, 0x6e, 0x69, 0x74,
+	0x69, 0x61, 0x6c, 0x5f, 0x62, 0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0e, 0x69, 0x6e,
+	0x69, 0x74, 0x69, 0x61, 0x6c, 0x42, 0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66, 0x12, 0x3a, 0x0a, 0x0b,
+	0x6d, 0x61, 0x78, 0x5f, 0x62, 0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x6d, 0x61,
+	0x78, 0x42, 0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x6d, 0x61, 0x78,
+	0x5f, 0x72, 0x65, 0x74, 0x72, 0x69, 0x65, 0x73, 0x22, 0x62, 0x0a, 0x0a, 0x53, 0x74, 0x6f, 0x70,
+	0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x6c,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x12, 0x3c,
+	0x0a, 0x0c, 0x67, 0x72, 0x61, 0x63, 0x65, 0x5f, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x0b, 0x67, 0x72, 0x61, 0x63, 0x65, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x22, 0xbf, 0x01, 0x0a,
+	0x0b, 0x53, 0x74, 0x6f, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76,
+	0x31, 0x2e, 0x4a, 0x6f, 0x62, 0x49, 0x64, 0x52, 0x02, 0x69, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x6c,
+	0x61, 0x62, 0x65, 0x6c, 0x5f, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0d, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74,
+	0x6f, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x06, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x12, 0x3c, 0x0a, 0x0c, 0x67, 0x72,
+	0x61, 0x63, 0x65, 0x5f, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x67, 0x72, 0x61,
+	0x63, 0x65, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6f, 0x72, 0x63,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x22, 0x37,
+	0x0a, 0x0c, 0x53, 0x74, 0x6f, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x27,
+	0x0a, 0x07, 0x73, 0x74, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x0d, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x4a, 0x6f, 0x62, 0x49, 0x64, 0x52, 0x07,
+	0x73, 0x74, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x22, 0x17, 0x0a, 0x05, 0x4a, 0x6f, 0x62, 0x49, 0x64,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
+	0x22, 0xa1, 0x03, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x25, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0e,
+	0x32, 0x0d, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x65, 0x73, 0x12, 0x19, 0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x88,
+	0x01, 0x01, 0x12, 0x1d, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x88, 0x01,
+	0x01, 0x12, 0x3f, 0x0a, 0x0d, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x66, 0x74,
+	0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x0c, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x41, 0x66, 0x74,
+	0x65, 0x72, 0x12, 0x41, 0x0a, 0x0e, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x5f, 0x62, 0x65,
+	0x66, 0x6f, 0x72, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0d, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x42,
+	0x65, 0x66, 0x6f, 0x72, 0x65, 0x12, 0x24, 0x0a, 0x0b, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c,
+	0x65, 0x5f, 0x69, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x48, 0x02, 0x52, 0x0a, 0x73, 0x63,
+	0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x49, 0x64, 0x88, 0x01, 0x01, 0x12, 0x25, 0x0a, 0x0e, 0x6c,
+	0x61, 0x62, 0x65, 0x6c, 0x5f, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x09, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0d, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74,
+	0x6f, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12,
+	0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x42, 0x08,
+	0x0a, 0x06, 0x5f, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x63, 0x6f, 0x6d,
+	0x6d, 0x61, 0x6e, 0x64, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c,
+	0x65, 0x5f, 0x69, 0x64, 0x22, 0x58, 0x0a, 0x07, 0x4a, 0x6f, 0x62, 0x4c, 0x69, 0x73, 0x74, 0x12,
+	0x25, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f,
+	0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x4a, 0x6f, 0x62, 0x49, 0x6e, 0x66, 0x6f, 0x52,
+	0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70,
+	0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x69,
+	0x0a, 0x07, 0x4a, 0x6f, 0x62, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1d, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x4a,
+	0x6f, 0x62, 0x49, 0x64, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65,
+	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x12, 0x29,
+	0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11,
+	0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x2d, 0x0a, 0x0c, 0x57, 0x61, 0x74,
+	0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x4a,
+	0x6f, 0x62, 0x49, 0x64, 0x52, 0x02, 0x69, 0x64, 0x22, 0x56, 0x0a, 0x0a, 0x57, 0x61, 0x74, 0x63,
+	0x68, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x4a, 0x6f, 0x62, 0x49,
+	0x64, 0x52, 0x02, 0x69, 0x64, 0x12, 0x29, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x4a,
+	0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x22, 0xec, 0x04, 0x0a, 0x09, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x23,
+	0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0d, 0x2e,
+	0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74,
+	0x61, 0x74, 0x65, 0x12, 0x23, 0x0a, 0x04, 0x73, 0x70, 0x65, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0f, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x4a, 0x6f, 0x62, 0x53, 0x70,
+	0x65, 0x63, 0x52, 0x04, 0x73, 0x70, 0x65, 0x63, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x03, 0x70, 0x69, 0x64, 0x12, 0x39, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x74, 0x69,
+	0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12,
+	0x39, 0x0a, 0x0a, 0x74, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x64, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x65, 0x72,
+	0x6d, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x0a,
+	0x74, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x64, 0x12, 0x2d, 0x0a, 0x07, 0x73, 0x69,
+	0x67, 0x6e, 0x61, 0x6c, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6a, 0x6f,
+	0x62, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x52, 0x07, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x74, 0x74,
+	0x65, 0x6d, 0x70, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x61, 0x74, 0x74, 0x65,
+	0x6d, 0x70, 0x74, 0x12, 0x42, 0x0a, 0x11, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x5f,
+	0x61, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15,
+	0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x10, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x41,
+	0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x12, 0x46, 0x0a, 0x11, 0x6e, 0x65, 0x78, 0x74, 0x5f,
+	0x72, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x0a, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0f,
+	0x6e, 0x65, 0x78, 0x74, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12,
+	0x25, 0x0a, 0x0e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x5f, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x71, 0x75, 0x65, 0x75, 0x65, 0x50, 0x6f,
+	0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75,
+	0x6c, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x63, 0x68,
+	0x65, 0x64, 0x75, 0x6c, 0x65, 0x49, 0x64, 0x12, 0x2e, 0x0a, 0x06, 0x6c, 0x69, 0x6d, 0x69, 0x74,
+	0x73, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31,
+	0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x52,
+	0x06, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x70, 0x69, 0x64, 0x73, 0x5f,
+	0x6c, 0x69, 0x6d, 0x69, 0x74, 0x5f, 0x68, 0x69, 0x74, 0x73, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x0d, 0x70, 0x69, 0x64, 0x73, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x48, 0x69, 0x74, 0x73, 0x22,
+	0x98, 0x02, 0x0a, 0x0d, 0x41, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x07, 0x61, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x12, 0x23, 0x0a, 0x05, 0x73,
+	0x74, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0d, 0x2e, 0x6a, 0x6f, 0x62,
+	0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65,
+	0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x69,
+	0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x70, 0x69, 0x64, 0x12, 0x39, 0x0a, 0x0a,
+	0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x39, 0x0a, 0x0a, 0x74, 0x65, 0x72, 0x6d, 0x69,
+	0x6e, 0x61, 0x74, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6a, 0x6f,
+	0x62, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x0a, 0x74, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x74,
+	0x65, 0x64, 0x12, 0x26, 0x0a, 0x0f, 0x70, 0x69, 0x64, 0x73, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74,
+	0x5f, 0x68, 0x69, 0x74, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x70, 0x69, 0x64,
+	0x73, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x48, 0x69, 0x74, 0x73, 0x22, 0xc5, 0x01, 0x0a, 0x0c, 0x53,
+	0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x53, 0x70, 0x65, 0x63, 0x12, 0x12, 0x0a, 0x04, 0x63,
+	0x72, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x72, 0x6f, 0x6e, 0x12,
+	0x32, 0x0a, 0x0c, 0x6a, 0x6f, 0x62, 0x5f, 0x74, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x4a,
+	0x6f, 0x62, 0x53, 0x70, 0x65, 0x63, 0x52, 0x0b, 0x6a, 0x6f, 0x62, 0x54, 0x65, 0x6d, 0x70, 0x6c,
+	0x61, 0x74, 0x65, 0x12, 0x48, 0x0a, 0x12, 0x63, 0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e,
+	0x63, 0x79, 0x5f, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x19, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72,
+	0x65, 0x6e, 0x63, 0x79, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x11, 0x63, 0x6f, 0x6e, 0x63,
+	0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x23, 0x0a,
+	0x0d, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x4c, 0x69, 0x6d,
+	0x69, 0x74, 0x22, 0x1c, 0x0a, 0x0a, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x49, 0x64,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
+	0x22, 0x3b, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65,
+	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72,
+	0x88, 0x01, 0x01, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x22, 0x3a, 0x0a,
+	0x0c, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x2a, 0x0a,
+	0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6a,
+	0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x49, 0x6e,
+	0x66, 0x6f, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x22, 0xd0, 0x02, 0x0a, 0x0c, 0x53, 0x63,
+	0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x22, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e,
+	0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x49, 0x64, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14,
+	0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6f,
+	0x77, 0x6e, 0x65, 0x72, 0x12, 0x28, 0x0a, 0x04, 0x73, 0x70, 0x65, 0x63, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x63, 0x68, 0x65,
+	0x64, 0x75, 0x6c, 0x65, 0x53, 0x70, 0x65, 0x63, 0x52, 0x04, 0x73, 0x70, 0x65, 0x63, 0x12, 0x48,
+	0x0a, 0x12, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x5f,
+	0x74, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x10, 0x6c, 0x61, 0x73, 0x74, 0x53, 0x63, 0x68, 0x65,
+	0x64, 0x75, 0x6c, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x48, 0x0a, 0x12, 0x6e, 0x65, 0x78, 0x74,
+	0x5f, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x10, 0x6e, 0x65, 0x78, 0x74, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x54, 0x69,
+	0x6d, 0x65, 0x12, 0x2e, 0x0a, 0x0b, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x6a, 0x6f, 0x62,
+	0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31,
+	0x2e, 0x4a, 0x6f, 0x62, 0x49, 0x64, 0x52, 0x0a, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x4a, 0x6f,
+	0x62, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x37, 0x0a, 0x0c,
+	0x57, 0x6f, 0x72, 0x6b, 0x66, 0x6c, 0x6f, 0x77, 0x53, 0x70, 0x65, 0x63, 0x12, 0x27, 0x0a, 0x04,
+	0x6a, 0x6f, 0x62, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6a, 0x6f, 0x62,
+	0x2e, 0x76, 0x31, 0x2e, 0x57, 0x6f, 0x72, 0x6b, 0x66, 0x6c, 0x6f, 0x77, 0x4a, 0x6f, 0x62, 0x52,
+	0x04, 0x6a, 0x6f, 0x62, 0x73, 0x22, 0x65, 0x0a, 0x0b, 0x57, 0x6f, 0x72, 0x6b, 0x66, 0x6c, 0x6f,
+	0x77, 0x4a, 0x6f, 0x62, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x65, 0x70, 0x65,
+	0x6e, 0x64, 0x73, 0x5f, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x64, 0x65,
+	0x70, 0x65, 0x6e, 0x64, 0x73, 0x4f, 0x6e, 0x12, 0x23, 0x0a, 0x04, 0x73, 0x70, 0x65, 0x63, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x4a,
+	0x6f, 0x62, 0x53, 0x70, 0x65, 0x63, 0x52, 0x04, 0x73, 0x70, 0x65, 0x63, 0x22, 0x1c, 0x0a, 0x0a,
+	0x57, 0x6f, 0x72, 0x6b, 0x66, 0x6c, 0x6f, 0x77, 0x49, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x82, 0x01, 0x0a, 0x0e, 0x57,
+	0x6f, 0x72, 0x6b, 0x66, 0x6c, 0x6f, 0x77, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x2b, 0x0a,
+	0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x6a,
+	0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x6f, 0x72, 0x6b, 0x66, 0x6c, 0x6f, 0x77, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x77,
+	0x6e, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72,
+	0x12, 0x2d, 0x0a, 0x04, 0x6a, 0x6f, 0x62, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19,
+	0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x6f, 0x72, 0x6b, 0x66, 0x6c, 0x6f, 0x77,
+	0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x04, 0x6a, 0x6f, 0x62, 0x73, 0x22,
+	0x90, 0x01, 0x0a, 0x11, 0x57, 0x6f, 0x72, 0x6b, 0x66, 0x6c, 0x6f, 0x77, 0x4a, 0x6f, 0x62, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2e, 0x0a, 0x05, 0x73, 0x74, 0x61,
+	0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76,
+	0x31, 0x2e, 0x57, 0x6f, 0x72, 0x6b, 0x66, 0x6c, 0x6f, 0x77, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1d, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x4a,
+	0x6f, 0x62, 0x49, 0x64, 0x52, 0x02, 0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x22, 0x6b, 0x0a, 0x0d, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0d, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x4a, 0x6f, 0x62, 0x49, 0x64, 0x52, 0x02,
+	0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x06, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x12, 0x23, 0x0a, 0x0d, 0x61, 0x6c,
+	0x6c, 0x5f, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0c, 0x61, 0x6c, 0x6c, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x65, 0x73, 0x22,
+	0x64, 0x0a, 0x13, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x4a, 0x6f, 0x62, 0x49,
+	0x64, 0x52, 0x02, 0x69, 0x64, 0x12, 0x2e, 0x0a, 0x06, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x52,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x52, 0x06, 0x6c,
+	0x69, 0x6d, 0x69, 0x74, 0x73, 0x22, 0x8e, 0x01, 0x0a, 0x0b, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x6c,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x12, 0x23, 0x0a,
+	0x0d, 0x61, 0x6c, 0x6c, 0x5f, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x61, 0x6c, 0x6c, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73,
+	0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x22, 0xbf, 0x01, 0x0a, 0x11, 0x54, 0x65, 0x72, 0x6d, 0x69,
+	0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1b, 0x0a, 0x09,
+	0x65, 0x78, 0x69, 0x74, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x08, 0x65, 0x78, 0x69, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x69, 0x67,
+	0x6e, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x73, 0x69, 0x67, 0x6e, 0x61,
+	0x6c, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x74, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x07, 0x73, 0x74, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x12, 0x2e, 0x0a, 0x04, 0x74,
+	0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x2b, 0x0a, 0x11, 0x64,
+	0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x5f, 0x65, 0x78, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x64, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65,
+	0x45, 0x78, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x22, 0xe8, 0x01, 0x0a, 0x0b, 0x43, 0x6f, 0x6d,
+	0x6d, 0x61, 0x6e, 0x64, 0x53, 0x70, 0x65, 0x63, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d,
+	0x61, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61,
+	0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x72, 0x67, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x04, 0x61, 0x72, 0x67, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e, 0x76, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x03, 0x65, 0x6e, 0x76, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x64, 0x69,
+	0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x73, 0x74, 0x64, 0x69, 0x6e, 0x12, 0x10,
+	0x0a, 0x03, 0x74, 0x74, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x74, 0x74, 0x79,
+	0x12, 0x18, 0x0a, 0x07, 0x77, 0x6f, 0x72, 0x6b, 0x64, 0x69, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x77, 0x6f, 0x72, 0x6b, 0x64, 0x69, 0x72, 0x12, 0x32, 0x0a, 0x0a, 0x63, 0x72,
+	0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12,
+	0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69,
+	0x61, 0x6c, 0x52, 0x0a, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x12, 0x19,
+	0x0a, 0x05, 0x75, 0x6d, 0x61, 0x73, 0x6b, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x00, 0x52,
+	0x05, 0x75, 0x6d, 0x61, 0x73, 0x6b, 0x88, 0x01, 0x01, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x75, 0x6d,
+	0x61, 0x73, 0x6b, 0x22, 0x48, 0x0a, 0x0a, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61,
+	0x6c, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03,
+	0x75, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x67, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x03, 0x67, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x22, 0x36, 0x0a,
+	0x0c, 0x54, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x6c, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x72, 0x6f, 0x77, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x72, 0x6f, 0x77,
+	0x73, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x04, 0x63, 0x6f, 0x6c, 0x73, 0x22, 0xa6, 0x01, 0x0a, 0x0d, 0x41, 0x74, 0x74, 0x61, 0x63, 0x68,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x4a, 0x6f, 0x62,
+	0x49, 0x64, 0x48, 0x00, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x05, 0x69, 0x6e, 0x70, 0x75,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x05, 0x69, 0x6e, 0x70, 0x75, 0x74,
+	0x12, 0x21, 0x0a, 0x0b, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x5f, 0x73, 0x74, 0x64, 0x69, 0x6e, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x0a, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x53, 0x74,
+	0x64, 0x69, 0x6e, 0x12, 0x2e, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x65, 0x72,
+	0x6d, 0x69, 0x6e, 0x61, 0x6c, 0x53, 0x69, 0x7a, 0x65, 0x48, 0x00, 0x52, 0x06, 0x72, 0x65, 0x73,
+	0x69, 0x7a, 0x65, 0x42, 0x09, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x45,
+	0x0a, 0x0a, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x53, 0x70, 0x65, 0x63, 0x12, 0x26, 0x0a, 0x0c,
+	0x72, 0x65, 0x74, 0x61, 0x69, 0x6e, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x48, 0x00, 0x52, 0x0b, 0x72, 0x65, 0x74, 0x61, 0x69, 0x6e, 0x42, 0x79, 0x74, 0x65,
+	0x73, 0x88, 0x01, 0x01, 0x42, 0x0f, 0x0a, 0x0d, 0x5f, 0x72, 0x65, 0x74, 0x61, 0x69, 0x6e, 0x5f,
+	0x62, 0x79, 0x74, 0x65, 0x73, 0x22, 0x80, 0x02, 0x0a, 0x0d, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x4a, 0x6f, 0x62,
+	0x49, 0x64, 0x52, 0x02, 0x69, 0x64, 0x12, 0x28, 0x0a, 0x07, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x0e, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31,
+	0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x07, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73,
+	0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x22, 0x0a, 0x0a, 0x74, 0x61, 0x69, 0x6c,
+	0x5f, 0x6c, 0x69, 0x6e, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x09,
+	0x74, 0x61, 0x69, 0x6c, 0x4c, 0x69, 0x6e, 0x65, 0x73, 0x88, 0x01, 0x01, 0x12, 0x30, 0x0a, 0x05,
+	0x73, 0x69, 0x6e, 0x63, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x05, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x12, 0x1d,
+	0x0a, 0x07, 0x61, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x48,
+	0x01, 0x52, 0x07, 0x61, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x88, 0x01, 0x01, 0x42, 0x0d, 0x0a,
+	0x0b, 0x5f, 0x74, 0x61, 0x69, 0x6c, 0x5f, 0x6c, 0x69, 0x6e, 0x65, 0x73, 0x42, 0x0a, 0x0a, 0x08,
+	0x5f, 0x61, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x22, 0xbc, 0x01, 0x0a, 0x0d, 0x50, 0x72, 0x6f,
+	0x63, 0x65, 0x73, 0x73, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x75,
+	0x74, 0x70, 0x75, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x6f, 0x75, 0x74, 0x70,
+	0x75, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x5f, 0x62, 0x79,
+	0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x64, 0x72, 0x6f, 0x70, 0x70,
+	0x65, 0x64, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x26, 0x0a, 0x06, 0x73, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0e, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31,
+	0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x06, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12,
+	0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12,
+	0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x22, 0xc2, 0x02, 0x0a, 0x0e, 0x52, 0x65, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x15, 0x0a, 0x03, 0x63, 0x70,
+	0x75, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x03, 0x63, 0x70, 0x75, 0x88, 0x01,
+	0x01, 0x12, 0x2c, 0x0a, 0x06, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x14, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x6d, 0x6f, 0x72,
+	0x79, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x52, 0x06, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x12,
+	0x26, 0x0a, 0x02, 0x69, 0x6f, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6a, 0x6f,
+	0x62, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x4f, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x4c, 0x69, 0x6d,
+	0x69, 0x74, 0x73, 0x52, 0x02, 0x69, 0x6f, 0x12, 0x22, 0x0a, 0x0a, 0x63, 0x70, 0x75, 0x5f, 0x77,
+	0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x01, 0x52, 0x09, 0x63,
+	0x70, 0x75, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x88, 0x01, 0x01, 0x12, 0x38, 0x0a, 0x0a, 0x63,
+	0x70, 0x75, 0x5f, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x63, 0x70, 0x75, 0x50,
+	0x65, 0x72, 0x69, 0x6f, 0x64, 0x12, 0x2c, 0x0a, 0x06, 0x63, 0x70, 0x75, 0x73, 0x65, 0x74, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x43,
+	0x70, 0x75, 0x73, 0x65, 0x74, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x52, 0x06, 0x63, 0x70, 0x75,
+	0x73, 0x65, 0x74, 0x12, 0x17, 0x0a, 0x04, 0x70, 0x69, 0x64, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x03, 0x48, 0x02, 0x52, 0x04, 0x70, 0x69, 0x64, 0x73, 0x88, 0x01, 0x01, 0x42, 0x06, 0x0a, 0x04,
+	0x5f, 0x63, 0x70, 0x75, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x63, 0x70, 0x75, 0x5f, 0x77, 0x65, 0x69,
+	0x67, 0x68, 0x74, 0x42, 0x07, 0x0a, 0x05, 0x5f, 0x70, 0x69, 0x64, 0x73, 0x22, 0x36, 0x0a, 0x0c,
+	0x43, 0x70, 0x75, 0x73, 0x65, 0x74, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x12, 0x0a, 0x04,
+	0x63, 0x70, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x70, 0x75, 0x73,
+	0x12, 0x12, 0x0a, 0x04, 0x6d, 0x65, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6d, 0x65, 0x6d, 0x73, 0x22, 0x87, 0x02, 0x0a, 0x0c, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x4c,
+	0x69, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x22, 0x0a, 0x0a, 0x73, 0x6f, 0x66, 0x74, 0x5f, 0x6c, 0x69,
+	0x6d, 0x69, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x09, 0x73, 0x6f, 0x66,
+	0x74, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x88, 0x01, 0x01, 0x12, 0x19, 0x0a, 0x05, 0x6c, 0x69, 0x6d,
+	0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x48, 0x01, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69,
+	0x74, 0x88, 0x01, 0x01, 0x12, 0x22, 0x0a, 0x0a, 0x73, 0x77, 0x61, 0x70, 0x5f, 0x6c, 0x69, 0x6d,
+	0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x48, 0x02, 0x52, 0x09, 0x73, 0x77, 0x61, 0x70,
+	0x4c, 0x69, 0x6d, 0x69, 0x74, 0x88, 0x01, 0x01, 0x12, 0x15, 0x0a, 0x03, 0x6c, 0x6f, 0x77, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x03, 0x48, 0x03, 0x52, 0x03, 0x6c, 0x6f, 0x77, 0x88, 0x01, 0x01, 0x12,
+	0x15, 0x0a, 0x03, 0x6d, 0x69, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x48, 0x04, 0x52, 0x03,
+	0x6d, 0x69, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x20, 0x0a, 0x09, 0x6f, 0x6f, 0x6d, 0x5f, 0x67, 0x72,
+	0x6f, 0x75, 0x70, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x48, 0x05, 0x52, 0x08, 0x6f, 0x6f, 0x6d,
+	0x47, 0x72, 0x6f, 0x75, 0x70, 0x88, 0x01, 0x01, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x73, 0x6f, 0x66,
+	0x74, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x6c, 0x69, 0x6d, 0x69,
+	0x74, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x73, 0x77, 0x61, 0x70, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74,
+	0x42, 0x06, 0x0a, 0x04, 0x5f, 0x6c, 0x6f, 0x77, 0x42, 0x06, 0x0a, 0x04, 0x5f, 0x6d, 0x69, 0x6e,
+	0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x6f, 0x6f, 0x6d, 0x5f, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x22, 0x52,
+	0x0a, 0x0e, 0x49, 0x4f, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73,
+	0x12, 0x16, 0x0a, 0x06, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x12, 0x28, 0x0a, 0x06, 0x6c, 0x69, 0x6d, 0x69,
+	0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76,
+	0x31, 0x2e, 0x49, 0x4f, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x52, 0x06, 0x6c, 0x69, 0x6d, 0x69,
+	0x74, 0x73, 0x22, 0xca, 0x01, 0x0a, 0x08, 0x49, 0x4f, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x12,
+	0x1e, 0x0a, 0x08, 0x72, 0x65, 0x61, 0x64, 0x5f, 0x62, 0x70, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x48, 0x00, 0x52, 0x07, 0x72, 0x65, 0x61, 0x64, 0x42, 0x70, 0x73, 0x88, 0x01, 0x01, 0x12,
+	0x20, 0x0a, 0x09, 0x77, 0x72, 0x69, 0x74, 0x65, 0x5f, 0x62, 0x70, 0x73, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x03, 0x48, 0x01, 0x52, 0x08, 0x77, 0x72, 0x69, 0x74, 0x65, 0x42, 0x70, 0x73, 0x88, 0x01,
+	0x01, 0x12, 0x20, 0x0a, 0x09, 0x72, 0x65, 0x61, 0x64, 0x5f, 0x69, 0x6f, 0x70, 0x73, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x03, 0x48, 0x02, 0x52, 0x08, 0x72, 0x65, 0x61, 0x64, 0x49, 0x6f, 0x70, 0x73,
+	0x88, 0x01, 0x01, 0x12, 0x22, 0x0a, 0x0a, 0x77, 0x72, 0x69, 0x74, 0x65, 0x5f, 0x69, 0x6f, 0x70,
+	0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x48, 0x03, 0x52, 0x09, 0x77, 0x72, 0x69, 0x74, 0x65,
+	0x49, 0x6f, 0x70, 0x73, 0x88, 0x01, 0x01, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x72, 0x65, 0x61, 0x64,
+	0x5f, 0x62, 0x70, 0x73, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x77, 0x72, 0x69, 0x74, 0x65, 0x5f, 0x62,
+	0x70, 0x73, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x72, 0x65, 0x61, 0x64, 0x5f, 0x69, 0x6f, 0x70, 0x73,
+	0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x77, 0x72, 0x69, 0x74, 0x65, 0x5f, 0x69, 0x6f, 0x70, 0x73, 0x22,
+	0xde, 0x01, 0x0a, 0x0d, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x55, 0x73, 0x61, 0x67,
+	0x65, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x74, 0x69, 0x6d,
+	0x65, 0x12, 0x22, 0x0a, 0x03, 0x63, 0x70, 0x75, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10,
+	0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x70, 0x75, 0x55, 0x73, 0x61, 0x67, 0x65,
+	0x52, 0x03, 0x63, 0x70, 0x75, 0x12, 0x2b, 0x0a, 0x06, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x4d,
+	0x65, 0x6d, 0x6f, 0x72, 0x79, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x06, 0x6d, 0x65, 0x6d, 0x6f,
+	0x72, 0x79, 0x12, 0x25, 0x0a, 0x02, 0x69, 0x6f, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15,
+	0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x4f,
+	0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x02, 0x69, 0x6f, 0x12, 0x25, 0x0a, 0x04, 0x70, 0x69, 0x64,
+	0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31,
+	0x2e, 0x50, 0x69, 0x64, 0x73, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x04, 0x70, 0x69, 0x64, 0x73,
+	0x22, 0xd0, 0x01, 0x0a, 0x08, 0x43, 0x70, 0x75, 0x55, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1d, 0x0a,
+	0x0a, 0x75, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x75, 0x73, 0x65, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x09, 0x75, 0x73, 0x61, 0x67, 0x65, 0x55, 0x73, 0x65, 0x63, 0x12, 0x1b, 0x0a, 0x09,
+	0x75, 0x73, 0x65, 0x72, 0x5f, 0x75, 0x73, 0x65, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x08, 0x75, 0x73, 0x65, 0x72, 0x55, 0x73, 0x65, 0x63, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x79, 0x73,
+	0x74, 0x65, 0x6d, 0x5f, 0x75, 0x73, 0x65, 0x63, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a,
+	0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x55, 0x73, 0x65, 0x63, 0x12, 0x1d, 0x0a, 0x0a, 0x6e, 0x72,
+	0x5f, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09,
+	0x6e, 0x72, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x6e, 0x72, 0x5f,
+	0x74, 0x68, 0x72, 0x6f, 0x74, 0x74, 0x6c, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x0b, 0x6e, 0x72, 0x54, 0x68, 0x72, 0x6f, 0x74, 0x74, 0x6c, 0x65, 0x64, 0x12, 0x25, 0x0a, 0x0e,
+	0x74, 0x68, 0x72, 0x6f, 0x74, 0x74, 0x6c, 0x65, 0x64, 0x5f, 0x75, 0x73, 0x65, 0x63, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x74, 0x68, 0x72, 0x6f, 0x74, 0x74, 0x6c, 0x65, 0x64, 0x55,
+	0x73, 0x65, 0x63, 0x22, 0xb3, 0x01, 0x0a, 0x0b, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x55, 0x73,
+	0x61, 0x67, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x12, 0x17, 0x0a,
+	0x04, 0x70, 0x65, 0x61, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x48, 0x00, 0x52, 0x04, 0x70,
+	0x65, 0x61, 0x6b, 0x88, 0x01, 0x01, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x6e, 0x6f, 0x6e, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x61, 0x6e, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x69,
+	0x6c, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x16,
+	0x0a, 0x06, 0x6b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06,
+	0x6b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x68, 0x6d, 0x65, 0x6d, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x68, 0x6d, 0x65, 0x6d, 0x12, 0x12, 0x0a, 0x04,
+	0x73, 0x6f, 0x63, 0x6b, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x73, 0x6f, 0x63, 0x6b,
+	0x42, 0x07, 0x0a, 0x05, 0x5f, 0x70, 0x65, 0x61, 0x6b, 0x22, 0xe5, 0x01, 0x0a, 0x0d, 0x44, 0x65,
+	0x76, 0x69, 0x63, 0x65, 0x49, 0x4f, 0x55, 0x73, 0x61, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x64,
+	0x65, 0x76, 0x69, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x65, 0x76,
+	0x69, 0x63, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x61, 0x64, 0x5f, 0x62, 0x79, 0x74, 0x65,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x72, 0x65, 0x61, 0x64, 0x42, 0x79, 0x74,
+	0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x77, 0x72, 0x69, 0x74, 0x65, 0x5f, 0x62, 0x79, 0x74, 0x65,
+	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x77, 0x72, 0x69, 0x74, 0x65, 0x42, 0x79,
+	0x74, 0x65, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x72, 0x65, 0x61, 0x64, 0x5f, 0x69, 0x6f, 0x73, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x72, 0x65, 0x61, 0x64, 0x49, 0x6f, 0x73, 0x12, 0x1b,
+	0x0a, 0x09, 0x77, 0x72, 0x69, 0x74, 0x65, 0x5f, 0x69, 0x6f, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x08, 0x77, 0x72, 0x69, 0x74, 0x65, 0x49, 0x6f, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x64,
+	0x69, 0x73, 0x63, 0x61, 0x72, 0x64, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x0c, 0x64, 0x69, 0x73, 0x63, 0x61, 0x72, 0x64, 0x42, 0x79, 0x74, 0x65, 0x73,
+	0x12, 0x1f, 0x0a, 0x0b, 0x64, 0x69, 0x73, 0x63, 0x61, 0x72, 0x64, 0x5f, 0x69, 0x6f, 0x73, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x64, 0x69, 0x73, 0x63, 0x61, 0x72, 0x64, 0x49, 0x6f,
+	0x73, 0x22, 0x25, 0x0a, 0x09, 0x50, 0x69, 0x64, 0x73, 0x55, 0x73, 0x61, 0x67, 0x65, 0x12, 0x18,
+	0x0a, 0x07, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x07, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x2a, 0x34, 0x0a, 0x0b, 0x52, 0x65, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x09, 0x0a, 0x05, 0x4e, 0x45, 0x56, 0x45, 0x52,
+	0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x4f, 0x4e, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x55, 0x52, 0x45,
+	0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x41, 0x4c, 0x57, 0x41, 0x59, 0x53, 0x10, 0x02, 0x2a, 0x5a,
+	0x0a, 0x05, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f,
+	0x57, 0x4e, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x50, 0x45, 0x4e, 0x44, 0x49, 0x4e, 0x47, 0x10,
+	0x01, 0x12, 0x0a, 0x0a, 0x06, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x02, 0x12, 0x0b, 0x0a,
+	0x07, 0x52, 0x55, 0x4e, 0x4e, 0x49, 0x4e, 0x47, 0x10, 0x03, 0x12, 0x0e, 0x0a, 0x0a, 0x54, 0x45,
+	0x52, 0x4d, 0x49, 0x4e, 0x41, 0x54, 0x45, 0x44, 0x10, 0x04, 0x12, 0x0e, 0x0a, 0x0a, 0x52, 0x45,
+	0x53, 0x54, 0x41, 0x52, 0x54, 0x49, 0x4e, 0x47, 0x10, 0x05, 0x2a, 0x37, 0x0a, 0x11, 0x43, 0x6f,
+	0x6e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12,
+	0x09, 0x0a, 0x05, 0x41, 0x4c, 0x4c, 0x4f, 0x57, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x46, 0x4f,
+	0x52, 0x42, 0x49, 0x44, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x52, 0x45, 0x50, 0x4c, 0x41, 0x43,
+	0x45, 0x10, 0x02, 0x2a, 0x52, 0x0a, 0x0d, 0x57, 0x6f, 0x72, 0x6b, 0x66, 0x6c, 0x6f, 0x77, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x12, 0x14, 0x0a, 0x10, 0x57, 0x4f, 0x52, 0x4b, 0x46, 0x4c, 0x4f, 0x57,
+	0x5f, 0x52, 0x55, 0x4e, 0x4e, 0x49, 0x4e, 0x47, 0x10, 0x00, 0x12, 0x16, 0x0a, 0x12, 0x57, 0x4f,
+	0x52, 0x4b, 0x46, 0x4c, 0x4f, 0x57, 0x5f, 0x53, 0x55, 0x43, 0x43, 0x45, 0x45, 0x44, 0x45, 0x44,
+	0x10, 0x01, 0x12, 0x13, 0x0a, 0x0f, 0x57, 0x4f, 0x52, 0x4b, 0x46, 0x4c, 0x4f, 0x57, 0x5f, 0x46,
+	0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x02, 0x2a, 0x95, 0x01, 0x0a, 0x10, 0x57, 0x6f, 0x72, 0x6b,
+	0x66, 0x6c, 0x6f, 0x77, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x18, 0x0a, 0x14,
+	0x57, 0x4f, 0x52, 0x4b, 0x46, 0x4c, 0x4f, 0x57, 0x5f, 0x4a, 0x4f, 0x42, 0x5f, 0x57, 0x41, 0x49,
+	0x54, 0x49, 0x4e, 0x47, 0x10, 0x00, 0x12, 0x18, 0x0a, 0x14, 0x57, 0x4f, 0x52, 0x4b, 0x46, 0x4c,
+	0x4f, 0x57, 0x5f, 0x4a, 0x4f, 0x42, 0x5f, 0x53, 0x54, 0x41, 0x52, 0x54, 0x45, 0x44, 0x10, 0x01,
+	0x12, 0x1a, 0x0a, 0x16, 0x57, 0x4f, 0x52, 0x4b, 0x46, 0x4c, 0x4f, 0x57, 0x5f, 0x4a, 0x4f, 0x42,
+	0x5f, 0x53, 0x55, 0x43, 0x43, 0x45, 0x45, 0x44, 0x45, 0x44, 0x10, 0x02, 0x12, 0x17, 0x0a, 0x13,
+	0x57, 0x4f, 0x52, 0x4b, 0x46, 0x4c, 0x4f, 0x57, 0x5f, 0x4a, 0x4f, 0x42, 0x5f, 0x46, 0x41, 0x49,
+	0x4c, 0x45, 0x44, 0x10, 0x03, 0x12, 0x18, 0x0a, 0x14, 0x57, 0x4f, 0x52, 0x4b, 0x46, 0x4c, 0x4f,
+	0x57, 0x5f, 0x4a, 0x4f, 0x42, 0x5f, 0x53, 0x4b, 0x49, 0x50, 0x50, 0x45, 0x44, 0x10, 0x04, 0x2a,
+	0x38, 0x0a, 0x06, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x16, 0x0a, 0x12, 0x53, 0x54, 0x52,
+	0x45, 0x41, 0x4d, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10,
+	0x00, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x54, 0x44, 0x4f, 0x55, 0x54, 0x10, 0x01, 0x12, 0x0a, 0x0a,
+	0x06, 0x53, 0x54, 0x44, 0x45, 0x52, 0x52, 0x10, 0x02, 0x32, 0xe7, 0x07, 0x0a, 0x03, 0x4a, 0x6f,
+	0x62, 0x12, 0x27, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x72, 0x74, 0x12, 0x0f, 0x2e, 0x6a, 0x6f, 0x62,
+	0x2e, 0x76, 0x31, 0x2e, 0x4a, 0x6f, 0x62, 0x53, 0x70, 0x65, 0x63, 0x1a, 0x0d, 0x2e, 0x6a, 0x6f,
+	0x62, 0x2e, 0x76, 0x31, 0x2e, 0x4a, 0x6f, 0x62, 0x49, 0x64, 0x12, 0x39, 0x0a, 0x04, 0x53, 0x74,
+	0x6f, 0x70, 0x12, 0x13, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x6f, 0x70,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31,
+	0x2e, 0x53, 0x74, 0x6f, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x06, 0x82,
+	0xb5, 0x18, 0x02, 0x08, 0x01, 0x12, 0x32, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
+	0x0d, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x4a, 0x6f, 0x62, 0x49, 0x64, 0x1a, 0x11,
+	0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x22, 0x06, 0x82, 0xb5, 0x18, 0x02, 0x08, 0x01, 0x12, 0x35, 0x0a, 0x05, 0x55, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x0d, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x4a, 0x6f, 0x62, 0x49,
+	0x64, 0x1a, 0x15, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x55, 0x73, 0x61, 0x67, 0x65, 0x22, 0x06, 0x82, 0xb5, 0x18, 0x02, 0x08, 0x01,
+	0x12, 0x34, 0x0a, 0x04, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x13, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76,
+	0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0f, 0x2e,
+	0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x4a, 0x6f, 0x62, 0x4c, 0x69, 0x73, 0x74, 0x22, 0x06,
+	0x82, 0xb5, 0x18, 0x02, 0x08, 0x01, 0x12, 0x40, 0x0a, 0x06, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74,
+	0x12, 0x15, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31,
+	0x2e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x22, 0x06,
+	0x82, 0xb5, 0x18, 0x02, 0x08, 0x01, 0x30, 0x01, 0x12, 0x37, 0x0a, 0x06, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x12, 0x0d, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x4a, 0x6f, 0x62, 0x49,
+	0x64, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x06, 0x82, 0xb5, 0x18, 0x02, 0x08,
+	0x01, 0x12, 0x3b, 0x0a, 0x05, 0x57, 0x61, 0x74, 0x63, 0x68, 0x12, 0x14, 0x2e, 0x6a, 0x6f, 0x62,
+	0x2e, 0x76, 0x31, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x12, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x22, 0x06, 0x82, 0xb5, 0x18, 0x02, 0x08, 0x01, 0x30, 0x01, 0x12, 0x42,
+	0x0a, 0x06, 0x41, 0x74, 0x74, 0x61, 0x63, 0x68, 0x12, 0x15, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76,
+	0x31, 0x2e, 0x41, 0x74, 0x74, 0x61, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x15, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73,
+	0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x22, 0x06, 0x82, 0xb5, 0x18, 0x02, 0x08, 0x01, 0x28, 0x01,
+	0x30, 0x01, 0x12, 0x3f, 0x0a, 0x06, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x12, 0x15, 0x2e, 0x6a,
+	0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x06, 0x82, 0xb5, 0x18,
+	0x02, 0x08, 0x01, 0x12, 0x4b, 0x0a, 0x0c, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d,
+	0x69, 0x74, 0x73, 0x12, 0x1b, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x16, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x22, 0x06, 0x82, 0xb5, 0x18, 0x02, 0x08, 0x01,
+	0x12, 0x3a, 0x0a, 0x0e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75,
+	0x6c, 0x65, 0x12, 0x14, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x63, 0x68, 0x65,
+	0x64, 0x75, 0x6c, 0x65, 0x53, 0x70, 0x65, 0x63, 0x1a, 0x12, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76,
+	0x31, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x49, 0x64, 0x12, 0x4b, 0x0a, 0x0d,
+	0x4c, 0x69, 0x73, 0x74, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x12, 0x1c, 0x2e,
+	0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x63, 0x68, 0x65, 0x64,
+	0x75, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x6a, 0x6f,
+	0x62, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x4c, 0x69, 0x73,
+	0x74, 0x22, 0x06, 0x82, 0xb5, 0x18, 0x02, 0x08, 0x01, 0x12, 0x44, 0x0a, 0x0e, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x12, 0x12, 0x2e, 0x6a, 0x6f,
+	0x62, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x49, 0x64, 0x1a,
+	0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x06, 0x82, 0xb5, 0x18, 0x02, 0x08, 0x01, 0x12,
+	0x39, 0x0a, 0x0d, 0x53, 0x74, 0x61, 0x72, 0x74, 0x57, 0x6f, 0x72, 0x6b, 0x66, 0x6c, 0x6f, 0x77,
+	0x12, 0x14, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x6f, 0x72, 0x6b, 0x66, 0x6c,
+	0x6f, 0x77, 0x53, 0x70, 0x65, 0x63, 0x1a, 0x12, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e,
+	0x57, 0x6f, 0x72, 0x6b, 0x66, 0x6c, 0x6f, 0x77, 0x49, 0x64, 0x12, 0x47, 0x0a, 0x11, 0x47, 0x65,
+	0x74, 0x57, 0x6f, 0x72, 0x6b, 0x66, 0x6c, 0x6f, 0x77, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
+	0x12, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x6f, 0x72, 0x6b, 0x66, 0x6c, 0x6f,
+	0x77, 0x49, 0x64, 0x1a, 0x16, 0x2e, 0x6a, 0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x6f, 0x72,
+	0x6b, 0x66, 0x6c, 0x6f, 0x77, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x06, 0x82, 0xb5, 0x18,
+	0x02, 0x08, 0x01, 0x42, 0x35, 0x5a, 0x33, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2f, 0x6b, 0x72, 0x61, 0x6c, 0x69, 0x63, 0x6b, 0x79, 0x2f, 0x6a, 0x6f, 0x62, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x61, 0x70, 0x69, 0x73, 0x2f, 0x6a, 0x6f,
+	0x62, 0x2f, 0x76, 0x31, 0x3b, 0x6a, 0x6f, 0x62, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
 }
 
 var (
@@ -932,51 +4474,174 @@ func file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_rawDescGZIP()
 	return file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_rawDescData
 }
 
-var file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
+var file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes = make([]protoimpl.MessageInfo, 45)
 var file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_goTypes = []interface{}{
-	(State)(0),                    // 0: job.v1.State
-	(*JobSpec)(nil),               // 1: job.v1.JobSpec
-	(*JobId)(nil),                 // 2: job.v1.JobId
-	(*JobIdList)(nil),             // 3: job.v1.JobIdList
-	(*JobStatus)(nil),             // 4: job.v1.JobStatus
-	(*TerminationStatus)(nil),     // 5: job.v1.TerminationStatus
-	(*CommandSpec)(nil),           // 6: job.v1.CommandSpec
-	(*ProcessOutput)(nil),         // 7: job.v1.ProcessOutput
-	(*ResourceLimits)(nil),        // 8: job.v1.ResourceLimits
-	(*MemoryLimits)(nil),          // 9: job.v1.MemoryLimits
-	(*IODeviceLimits)(nil),        // 10: job.v1.IODeviceLimits
-	(*IOLimits)(nil),              // 11: job.v1.IOLimits
-	(*timestamppb.Timestamp)(nil), // 12: google.protobuf.Timestamp
-	(*emptypb.Empty)(nil),         // 13: google.protobuf.Empty
+	(RestartMode)(0),              // 0: job.v1.RestartMode
+	(State)(0),                    // 1: job.v1.State
+	(ConcurrencyPolicy)(0),        // 2: job.v1.ConcurrencyPolicy
+	(WorkflowState)(0),            // 3: job.v1.WorkflowState
+	(WorkflowJobState)(0),         // 4: job.v1.WorkflowJobState
+	(Stream)(0),                   // 5: job.v1.Stream
+	(*JobSpec)(nil),               // 6: job.v1.JobSpec
+	(*RestartPolicy)(nil),         // 7: job.v1.RestartPolicy
+	(*StopPolicy)(nil),            // 8: job.v1.StopPolicy
+	(*StopRequest)(nil),           // 9: job.v1.StopRequest
+	(*StopResponse)(nil),          // 10: job.v1.StopResponse
+	(*JobId)(nil),                 // 11: job.v1.JobId
+	(*ListRequest)(nil),           // 12: job.v1.ListRequest
+	(*JobList)(nil),               // 13: job.v1.JobList
+	(*JobInfo)(nil),               // 14: job.v1.JobInfo
+	(*WatchRequest)(nil),          // 15: job.v1.WatchRequest
+	(*WatchEvent)(nil),            // 16: job.v1.WatchEvent
+	(*JobStatus)(nil),             // 17: job.v1.JobStatus
+	(*AttemptStatus)(nil),         // 18: job.v1.AttemptStatus
+	(*ScheduleSpec)(nil),          // 19: job.v1.ScheduleSpec
+	(*ScheduleId)(nil),            // 20: job.v1.ScheduleId
+	(*ListSchedulesRequest)(nil),  // 21: job.v1.ListSchedulesRequest
+	(*ScheduleList)(nil),          // 22: job.v1.ScheduleList
+	(*ScheduleInfo)(nil),          // 23: job.v1.ScheduleInfo
+	(*WorkflowSpec)(nil),          // 24: job.v1.WorkflowSpec
+	(*WorkflowJob)(nil),           // 25: job.v1.WorkflowJob
+	(*WorkflowId)(nil),            // 26: job.v1.WorkflowId
+	(*WorkflowStatus)(nil),        // 27: job.v1.WorkflowStatus
+	(*WorkflowJobStatus)(nil),     // 28: job.v1.WorkflowJobStatus
+	(*SignalRequest)(nil),         // 29: job.v1.SignalRequest
+	(*UpdateLimitsRequest)(nil),   // 30: job.v1.UpdateLimitsRequest
+	(*SignalEvent)(nil),           // 31: job.v1.SignalEvent
+	(*TerminationStatus)(nil),     // 32: job.v1.TerminationStatus
+	(*CommandSpec)(nil),           // 33: job.v1.CommandSpec
+	(*Credential)(nil),            // 34: job.v1.Credential
+	(*TerminalSize)(nil),          // 35: job.v1.TerminalSize
+	(*AttachRequest)(nil),         // 36: job.v1.AttachRequest
+	(*OutputSpec)(nil),            // 37: job.v1.OutputSpec
+	(*OutputRequest)(nil),         // 38: job.v1.OutputRequest
+	(*ProcessOutput)(nil),         // 39: job.v1.ProcessOutput
+	(*ResourceLimits)(nil),        // 40: job.v1.ResourceLimits
+	(*CpusetLimits)(nil),          // 41: job.v1.CpusetLimits
+	(*MemoryLimits)(nil),          // 42: job.v1.MemoryLimits
+	(*IODeviceLimits)(nil),        // 43: job.v1.IODeviceLimits
+	(*IOLimits)(nil),              // 44: job.v1.IOLimits
+	(*ResourceUsage)(nil),         // 45: job.v1.ResourceUsage
+	(*CpuUsage)(nil),              // 46: job.v1.CpuUsage
+	(*MemoryUsage)(nil),           // 47: job.v1.MemoryUsage
+	(*DeviceIOUsage)(nil),         // 48: job.v1.DeviceIOUsage
+	(*PidsUsage)(nil),             // 49: job.v1.PidsUsage
+	nil,                           // 50: job.v1.JobSpec.LabelsEntry
+	(*durationpb.Duration)(nil),   // 51: google.protobuf.Duration
+	(*timestamppb.Timestamp)(nil), // 52: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),         // 53: google.protobuf.Empty
 }
 var file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_depIdxs = []int32{
-	6,  // 0: job.v1.JobSpec.command:type_name -> job.v1.CommandSpec
-	8,  // 1: job.v1.JobSpec.limits:type_name -> job.v1.ResourceLimits
-	2,  // 2: job.v1.JobIdList.items:type_name -> job.v1.JobId
-	0,  // 3: job.v1.JobStatus.state:type_name -> job.v1.State
-	1,  // 4: job.v1.JobStatus.spec:type_name -> job.v1.JobSpec
-	12, // 5: job.v1.JobStatus.start_time:type_name -> google.protobuf.Timestamp
-	5,  // 6: job.v1.JobStatus.terminated:type_name -> job.v1.TerminationStatus
-	12, // 7: job.v1.TerminationStatus.time:type_name -> google.protobuf.Timestamp
-	9,  // 8: job.v1.ResourceLimits.memory:type_name -> job.v1.MemoryLimits
-	10, // 9: job.v1.ResourceLimits.io:type_name -> job.v1.IODeviceLimits
-	11, // 10: job.v1.IODeviceLimits.limits:type_name -> job.v1.IOLimits
-	1,  // 11: job.v1.Job.Start:input_type -> job.v1.JobSpec
-	2,  // 12: job.v1.Job.Stop:input_type -> job.v1.JobId
-	2,  // 13: job.v1.Job.Status:input_type -> job.v1.JobId
-	13, // 14: job.v1.Job.List:input_type -> google.protobuf.Empty
-	2,  // 15: job.v1.Job.Output:input_type -> job.v1.JobId
-	2,  // 16: job.v1.Job.Start:output_type -> job.v1.JobId
-	13, // 17: job.v1.Job.Stop:output_type -> google.protobuf.Empty
-	4,  // 18: job.v1.Job.Status:output_type -> job.v1.JobStatus
-	3,  // 19: job.v1.Job.List:output_type -> job.v1.JobIdList
-	7,  // 20: job.v1.Job.Output:output_type -> job.v1.ProcessOutput
-	16, // [16:21] is the sub-list for method output_type
-	11, // [11:16] is the sub-list for method input_type
-	11, // [11:11] is the sub-list for extension type_name
-	11, // [11:11] is the sub-list for extension extendee
-	0,  // [0:11] is the sub-list for field type_name
+	33, // 0: job.v1.JobSpec.command:type_name -> job.v1.CommandSpec
+	40, // 1: job.v1.JobSpec.limits:type_name -> job.v1.ResourceLimits
+	37, // 2: job.v1.JobSpec.output:type_name -> job.v1.OutputSpec
+	8,  // 3: job.v1.JobSpec.stop:type_name -> job.v1.StopPolicy
+	51, // 4: job.v1.JobSpec.timeout:type_name -> google.protobuf.Duration
+	52, // 5: job.v1.JobSpec.deadline:type_name -> google.protobuf.Timestamp
+	7,  // 6: job.v1.JobSpec.restart:type_name -> job.v1.RestartPolicy
+	50, // 7: job.v1.JobSpec.labels:type_name -> job.v1.JobSpec.LabelsEntry
+	0,  // 8: job.v1.RestartPolicy.mode:type_name -> job.v1.RestartMode
+	51, // 9: job.v1.RestartPolicy.initial_backoff:type_name -> google.protobuf.Duration
+	51, // 10: job.v1.RestartPolicy.max_backoff:type_name -> google.protobuf.Duration
+	51, // 11: job.v1.StopPolicy.grace_period:type_name -> google.protobuf.Duration
+	11, // 12: job.v1.StopRequest.id:type_name -> job.v1.JobId
+	51, // 13: job.v1.StopRequest.grace_period:type_name -> google.protobuf.Duration
+	11, // 14: job.v1.StopResponse.stopped:type_name -> job.v1.JobId
+	1,  // 15: job.v1.ListRequest.states:type_name -> job.v1.State
+	52, // 16: job.v1.ListRequest.started_after:type_name -> google.protobuf.Timestamp
+	52, // 17: job.v1.ListRequest.started_before:type_name -> google.protobuf.Timestamp
+	14, // 18: job.v1.JobList.items:type_name -> job.v1.JobInfo
+	11, // 19: job.v1.JobInfo.id:type_name -> job.v1.JobId
+	17, // 20: job.v1.JobInfo.status:type_name -> job.v1.JobStatus
+	11, // 21: job.v1.WatchRequest.id:type_name -> job.v1.JobId
+	11, // 22: job.v1.WatchEvent.id:type_name -> job.v1.JobId
+	17, // 23: job.v1.WatchEvent.status:type_name -> job.v1.JobStatus
+	1,  // 24: job.v1.JobStatus.state:type_name -> job.v1.State
+	6,  // 25: job.v1.JobStatus.spec:type_name -> job.v1.JobSpec
+	52, // 26: job.v1.JobStatus.start_time:type_name -> google.protobuf.Timestamp
+	32, // 27: job.v1.JobStatus.terminated:type_name -> job.v1.TerminationStatus
+	31, // 28: job.v1.JobStatus.signals:type_name -> job.v1.SignalEvent
+	18, // 29: job.v1.JobStatus.previous_attempts:type_name -> job.v1.AttemptStatus
+	52, // 30: job.v1.JobStatus.next_restart_time:type_name -> google.protobuf.Timestamp
+	40, // 31: job.v1.JobStatus.limits:type_name -> job.v1.ResourceLimits
+	1,  // 32: job.v1.AttemptStatus.state:type_name -> job.v1.State
+	52, // 33: job.v1.AttemptStatus.start_time:type_name -> google.protobuf.Timestamp
+	32, // 34: job.v1.AttemptStatus.terminated:type_name -> job.v1.TerminationStatus
+	6,  // 35: job.v1.ScheduleSpec.job_template:type_name -> job.v1.JobSpec
+	2,  // 36: job.v1.ScheduleSpec.concurrency_policy:type_name -> job.v1.ConcurrencyPolicy
+	23, // 37: job.v1.ScheduleList.items:type_name -> job.v1.ScheduleInfo
+	20, // 38: job.v1.ScheduleInfo.id:type_name -> job.v1.ScheduleId
+	19, // 39: job.v1.ScheduleInfo.spec:type_name -> job.v1.ScheduleSpec
+	52, // 40: job.v1.ScheduleInfo.last_schedule_time:type_name -> google.protobuf.Timestamp
+	52, // 41: job.v1.ScheduleInfo.next_schedule_time:type_name -> google.protobuf.Timestamp
+	11, // 42: job.v1.ScheduleInfo.active_jobs:type_name -> job.v1.JobId
+	25, // 43: job.v1.WorkflowSpec.jobs:type_name -> job.v1.WorkflowJob
+	6,  // 44: job.v1.WorkflowJob.spec:type_name -> job.v1.JobSpec
+	3,  // 45: job.v1.WorkflowStatus.state:type_name -> job.v1.WorkflowState
+	28, // 46: job.v1.WorkflowStatus.jobs:type_name -> job.v1.WorkflowJobStatus
+	4,  // 47: job.v1.WorkflowJobStatus.state:type_name -> job.v1.WorkflowJobState
+	11, // 48: job.v1.WorkflowJobStatus.id:type_name -> job.v1.JobId
+	11, // 49: job.v1.SignalRequest.id:type_name -> job.v1.JobId
+	11, // 50: job.v1.UpdateLimitsRequest.id:type_name -> job.v1.JobId
+	40, // 51: job.v1.UpdateLimitsRequest.limits:type_name -> job.v1.ResourceLimits
+	52, // 52: job.v1.SignalEvent.time:type_name -> google.protobuf.Timestamp
+	52, // 53: job.v1.TerminationStatus.time:type_name -> google.protobuf.Timestamp
+	34, // 54: job.v1.CommandSpec.credential:type_name -> job.v1.Credential
+	11, // 55: job.v1.AttachRequest.id:type_name -> job.v1.JobId
+	35, // 56: job.v1.AttachRequest.resize:type_name -> job.v1.TerminalSize
+	11, // 57: job.v1.OutputRequest.id:type_name -> job.v1.JobId
+	5,  // 58: job.v1.OutputRequest.streams:type_name -> job.v1.Stream
+	52, // 59: job.v1.OutputRequest.since:type_name -> google.protobuf.Timestamp
+	5,  // 60: job.v1.ProcessOutput.stream:type_name -> job.v1.Stream
+	52, // 61: job.v1.ProcessOutput.time:type_name -> google.protobuf.Timestamp
+	42, // 62: job.v1.ResourceLimits.memory:type_name -> job.v1.MemoryLimits
+	43, // 63: job.v1.ResourceLimits.io:type_name -> job.v1.IODeviceLimits
+	51, // 64: job.v1.ResourceLimits.cpu_period:type_name -> google.protobuf.Duration
+	41, // 65: job.v1.ResourceLimits.cpuset:type_name -> job.v1.CpusetLimits
+	44, // 66: job.v1.IODeviceLimits.limits:type_name -> job.v1.IOLimits
+	52, // 67: job.v1.ResourceUsage.time:type_name -> google.protobuf.Timestamp
+	46, // 68: job.v1.ResourceUsage.cpu:type_name -> job.v1.CpuUsage
+	47, // 69: job.v1.ResourceUsage.memory:type_name -> job.v1.MemoryUsage
+	48, // 70: job.v1.ResourceUsage.io:type_name -> job.v1.DeviceIOUsage
+	49, // 71: job.v1.ResourceUsage.pids:type_name -> job.v1.PidsUsage
+	6,  // 72: job.v1.Job.Start:input_type -> job.v1.JobSpec
+	9,  // 73: job.v1.Job.Stop:input_type -> job.v1.StopRequest
+	11, // 74: job.v1.Job.Status:input_type -> job.v1.JobId
+	11, // 75: job.v1.Job.Usage:input_type -> job.v1.JobId
+	12, // 76: job.v1.Job.List:input_type -> job.v1.ListRequest
+	38, // 77: job.v1.Job.Output:input_type -> job.v1.OutputRequest
+	11, // 78: job.v1.Job.Delete:input_type -> job.v1.JobId
+	15, // 79: job.v1.Job.Watch:input_type -> job.v1.WatchRequest
+	36, // 80: job.v1.Job.Attach:input_type -> job.v1.AttachRequest
+	29, // 81: job.v1.Job.Signal:input_type -> job.v1.SignalRequest
+	30, // 82: job.v1.Job.UpdateLimits:input_type -> job.v1.UpdateLimitsRequest
+	19, // 83: job.v1.Job.CreateSchedule:input_type -> job.v1.ScheduleSpec
+	21, // 84: job.v1.Job.ListSchedules:input_type -> job.v1.ListSchedulesRequest
+	20, // 85: job.v1.Job.DeleteSchedule:input_type -> job.v1.ScheduleId
+	24, // 86: job.v1.Job.StartWorkflow:input_type -> job.v1.WorkflowSpec
+	26, // 87: job.v1.Job.GetWorkflowStatus:input_type -> job.v1.WorkflowId
+	11, // 88: job.v1.Job.Start:output_type -> job.v1.JobId
+	10, // 89: job.v1.Job.Stop:output_type -> job.v1.StopResponse
+	17, // 90: job.v1.Job.Status:output_type -> job.v1.JobStatus
+	45, // 91: job.v1.Job.Usage:output_type -> job.v1.ResourceUsage
+	13, // 92: job.v1.Job.List:output_type -> job.v1.JobList
+	39, // 93: job.v1.Job.Output:output_type -> job.v1.ProcessOutput
+	53, // 94: job.v1.Job.Delete:output_type -> google.protobuf.Empty
+	16, // 95: job.v1.Job.Watch:output_type -> job.v1.WatchEvent
+	39, // 96: job.v1.Job.Attach:output_type -> job.v1.ProcessOutput
+	53, // 97: job.v1.Job.Signal:output_type -> google.protobuf.Empty
+	40, // 98: job.v1.Job.UpdateLimits:output_type -> job.v1.ResourceLimits
+	20, // 99: job.v1.Job.CreateSchedule:output_type -> job.v1.ScheduleId
+	22, // 100: job.v1.Job.ListSchedules:output_type -> job.v1.ScheduleList
+	53, // 101: job.v1.Job.DeleteSchedule:output_type -> google.protobuf.Empty
+	26, // 102: job.v1.Job.StartWorkflow:output_type -> job.v1.WorkflowId
+	27, // 103: job.v1.Job.GetWorkflowStatus:output_type -> job.v1.WorkflowStatus
+	88, // [88:104] is the sub-list for method output_type
+	72, // [72:88] is the sub-list for method input_type
+	72, // [72:72] is the sub-list for extension type_name
+	72, // [72:72] is the sub-list for extension extendee
+	0,  // [0:72] is the sub-list for field type_name
 }
 
 func init() { file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_init() }
@@ -997,8 +4662,116 @@ func file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_init() {
 				return nil
 			}
 		}
-		file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*JobId); i {
+		file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RestartPolicy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StopPolicy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StopRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StopResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JobId); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JobList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JobInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchEvent); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1009,8 +4782,8 @@ func file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_init() {
 				return nil
 			}
 		}
-		file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*JobIdList); i {
+		file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JobStatus); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1021,8 +4794,8 @@ func file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_init() {
 				return nil
 			}
 		}
-		file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*JobStatus); i {
+		file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AttemptStatus); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1033,7 +4806,163 @@ func file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_init() {
 				return nil
 			}
 		}
-		file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+		file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ScheduleSpec); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ScheduleId); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListSchedulesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ScheduleList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ScheduleInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WorkflowSpec); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WorkflowJob); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WorkflowId); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WorkflowStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WorkflowJobStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignalRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateLimitsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignalEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*TerminationStatus); i {
 			case 0:
 				return &v.state
@@ -1045,7 +4974,7 @@ func file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_init() {
 				return nil
 			}
 		}
-		file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+		file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CommandSpec); i {
 			case 0:
 				return &v.state
@@ -1057,7 +4986,67 @@ func file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_init() {
 				return nil
 			}
 		}
-		file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+		file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Credential); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TerminalSize); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AttachRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OutputSpec); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OutputRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ProcessOutput); i {
 			case 0:
 				return &v.state
@@ -1069,7 +5058,7 @@ func file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_init() {
 				return nil
 			}
 		}
-		file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+		file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ResourceLimits); i {
 			case 0:
 				return &v.state
@@ -1081,7 +5070,19 @@ func file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_init() {
 				return nil
 			}
 		}
-		file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+		file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CpusetLimits); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*MemoryLimits); i {
 			case 0:
 				return &v.state
@@ -1093,7 +5094,7 @@ func file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_init() {
 				return nil
 			}
 		}
-		file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+		file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*IODeviceLimits); i {
 			case 0:
 				return &v.state
@@ -1105,7 +5106,7 @@ func file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_init() {
 				return nil
 			}
 		}
-		file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+		file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*IOLimits); i {
 			case 0:
 				return &v.state
@@ -1117,17 +5118,94 @@ func file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_init() {
 				return nil
 			}
 		}
+		file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResourceUsage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CpuUsage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MemoryUsage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeviceIOUsage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PidsUsage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[0].OneofWrappers = []interface{}{
+		(*JobSpec_Timeout)(nil),
+		(*JobSpec_Deadline)(nil),
+	}
+	file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[1].OneofWrappers = []interface{}{}
+	file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[6].OneofWrappers = []interface{}{}
+	file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[15].OneofWrappers = []interface{}{}
+	file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[27].OneofWrappers = []interface{}{}
+	file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[30].OneofWrappers = []interface{}{
+		(*AttachRequest_Id)(nil),
+		(*AttachRequest_Input)(nil),
+		(*AttachRequest_CloseStdin)(nil),
+		(*AttachRequest_Resize)(nil),
 	}
-	file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[7].OneofWrappers = []interface{}{}
-	file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[8].OneofWrappers = []interface{}{}
-	file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[10].OneofWrappers = []interface{}{}
+	file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[31].OneofWrappers = []interface{}{}
+	file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[32].OneofWrappers = []interface{}{}
+	file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[34].OneofWrappers = []interface{}{}
+	file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[36].OneofWrappers = []interface{}{}
+	file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[38].OneofWrappers = []interface{}{}
+	file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_msgTypes[41].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_github_com_kralicky_jobserver_pkg_apis_job_v1_job_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   11,
+			NumEnums:      6,
+			NumMessages:   45,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
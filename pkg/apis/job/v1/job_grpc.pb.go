@@ -20,11 +20,22 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	Job_Start_FullMethodName  = "/job.v1.Job/Start"
-	Job_Stop_FullMethodName   = "/job.v1.Job/Stop"
-	Job_Status_FullMethodName = "/job.v1.Job/Status"
-	Job_List_FullMethodName   = "/job.v1.Job/List"
-	Job_Output_FullMethodName = "/job.v1.Job/Output"
+	Job_Start_FullMethodName             = "/job.v1.Job/Start"
+	Job_Stop_FullMethodName              = "/job.v1.Job/Stop"
+	Job_Status_FullMethodName            = "/job.v1.Job/Status"
+	Job_Usage_FullMethodName             = "/job.v1.Job/Usage"
+	Job_List_FullMethodName              = "/job.v1.Job/List"
+	Job_Output_FullMethodName            = "/job.v1.Job/Output"
+	Job_Delete_FullMethodName            = "/job.v1.Job/Delete"
+	Job_Watch_FullMethodName             = "/job.v1.Job/Watch"
+	Job_Attach_FullMethodName            = "/job.v1.Job/Attach"
+	Job_Signal_FullMethodName            = "/job.v1.Job/Signal"
+	Job_UpdateLimits_FullMethodName      = "/job.v1.Job/UpdateLimits"
+	Job_CreateSchedule_FullMethodName    = "/job.v1.Job/CreateSchedule"
+	Job_ListSchedules_FullMethodName     = "/job.v1.Job/ListSchedules"
+	Job_DeleteSchedule_FullMethodName    = "/job.v1.Job/DeleteSchedule"
+	Job_StartWorkflow_FullMethodName     = "/job.v1.Job/StartWorkflow"
+	Job_GetWorkflowStatus_FullMethodName = "/job.v1.Job/GetWorkflowStatus"
 )
 
 // JobClient is the client API for Job service.
@@ -43,13 +54,27 @@ type JobClient interface {
 	// Stops a running job. Once stopped, this method will wait until the job has
 	// completed before returning.
 	//
-	// This will first attempt to stop the job's process using SIGTERM, but if the
-	// process does not exit within a short grace period, it will be forcefully
-	// killed with SIGKILL.
+	// This will first attempt to stop the job's process by sending it a stop
+	// signal (SIGTERM by default), but if the process does not exit within a
+	// grace period (10 seconds by default), every process in the job will be
+	// forcefully killed with SIGKILL. The signal and grace period can be set in
+	// the job's spec, and overridden by the request. If the request sets the
+	// 'force' option, the job is killed immediately.
 	//
-	// A job must be in the Running state to be stopped. If the job is in any
-	// other state, this returns a FailedPrecondition error.
-	Stop(ctx context.Context, in *JobId, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Stop can be called again while a job is stopping, for example to force
+	// a job that is taking too long to exit.
+	//
+	// If the job is in the Pending state, it is canceled without ever being
+	// started, and its termination status will have 'stopped' set. If the job
+	// is in the Restarting state, it is not restarted. If the job has already
+	// completed (i.e. it is in the Failed or Terminated state), this returns a
+	// FailedPrecondition error.
+	//
+	// If the request has a label selector instead of an id, every job visible
+	// to the caller that matches the selector and has not completed is stopped,
+	// and this waits until all of them have completed. Jobs that have already
+	// completed are ignored.
+	Stop(ctx context.Context, in *StopRequest, opts ...grpc.CallOption) (*StopResponse, error)
 	// Returns the status of an existing job.
 	//
 	// If the job is completed, detailed termination status will be present in
@@ -61,23 +86,156 @@ type JobClient interface {
 	// the current state (if running), or an explanation for why the job was
 	// terminated (if terminated).
 	Status(ctx context.Context, in *JobId, opts ...grpc.CallOption) (*JobStatus, error)
-	// Returns a list of all job IDs that are currently known to the server.
+	// Returns the resources used by a job, as measured by its cgroup. While the
+	// job is running, the current usage is returned. Once the job's process
+	// has exited, the usage measured just before its cgroup was removed is
+	// returned instead. If the job was restarted, the usage of its latest
+	// attempt is returned.
+	//
+	// If the job is pending, or its usage is not available (for example, if
+	// it was restored after the server restarted, or failed to start), this
+	// returns a FailedPrecondition error.
+	Usage(ctx context.Context, in *JobId, opts ...grpc.CallOption) (*ResourceUsage, error)
+	// Returns a list of jobs that are currently known to the server, along with
+	// their owner and current status.
 	//
-	// All jobs, regardless of state, are included. No guarantees are made about
-	// the order of ids in the list.
-	List(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*JobIdList, error)
-	// Streams the combined stdout and stderr output of a running or completed job.
+	// By default, all jobs visible to the caller are included, regardless of
+	// state. The request can optionally specify filters to narrow down the
+	// results; a job is only included if it matches all of the given filters.
 	//
-	// The output always starts from the beginning of process execution, and will
-	// be written to the stream in real-time until the job completes, or until the
-	// stream is cancelled by the client.
+	// Results are paginated and sorted by job id. If there are more results
+	// than fit in a single page, the response will contain a page token that
+	// can be used to request the next page.
+	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*JobList, error)
+	// Streams the stdout and/or stderr output of a running or completed job.
+	//
+	// Each message contains output from a single stream, tagged with the
+	// stream it was written to and the time at which the server received it.
+	// By default, output from both streams is sent, in the order in which it
+	// was written; the request can select a single stream instead.
+	//
+	// By default, the output starts from the beginning of process execution.
+	// The request can instead start at a given offset (for example, to resume
+	// streaming after a disconnect), with the last few lines of output, or at a
+	// given time. Output will be written to the stream in real-time until the
+	// job completes, or until the stream is cancelled by the client.
 	//
 	// Jobs that were stopped by the user with the Stop() method will have output
 	// up to the time they were stopped.
 	//
-	// If the job is already completed, the full output of the job will be
+	// If the job's process has been restarted, each attempt has its own output.
+	// The output of the latest attempt is streamed by default; the request can
+	// select a previous attempt instead.
+	//
+	// If the job is already completed, the requested output of the job will be
 	// written to the stream, after which the stream will be closed.
-	Output(ctx context.Context, in *JobId, opts ...grpc.CallOption) (Job_OutputClient, error)
+	Output(ctx context.Context, in *OutputRequest, opts ...grpc.CallOption) (Job_OutputClient, error)
+	// Deletes a completed job, along with its stored status and output.
+	//
+	// Only jobs that are no longer running (i.e. in the Failed or Terminated
+	// state) can be deleted. If the job is in any other state, this returns a
+	// FailedPrecondition error.
+	//
+	// The server may also delete completed jobs automatically, according to
+	// its configured retention policy.
+	Delete(ctx context.Context, in *JobId, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Streams the status of one or more jobs each time their state changes.
+	//
+	// If an id is given in the request, the current status of that job is sent
+	// immediately, followed by its status each time its state changes. The
+	// stream is closed once the job is no longer running.
+	//
+	// Otherwise, the current status of every job visible to the caller is sent,
+	// followed by the status of any such job (including jobs started after the
+	// stream was opened) each time its state changes. The stream remains open
+	// until it is cancelled by the client.
+	//
+	// The same status may occasionally be sent more than once. Clients that do
+	// not keep up with the rate of events will have their stream closed with a
+	// ResourceExhausted error.
+	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (Job_WatchClient, error)
+	// Attaches to a running or completed job, streaming its output (in the same
+	// way as the Output() method) while forwarding input from the client to the
+	// job's stdin.
+	//
+	// The first message sent by the client must contain the id of the job to
+	// attach to. All subsequent messages may contain input to be written to the
+	// job's stdin, or a request to close the job's stdin. Input can only be
+	// sent to jobs started with the 'stdin' option enabled in their command
+	// spec; otherwise, this returns a FailedPrecondition error. Similarly, the
+	// job's terminal can only be resized if it was started with the 'tty'
+	// option enabled.
+	//
+	// The job's stdin is only closed when a client explicitly requests it.
+	// Closing the request stream, or cancelling the call, does not close the
+	// job's stdin, so that other clients can continue to attach to the job.
+	//
+	// The response stream is closed once all of the job's output has been sent.
+	Attach(ctx context.Context, opts ...grpc.CallOption) (Job_AttachClient, error)
+	// Sends a signal to a running job. By default, the signal is only sent to
+	// the job's main process; the request can instead send it to every process
+	// in the job.
+	//
+	// Unlike Stop(), this does not wait for the job to handle the signal, and
+	// does not mark the job as stopped if the signal terminates it. Each signal
+	// sent with this method is recorded in the job's status.
+	//
+	// If the job is not running, this returns a FailedPrecondition error.
+	Signal(ctx context.Context, in *SignalRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Changes the resource limits of a job without restarting it. Limits set in
+	// the request replace the job's current limits; limits that are not set
+	// are left unchanged. For IO limits, each device's limits are updated
+	// individually in the same way. Limits can be changed, but not removed.
+	//
+	// The new limits are applied to the job's cgroup immediately, and are used
+	// for any later attempts of the job. Lowering the memory limit below the
+	// job's current usage may cause it to be OOM-killed. The effective limits
+	// are recorded in the job's status, and returned by this method.
+	//
+	// If the job has already completed, this returns a FailedPrecondition
+	// error.
+	UpdateLimits(ctx context.Context, in *UpdateLimitsRequest, opts ...grpc.CallOption) (*ResourceLimits, error)
+	// Creates a schedule, which starts a new job from a template each time its
+	// cron expression fires, and returns its id.
+	//
+	// Jobs started by a schedule are owned by the user that created the
+	// schedule, and have the schedule's id set in their status. Whether a job
+	// is started while jobs previously started by the same schedule are still
+	// running is controlled by the schedule's concurrency policy.
+	//
+	// Schedules are evaluated in the server's local time zone. If the server is
+	// not running at a time the schedule should have fired, no job is started
+	// for that time.
+	CreateSchedule(ctx context.Context, in *ScheduleSpec, opts ...grpc.CallOption) (*ScheduleId, error)
+	// Returns a list of schedules that are currently known to the server, along
+	// with their owner and the jobs they started that are still running.
+	ListSchedules(ctx context.Context, in *ListSchedulesRequest, opts ...grpc.CallOption) (*ScheduleList, error)
+	// Deletes a schedule. No further jobs are started by the schedule. Jobs that
+	// were already started by the schedule are not affected.
+	DeleteSchedule(ctx context.Context, in *ScheduleId, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Starts a workflow, which runs a set of named jobs according to their
+	// dependencies on each other, and returns its id.
+	//
+	// Each job is started once all of the jobs it depends on have completed
+	// successfully (i.e. terminated with exit code 0, without being stopped or
+	// killed). If any of its dependencies does not complete successfully, the
+	// job is skipped, along with any jobs that depend on it. Jobs without
+	// dependencies are started immediately. All jobs in the workflow are owned
+	// by the user that started it, who must also be allowed to call Start.
+	//
+	// The workflow is rejected if any of its jobs depend on a job that is not
+	// part of the workflow, or if the dependencies contain a cycle.
+	//
+	// Workflows are only kept in memory, and are lost when the server exits.
+	// Jobs started by a workflow are persisted in the same way as other jobs,
+	// but after a restart they are no longer associated with the workflow:
+	// GetWorkflowStatus returns NotFound, jobs that had not been started yet
+	// are never started, and the restored jobs are deleted by the retention
+	// policy like any other job.
+	StartWorkflow(ctx context.Context, in *WorkflowSpec, opts ...grpc.CallOption) (*WorkflowId, error)
+	// Returns the status of a workflow, along with the state of each of its
+	// jobs.
+	GetWorkflowStatus(ctx context.Context, in *WorkflowId, opts ...grpc.CallOption) (*WorkflowStatus, error)
 }
 
 type jobClient struct {
@@ -97,8 +255,8 @@ func (c *jobClient) Start(ctx context.Context, in *JobSpec, opts ...grpc.CallOpt
 	return out, nil
 }
 
-func (c *jobClient) Stop(ctx context.Context, in *JobId, opts ...grpc.CallOption) (*emptypb.Empty, error) {
-	out := new(emptypb.Empty)
+func (c *jobClient) Stop(ctx context.Context, in *StopRequest, opts ...grpc.CallOption) (*StopResponse, error) {
+	out := new(StopResponse)
 	err := c.cc.Invoke(ctx, Job_Stop_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
@@ -115,8 +273,17 @@ func (c *jobClient) Status(ctx context.Context, in *JobId, opts ...grpc.CallOpti
 	return out, nil
 }
 
-func (c *jobClient) List(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*JobIdList, error) {
-	out := new(JobIdList)
+func (c *jobClient) Usage(ctx context.Context, in *JobId, opts ...grpc.CallOption) (*ResourceUsage, error) {
+	out := new(ResourceUsage)
+	err := c.cc.Invoke(ctx, Job_Usage_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobClient) List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*JobList, error) {
+	out := new(JobList)
 	err := c.cc.Invoke(ctx, Job_List_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
@@ -124,7 +291,7 @@ func (c *jobClient) List(ctx context.Context, in *emptypb.Empty, opts ...grpc.Ca
 	return out, nil
 }
 
-func (c *jobClient) Output(ctx context.Context, in *JobId, opts ...grpc.CallOption) (Job_OutputClient, error) {
+func (c *jobClient) Output(ctx context.Context, in *OutputRequest, opts ...grpc.CallOption) (Job_OutputClient, error) {
 	stream, err := c.cc.NewStream(ctx, &Job_ServiceDesc.Streams[0], Job_Output_FullMethodName, opts...)
 	if err != nil {
 		return nil, err
@@ -156,6 +323,141 @@ func (x *jobOutputClient) Recv() (*ProcessOutput, error) {
 	return m, nil
 }
 
+func (c *jobClient) Delete(ctx context.Context, in *JobId, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Job_Delete_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (Job_WatchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Job_ServiceDesc.Streams[1], Job_Watch_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &jobWatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Job_WatchClient interface {
+	Recv() (*WatchEvent, error)
+	grpc.ClientStream
+}
+
+type jobWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *jobWatchClient) Recv() (*WatchEvent, error) {
+	m := new(WatchEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *jobClient) Attach(ctx context.Context, opts ...grpc.CallOption) (Job_AttachClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Job_ServiceDesc.Streams[2], Job_Attach_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &jobAttachClient{stream}
+	return x, nil
+}
+
+type Job_AttachClient interface {
+	Send(*AttachRequest) error
+	Recv() (*ProcessOutput, error)
+	grpc.ClientStream
+}
+
+type jobAttachClient struct {
+	grpc.ClientStream
+}
+
+func (x *jobAttachClient) Send(m *AttachRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *jobAttachClient) Recv() (*ProcessOutput, error) {
+	m := new(ProcessOutput)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *jobClient) Signal(ctx context.Context, in *SignalRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Job_Signal_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobClient) UpdateLimits(ctx context.Context, in *UpdateLimitsRequest, opts ...grpc.CallOption) (*ResourceLimits, error) {
+	out := new(ResourceLimits)
+	err := c.cc.Invoke(ctx, Job_UpdateLimits_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobClient) CreateSchedule(ctx context.Context, in *ScheduleSpec, opts ...grpc.CallOption) (*ScheduleId, error) {
+	out := new(ScheduleId)
+	err := c.cc.Invoke(ctx, Job_CreateSchedule_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobClient) ListSchedules(ctx context.Context, in *ListSchedulesRequest, opts ...grpc.CallOption) (*ScheduleList, error) {
+	out := new(ScheduleList)
+	err := c.cc.Invoke(ctx, Job_ListSchedules_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobClient) DeleteSchedule(ctx context.Context, in *ScheduleId, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Job_DeleteSchedule_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobClient) StartWorkflow(ctx context.Context, in *WorkflowSpec, opts ...grpc.CallOption) (*WorkflowId, error) {
+	out := new(WorkflowId)
+	err := c.cc.Invoke(ctx, Job_StartWorkflow_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobClient) GetWorkflowStatus(ctx context.Context, in *WorkflowId, opts ...grpc.CallOption) (*WorkflowStatus, error) {
+	out := new(WorkflowStatus)
+	err := c.cc.Invoke(ctx, Job_GetWorkflowStatus_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // JobServer is the server API for Job service.
 // All implementations must embed UnimplementedJobServer
 // for forward compatibility
@@ -172,13 +474,27 @@ type JobServer interface {
 	// Stops a running job. Once stopped, this method will wait until the job has
 	// completed before returning.
 	//
-	// This will first attempt to stop the job's process using SIGTERM, but if the
-	// process does not exit within a short grace period, it will be forcefully
-	// killed with SIGKILL.
+	// This will first attempt to stop the job's process by sending it a stop
+	// signal (SIGTERM by default), but if the process does not exit within a
+	// grace period (10 seconds by default), every process in the job will be
+	// forcefully killed with SIGKILL. The signal and grace period can be set in
+	// the job's spec, and overridden by the request. If the request sets the
+	// 'force' option, the job is killed immediately.
+	//
+	// Stop can be called again while a job is stopping, for example to force
+	// a job that is taking too long to exit.
+	//
+	// If the job is in the Pending state, it is canceled without ever being
+	// started, and its termination status will have 'stopped' set. If the job
+	// is in the Restarting state, it is not restarted. If the job has already
+	// completed (i.e. it is in the Failed or Terminated state), this returns a
+	// FailedPrecondition error.
 	//
-	// A job must be in the Running state to be stopped. If the job is in any
-	// other state, this returns a FailedPrecondition error.
-	Stop(context.Context, *JobId) (*emptypb.Empty, error)
+	// If the request has a label selector instead of an id, every job visible
+	// to the caller that matches the selector and has not completed is stopped,
+	// and this waits until all of them have completed. Jobs that have already
+	// completed are ignored.
+	Stop(context.Context, *StopRequest) (*StopResponse, error)
 	// Returns the status of an existing job.
 	//
 	// If the job is completed, detailed termination status will be present in
@@ -190,23 +506,156 @@ type JobServer interface {
 	// the current state (if running), or an explanation for why the job was
 	// terminated (if terminated).
 	Status(context.Context, *JobId) (*JobStatus, error)
-	// Returns a list of all job IDs that are currently known to the server.
+	// Returns the resources used by a job, as measured by its cgroup. While the
+	// job is running, the current usage is returned. Once the job's process
+	// has exited, the usage measured just before its cgroup was removed is
+	// returned instead. If the job was restarted, the usage of its latest
+	// attempt is returned.
+	//
+	// If the job is pending, or its usage is not available (for example, if
+	// it was restored after the server restarted, or failed to start), this
+	// returns a FailedPrecondition error.
+	Usage(context.Context, *JobId) (*ResourceUsage, error)
+	// Returns a list of jobs that are currently known to the server, along with
+	// their owner and current status.
+	//
+	// By default, all jobs visible to the caller are included, regardless of
+	// state. The request can optionally specify filters to narrow down the
+	// results; a job is only included if it matches all of the given filters.
+	//
+	// Results are paginated and sorted by job id. If there are more results
+	// than fit in a single page, the response will contain a page token that
+	// can be used to request the next page.
+	List(context.Context, *ListRequest) (*JobList, error)
+	// Streams the stdout and/or stderr output of a running or completed job.
 	//
-	// All jobs, regardless of state, are included. No guarantees are made about
-	// the order of ids in the list.
-	List(context.Context, *emptypb.Empty) (*JobIdList, error)
-	// Streams the combined stdout and stderr output of a running or completed job.
+	// Each message contains output from a single stream, tagged with the
+	// stream it was written to and the time at which the server received it.
+	// By default, output from both streams is sent, in the order in which it
+	// was written; the request can select a single stream instead.
 	//
-	// The output always starts from the beginning of process execution, and will
-	// be written to the stream in real-time until the job completes, or until the
-	// stream is cancelled by the client.
+	// By default, the output starts from the beginning of process execution.
+	// The request can instead start at a given offset (for example, to resume
+	// streaming after a disconnect), with the last few lines of output, or at a
+	// given time. Output will be written to the stream in real-time until the
+	// job completes, or until the stream is cancelled by the client.
 	//
 	// Jobs that were stopped by the user with the Stop() method will have output
 	// up to the time they were stopped.
 	//
-	// If the job is already completed, the full output of the job will be
+	// If the job's process has been restarted, each attempt has its own output.
+	// The output of the latest attempt is streamed by default; the request can
+	// select a previous attempt instead.
+	//
+	// If the job is already completed, the requested output of the job will be
 	// written to the stream, after which the stream will be closed.
-	Output(*JobId, Job_OutputServer) error
+	Output(*OutputRequest, Job_OutputServer) error
+	// Deletes a completed job, along with its stored status and output.
+	//
+	// Only jobs that are no longer running (i.e. in the Failed or Terminated
+	// state) can be deleted. If the job is in any other state, this returns a
+	// FailedPrecondition error.
+	//
+	// The server may also delete completed jobs automatically, according to
+	// its configured retention policy.
+	Delete(context.Context, *JobId) (*emptypb.Empty, error)
+	// Streams the status of one or more jobs each time their state changes.
+	//
+	// If an id is given in the request, the current status of that job is sent
+	// immediately, followed by its status each time its state changes. The
+	// stream is closed once the job is no longer running.
+	//
+	// Otherwise, the current status of every job visible to the caller is sent,
+	// followed by the status of any such job (including jobs started after the
+	// stream was opened) each time its state changes. The stream remains open
+	// until it is cancelled by the client.
+	//
+	// The same status may occasionally be sent more than once. Clients that do
+	// not keep up with the rate of events will have their stream closed with a
+	// ResourceExhausted error.
+	Watch(*WatchRequest, Job_WatchServer) error
+	// Attaches to a running or completed job, streaming its output (in the same
+	// way as the Output() method) while forwarding input from the client to the
+	// job's stdin.
+	//
+	// The first message sent by the client must contain the id of the job to
+	// attach to. All subsequent messages may contain input to be written to the
+	// job's stdin, or a request to close the job's stdin. Input can only be
+	// sent to jobs started with the 'stdin' option enabled in their command
+	// spec; otherwise, this returns a FailedPrecondition error. Similarly, the
+	// job's terminal can only be resized if it was started with the 'tty'
+	// option enabled.
+	//
+	// The job's stdin is only closed when a client explicitly requests it.
+	// Closing the request stream, or cancelling the call, does not close the
+	// job's stdin, so that other clients can continue to attach to the job.
+	//
+	// The response stream is closed once all of the job's output has been sent.
+	Attach(Job_AttachServer) error
+	// Sends a signal to a running job. By default, the signal is only sent to
+	// the job's main process; the request can instead send it to every process
+	// in the job.
+	//
+	// Unlike Stop(), this does not wait for the job to handle the signal, and
+	// does not mark the job as stopped if the signal terminates it. Each signal
+	// sent with this method is recorded in the job's status.
+	//
+	// If the job is not running, this returns a FailedPrecondition error.
+	Signal(context.Context, *SignalRequest) (*emptypb.Empty, error)
+	// Changes the resource limits of a job without restarting it. Limits set in
+	// the request replace the job's current limits; limits that are not set
+	// are left unchanged. For IO limits, each device's limits are updated
+	// individually in the same way. Limits can be changed, but not removed.
+	//
+	// The new limits are applied to the job's cgroup immediately, and are used
+	// for any later attempts of the job. Lowering the memory limit below the
+	// job's current usage may cause it to be OOM-killed. The effective limits
+	// are recorded in the job's status, and returned by this method.
+	//
+	// If the job has already completed, this returns a FailedPrecondition
+	// error.
+	UpdateLimits(context.Context, *UpdateLimitsRequest) (*ResourceLimits, error)
+	// Creates a schedule, which starts a new job from a template each time its
+	// cron expression fires, and returns its id.
+	//
+	// Jobs started by a schedule are owned by the user that created the
+	// schedule, and have the schedule's id set in their status. Whether a job
+	// is started while jobs previously started by the same schedule are still
+	// running is controlled by the schedule's concurrency policy.
+	//
+	// Schedules are evaluated in the server's local time zone. If the server is
+	// not running at a time the schedule should have fired, no job is started
+	// for that time.
+	CreateSchedule(context.Context, *ScheduleSpec) (*ScheduleId, error)
+	// Returns a list of schedules that are currently known to the server, along
+	// with their owner and the jobs they started that are still running.
+	ListSchedules(context.Context, *ListSchedulesRequest) (*ScheduleList, error)
+	// Deletes a schedule. No further jobs are started by the schedule. Jobs that
+	// were already started by the schedule are not affected.
+	DeleteSchedule(context.Context, *ScheduleId) (*emptypb.Empty, error)
+	// Starts a workflow, which runs a set of named jobs according to their
+	// dependencies on each other, and returns its id.
+	//
+	// Each job is started once all of the jobs it depends on have completed
+	// successfully (i.e. terminated with exit code 0, without being stopped or
+	// killed). If any of its dependencies does not complete successfully, the
+	// job is skipped, along with any jobs that depend on it. Jobs without
+	// dependencies are started immediately. All jobs in the workflow are owned
+	// by the user that started it, who must also be allowed to call Start.
+	//
+	// The workflow is rejected if any of its jobs depend on a job that is not
+	// part of the workflow, or if the dependencies contain a cycle.
+	//
+	// Workflows are only kept in memory, and are lost when the server exits.
+	// Jobs started by a workflow are persisted in the same way as other jobs,
+	// but after a restart they are no longer associated with the workflow:
+	// GetWorkflowStatus returns NotFound, jobs that had not been started yet
+	// are never started, and the restored jobs are deleted by the retention
+	// policy like any other job.
+	StartWorkflow(context.Context, *WorkflowSpec) (*WorkflowId, error)
+	// Returns the status of a workflow, along with the state of each of its
+	// jobs.
+	GetWorkflowStatus(context.Context, *WorkflowId) (*WorkflowStatus, error)
 	mustEmbedUnimplementedJobServer()
 }
 
@@ -217,18 +666,51 @@ type UnimplementedJobServer struct {
 func (UnimplementedJobServer) Start(context.Context, *JobSpec) (*JobId, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Start not implemented")
 }
-func (UnimplementedJobServer) Stop(context.Context, *JobId) (*emptypb.Empty, error) {
+func (UnimplementedJobServer) Stop(context.Context, *StopRequest) (*StopResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Stop not implemented")
 }
 func (UnimplementedJobServer) Status(context.Context, *JobId) (*JobStatus, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Status not implemented")
 }
-func (UnimplementedJobServer) List(context.Context, *emptypb.Empty) (*JobIdList, error) {
+func (UnimplementedJobServer) Usage(context.Context, *JobId) (*ResourceUsage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Usage not implemented")
+}
+func (UnimplementedJobServer) List(context.Context, *ListRequest) (*JobList, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
 }
-func (UnimplementedJobServer) Output(*JobId, Job_OutputServer) error {
+func (UnimplementedJobServer) Output(*OutputRequest, Job_OutputServer) error {
 	return status.Errorf(codes.Unimplemented, "method Output not implemented")
 }
+func (UnimplementedJobServer) Delete(context.Context, *JobId) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedJobServer) Watch(*WatchRequest, Job_WatchServer) error {
+	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+}
+func (UnimplementedJobServer) Attach(Job_AttachServer) error {
+	return status.Errorf(codes.Unimplemented, "method Attach not implemented")
+}
+func (UnimplementedJobServer) Signal(context.Context, *SignalRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Signal not implemented")
+}
+func (UnimplementedJobServer) UpdateLimits(context.Context, *UpdateLimitsRequest) (*ResourceLimits, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateLimits not implemented")
+}
+func (UnimplementedJobServer) CreateSchedule(context.Context, *ScheduleSpec) (*ScheduleId, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSchedule not implemented")
+}
+func (UnimplementedJobServer) ListSchedules(context.Context, *ListSchedulesRequest) (*ScheduleList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSchedules not implemented")
+}
+func (UnimplementedJobServer) DeleteSchedule(context.Context, *ScheduleId) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSchedule not implemented")
+}
+func (UnimplementedJobServer) StartWorkflow(context.Context, *WorkflowSpec) (*WorkflowId, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartWorkflow not implemented")
+}
+func (UnimplementedJobServer) GetWorkflowStatus(context.Context, *WorkflowId) (*WorkflowStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWorkflowStatus not implemented")
+}
 func (UnimplementedJobServer) mustEmbedUnimplementedJobServer() {}
 
 // UnsafeJobServer may be embedded to opt out of forward compatibility for this service.
@@ -261,7 +743,7 @@ func _Job_Start_Handler(srv interface{}, ctx context.Context, dec func(interface
 }
 
 func _Job_Stop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(JobId)
+	in := new(StopRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -273,7 +755,7 @@ func _Job_Stop_Handler(srv interface{}, ctx context.Context, dec func(interface{
 		FullMethod: Job_Stop_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(JobServer).Stop(ctx, req.(*JobId))
+		return srv.(JobServer).Stop(ctx, req.(*StopRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -296,8 +778,26 @@ func _Job_Status_Handler(srv interface{}, ctx context.Context, dec func(interfac
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Job_Usage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JobId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServer).Usage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Job_Usage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServer).Usage(ctx, req.(*JobId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Job_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
+	in := new(ListRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -309,13 +809,13 @@ func _Job_List_Handler(srv interface{}, ctx context.Context, dec func(interface{
 		FullMethod: Job_List_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(JobServer).List(ctx, req.(*emptypb.Empty))
+		return srv.(JobServer).List(ctx, req.(*ListRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
 func _Job_Output_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(JobId)
+	m := new(OutputRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
@@ -335,6 +835,197 @@ func (x *jobOutputServer) Send(m *ProcessOutput) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _Job_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JobId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Job_Delete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServer).Delete(ctx, req.(*JobId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Job_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(JobServer).Watch(m, &jobWatchServer{stream})
+}
+
+type Job_WatchServer interface {
+	Send(*WatchEvent) error
+	grpc.ServerStream
+}
+
+type jobWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *jobWatchServer) Send(m *WatchEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Job_Attach_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(JobServer).Attach(&jobAttachServer{stream})
+}
+
+type Job_AttachServer interface {
+	Send(*ProcessOutput) error
+	Recv() (*AttachRequest, error)
+	grpc.ServerStream
+}
+
+type jobAttachServer struct {
+	grpc.ServerStream
+}
+
+func (x *jobAttachServer) Send(m *ProcessOutput) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *jobAttachServer) Recv() (*AttachRequest, error) {
+	m := new(AttachRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Job_Signal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServer).Signal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Job_Signal_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServer).Signal(ctx, req.(*SignalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Job_UpdateLimits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateLimitsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServer).UpdateLimits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Job_UpdateLimits_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServer).UpdateLimits(ctx, req.(*UpdateLimitsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Job_CreateSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScheduleSpec)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServer).CreateSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Job_CreateSchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServer).CreateSchedule(ctx, req.(*ScheduleSpec))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Job_ListSchedules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSchedulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServer).ListSchedules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Job_ListSchedules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServer).ListSchedules(ctx, req.(*ListSchedulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Job_DeleteSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScheduleId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServer).DeleteSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Job_DeleteSchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServer).DeleteSchedule(ctx, req.(*ScheduleId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Job_StartWorkflow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WorkflowSpec)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServer).StartWorkflow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Job_StartWorkflow_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServer).StartWorkflow(ctx, req.(*WorkflowSpec))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Job_GetWorkflowStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WorkflowId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServer).GetWorkflowStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Job_GetWorkflowStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServer).GetWorkflowStatus(ctx, req.(*WorkflowId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Job_ServiceDesc is the grpc.ServiceDesc for Job service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -354,10 +1045,46 @@ var Job_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Status",
 			Handler:    _Job_Status_Handler,
 		},
+		{
+			MethodName: "Usage",
+			Handler:    _Job_Usage_Handler,
+		},
 		{
 			MethodName: "List",
 			Handler:    _Job_List_Handler,
 		},
+		{
+			MethodName: "Delete",
+			Handler:    _Job_Delete_Handler,
+		},
+		{
+			MethodName: "Signal",
+			Handler:    _Job_Signal_Handler,
+		},
+		{
+			MethodName: "UpdateLimits",
+			Handler:    _Job_UpdateLimits_Handler,
+		},
+		{
+			MethodName: "CreateSchedule",
+			Handler:    _Job_CreateSchedule_Handler,
+		},
+		{
+			MethodName: "ListSchedules",
+			Handler:    _Job_ListSchedules_Handler,
+		},
+		{
+			MethodName: "DeleteSchedule",
+			Handler:    _Job_DeleteSchedule_Handler,
+		},
+		{
+			MethodName: "StartWorkflow",
+			Handler:    _Job_StartWorkflow_Handler,
+		},
+		{
+			MethodName: "GetWorkflowStatus",
+			Handler:    _Job_GetWorkflowStatus_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -365,6 +1092,17 @@ var Job_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _Job_Output_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "Watch",
+			Handler:       _Job_Watch_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Attach",
+			Handler:       _Job_Attach_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "github.com/kralicky/jobserver/pkg/apis/job/v1/job.proto",
 }
@@ -0,0 +1,13 @@
+package jobv1_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestJobV1(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Job API Suite")
+}
@@ -0,0 +1,350 @@
+package jobv1
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/kralicky/jobserver/pkg/cron"
+	"github.com/kralicky/jobserver/pkg/labels"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func (s *JobSpec) Validate() error {
+	if err := s.GetCommand().Validate(); err != nil {
+		return fmt.Errorf("invalid command spec: %w", err)
+	}
+	if err := s.GetLimits().Validate(); err != nil {
+		return fmt.Errorf("invalid resource limits: %w", err)
+	}
+	if err := s.GetOutput().Validate(); err != nil {
+		return fmt.Errorf("invalid output spec: %w", err)
+	}
+	if err := s.GetStop().Validate(); err != nil {
+		return fmt.Errorf("invalid stop policy: %w", err)
+	}
+	if err := s.GetRestart().Validate(); err != nil {
+		return fmt.Errorf("invalid restart policy: %w", err)
+	}
+	if err := labels.Validate(s.GetLabels()); err != nil {
+		return fmt.Errorf("invalid labels: %w", err)
+	}
+	if s.GetName() != "" {
+		if !nameRegex.MatchString(s.GetName()) {
+			return fmt.Errorf("invalid job name %q", s.GetName())
+		}
+		if jobIdRegex.MatchString(s.GetName()) {
+			return fmt.Errorf("job name %q must not have the same format as a job id", s.GetName())
+		}
+	}
+	if len(s.GetIdempotencyKey()) > MaxIdempotencyKeyLength {
+		return fmt.Errorf("idempotency key must not be longer than %d bytes", MaxIdempotencyKeyLength)
+	}
+	switch limit := s.GetMaxRuntime().(type) {
+	case *JobSpec_Timeout:
+		if err := limit.Timeout.CheckValid(); err != nil {
+			return fmt.Errorf("invalid timeout: %w", err)
+		}
+		if limit.Timeout.AsDuration() <= 0 {
+			return fmt.Errorf("timeout must be greater than 0")
+		}
+	case *JobSpec_Deadline:
+		if err := limit.Deadline.CheckValid(); err != nil {
+			return fmt.Errorf("invalid deadline: %w", err)
+		}
+		if !limit.Deadline.AsTime().After(time.Now()) {
+			return fmt.Errorf("deadline %s is in the past", limit.Deadline.AsTime().Format(time.RFC3339))
+		}
+	}
+	return nil
+}
+
+// The maximum length of a job spec's idempotency key, in bytes.
+const MaxIdempotencyKeyLength = 256
+
+var (
+	nameRegex  = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9_.-]{0,61}[A-Za-z0-9])?$`)
+	jobIdRegex = regexp.MustCompile(`^[0-9a-f]{32}$`)
+	// a device's major and minor numbers, e.g. 8:16
+	deviceIdRegex = regexp.MustCompile(`^[0-9]+:[0-9]+$`)
+	// a list of cpus or memory nodes, e.g. 0-3,6
+	cpuListRegex = regexp.MustCompile(`^[0-9]+(-[0-9]+)?(,[0-9]+(-[0-9]+)?)*$`)
+)
+
+// Bounds of the cpu weight and cpu period limits, as accepted by the kernel.
+const (
+	MinCpuWeight = 1
+	MaxCpuWeight = 10000
+	MinCpuPeriod = time.Millisecond
+	MaxCpuPeriod = time.Second
+)
+
+// The largest number of supplementary groups a process can have (NGROUPS_MAX
+// on linux).
+const MaxGroups = 65536
+
+func (c *CommandSpec) Validate() error {
+	if c.GetWorkdir() != "" && !filepath.IsAbs(c.GetWorkdir()) {
+		return fmt.Errorf("workdir must be an absolute path")
+	}
+	if c.GetUmask() > 0o777 {
+		return fmt.Errorf("invalid umask %#o", c.GetUmask())
+	}
+	if len(c.GetCredential().GetGroups()) > MaxGroups {
+		return fmt.Errorf("too many supplementary groups (max %d)", MaxGroups)
+	}
+	return nil
+}
+
+func (l *ResourceLimits) Validate() error {
+	if l == nil {
+		return nil
+	}
+	if l.Cpu != nil && l.GetCpu() <= 0 {
+		return fmt.Errorf("cpu limit must be greater than 0")
+	}
+	if l.CpuWeight != nil && (l.GetCpuWeight() < MinCpuWeight || l.GetCpuWeight() > MaxCpuWeight) {
+		return fmt.Errorf("cpu weight must be between %d and %d", MinCpuWeight, MaxCpuWeight)
+	}
+	if p := l.GetCpuPeriod(); p != nil {
+		if err := p.CheckValid(); err != nil {
+			return fmt.Errorf("invalid cpu period: %w", err)
+		}
+		if p.AsDuration() < MinCpuPeriod || p.AsDuration() > MaxCpuPeriod {
+			return fmt.Errorf("cpu period must be between %s and %s", MinCpuPeriod, MaxCpuPeriod)
+		}
+	}
+	if cpuset := l.GetCpuset(); cpuset != nil {
+		if cpuset.GetCpus() == "" && cpuset.GetMems() == "" {
+			return fmt.Errorf("cpuset must set cpus or mems")
+		}
+		if cpuset.GetCpus() != "" && !cpuListRegex.MatchString(cpuset.GetCpus()) {
+			return fmt.Errorf("invalid cpuset cpus %q", cpuset.GetCpus())
+		}
+		if cpuset.GetMems() != "" && !cpuListRegex.MatchString(cpuset.GetMems()) {
+			return fmt.Errorf("invalid cpuset mems %q", cpuset.GetMems())
+		}
+	}
+	if l.Pids != nil && l.GetPids() <= 0 {
+		return fmt.Errorf("pids limit must be greater than 0")
+	}
+	if mem := l.GetMemory(); mem != nil {
+		if mem.SoftLimit != nil && mem.GetSoftLimit() <= 0 {
+			return fmt.Errorf("memory soft limit must be greater than 0")
+		}
+		if mem.Limit != nil && mem.GetLimit() <= 0 {
+			return fmt.Errorf("memory limit must be greater than 0")
+		}
+		if mem.SwapLimit != nil && mem.GetSwapLimit() < 0 {
+			return fmt.Errorf("swap limit must not be negative")
+		}
+		if mem.Low != nil && mem.GetLow() < 0 {
+			return fmt.Errorf("memory low must not be negative")
+		}
+		if mem.Min != nil && mem.GetMin() < 0 {
+			return fmt.Errorf("memory min must not be negative")
+		}
+	}
+	for _, dev := range l.GetIo() {
+		if !filepath.IsAbs(dev.GetDevice()) && !deviceIdRegex.MatchString(dev.GetDevice()) {
+			return fmt.Errorf("io device %q must be an absolute path or a device id (major:minor)", dev.GetDevice())
+		}
+		if io := dev.GetLimits(); io != nil {
+			for _, v := range []*int64{io.ReadBps, io.WriteBps, io.ReadIops, io.WriteIops} {
+				if v != nil && *v <= 0 {
+					return fmt.Errorf("io limits for device %s must be greater than 0", dev.GetDevice())
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (r *UpdateLimitsRequest) Validate() error {
+	if r.GetId().GetId() == "" {
+		return fmt.Errorf("id is required")
+	}
+	l := r.GetLimits()
+	if l == nil || proto.Size(l) == 0 {
+		return fmt.Errorf("at least one limit must be set")
+	}
+	return l.Validate()
+}
+
+func (o *OutputSpec) Validate() error {
+	if o == nil {
+		return nil
+	}
+	if o.RetainBytes != nil && o.GetRetainBytes() <= 0 {
+		return fmt.Errorf("retain_bytes must be greater than 0")
+	}
+	return nil
+}
+
+// The largest valid signal number (SIGRTMAX on linux).
+const MaxSignal = 64
+
+func validateSignal(signal int32) error {
+	if signal < 0 || signal > MaxSignal {
+		return fmt.Errorf("invalid signal %d", signal)
+	}
+	return nil
+}
+
+func validateGracePeriod(d *durationpb.Duration) error {
+	if d == nil {
+		return nil
+	}
+	if err := d.CheckValid(); err != nil {
+		return fmt.Errorf("invalid grace period: %w", err)
+	}
+	if d.AsDuration() < 0 {
+		return fmt.Errorf("grace period must not be negative")
+	}
+	return nil
+}
+
+func (p *StopPolicy) Validate() error {
+	if p == nil {
+		return nil
+	}
+	if err := validateSignal(p.GetSignal()); err != nil {
+		return err
+	}
+	return validateGracePeriod(p.GetGracePeriod())
+}
+
+func (r *StopRequest) Validate() error {
+	switch {
+	case r.GetId() == nil && r.GetLabelSelector() == "":
+		return fmt.Errorf("either id or label_selector must be set")
+	case r.GetId() != nil && r.GetLabelSelector() != "":
+		return fmt.Errorf("id and label_selector cannot both be set")
+	}
+	if r.GetLabelSelector() != "" {
+		if _, err := labels.ParseSelector(r.GetLabelSelector()); err != nil {
+			return err
+		}
+	}
+	if err := validateSignal(r.GetSignal()); err != nil {
+		return err
+	}
+	return validateGracePeriod(r.GetGracePeriod())
+}
+
+func (p *RestartPolicy) Validate() error {
+	if p == nil {
+		return nil
+	}
+	if RestartMode_name[int32(p.GetMode())] == "" {
+		return fmt.Errorf("invalid restart mode %d", p.GetMode())
+	}
+	if err := validateBackoff("initial_backoff", p.GetInitialBackoff()); err != nil {
+		return err
+	}
+	if err := validateBackoff("max_backoff", p.GetMaxBackoff()); err != nil {
+		return err
+	}
+	if p.GetInitialBackoff() != nil && p.GetMaxBackoff() != nil &&
+		p.GetInitialBackoff().AsDuration() > p.GetMaxBackoff().AsDuration() {
+		return fmt.Errorf("initial_backoff must not be greater than max_backoff")
+	}
+	return nil
+}
+
+func validateBackoff(name string, d *durationpb.Duration) error {
+	if d == nil {
+		return nil
+	}
+	if err := d.CheckValid(); err != nil {
+		return fmt.Errorf("invalid %s: %w", name, err)
+	}
+	if d.AsDuration() <= 0 {
+		return fmt.Errorf("%s must be greater than 0", name)
+	}
+	return nil
+}
+
+func (s *ScheduleSpec) Validate() error {
+	if _, err := cron.Parse(s.GetCron()); err != nil {
+		return fmt.Errorf("invalid cron expression: %w", err)
+	}
+	if s.GetJobTemplate() == nil {
+		return fmt.Errorf("job template is required")
+	}
+	if err := s.GetJobTemplate().Validate(); err != nil {
+		return fmt.Errorf("invalid job template: %w", err)
+	}
+	if s.GetJobTemplate().GetDeadline() != nil {
+		return fmt.Errorf("job template must not set a deadline")
+	}
+	if s.GetJobTemplate().GetName() != "" || s.GetJobTemplate().GetIdempotencyKey() != "" {
+		return fmt.Errorf("job template must not set a name or idempotency key")
+	}
+	if ConcurrencyPolicy_name[int32(s.GetConcurrencyPolicy())] == "" {
+		return fmt.Errorf("invalid concurrency policy %d", s.GetConcurrencyPolicy())
+	}
+	return nil
+}
+
+func (w *WorkflowSpec) Validate() error {
+	if len(w.GetJobs()) == 0 {
+		return fmt.Errorf("workflow must contain at least one job")
+	}
+	jobs := make(map[string]*WorkflowJob, len(w.GetJobs()))
+	for _, job := range w.GetJobs() {
+		if !nameRegex.MatchString(job.GetName()) {
+			return fmt.Errorf("invalid job name %q", job.GetName())
+		}
+		if _, ok := jobs[job.GetName()]; ok {
+			return fmt.Errorf("duplicate job name %q", job.GetName())
+		}
+		jobs[job.GetName()] = job
+		if job.GetSpec() == nil {
+			return fmt.Errorf("job %q: spec is required", job.GetName())
+		}
+		if err := job.GetSpec().Validate(); err != nil {
+			return fmt.Errorf("job %q: %w", job.GetName(), err)
+		}
+	}
+	for _, job := range w.GetJobs() {
+		for _, dep := range job.GetDependsOn() {
+			if _, ok := jobs[dep]; !ok {
+				return fmt.Errorf("job %q depends on unknown job %q", job.GetName(), dep)
+			}
+		}
+	}
+
+	// check for cycles with a depth-first search
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int, len(jobs))
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch state[name] {
+		case visiting:
+			return fmt.Errorf("dependency cycle involving job %q", name)
+		case visited:
+			return nil
+		}
+		state[name] = visiting
+		for _, dep := range jobs[name].GetDependsOn() {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		state[name] = visited
+		return nil
+	}
+	for _, job := range w.GetJobs() {
+		if err := visit(job.GetName()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package jobv1_test
+
+import (
+	"time"
+
+	jobv1 "github.com/kralicky/jobserver/pkg/apis/job/v1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var _ = Describe("JobSpec", func() {
+	newSpec := func() *jobv1.JobSpec {
+		return &jobv1.JobSpec{Command: &jobv1.CommandSpec{Command: "true"}}
+	}
+
+	DescribeTable("max runtime",
+		func(maxRuntime any, expectedErr string) {
+			spec := newSpec()
+			switch limit := maxRuntime.(type) {
+			case *durationpb.Duration:
+				spec.MaxRuntime = &jobv1.JobSpec_Timeout{Timeout: limit}
+			case *timestamppb.Timestamp:
+				spec.MaxRuntime = &jobv1.JobSpec_Deadline{Deadline: limit}
+			}
+			err := spec.Validate()
+			if expectedErr == "" {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+			}
+		},
+		Entry("timeout", durationpb.New(time.Minute), ""),
+		Entry("zero timeout", durationpb.New(0), "timeout must be greater than 0"),
+		Entry("negative timeout", durationpb.New(-time.Minute), "timeout must be greater than 0"),
+		Entry("future deadline", timestamppb.New(time.Now().Add(time.Hour)), ""),
+		Entry("past deadline", timestamppb.New(time.Now().Add(-time.Minute)), "is in the past"),
+		Entry("invalid deadline", &timestamppb.Timestamp{Nanos: -1}, "invalid deadline"),
+	)
+})
+
+var _ = Describe("WorkflowSpec", func() {
+	job := func(name string, dependsOn ...string) *jobv1.WorkflowJob {
+		return &jobv1.WorkflowJob{
+			Name:      name,
+			DependsOn: dependsOn,
+			Spec: &jobv1.JobSpec{
+				Command: &jobv1.CommandSpec{Command: "true"},
+			},
+		}
+	}
+
+	DescribeTable("Validate",
+		func(jobs []*jobv1.WorkflowJob, expectedErr string) {
+			err := (&jobv1.WorkflowSpec{Jobs: jobs}).Validate()
+			if expectedErr == "" {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+			}
+		},
+		Entry("single job", []*jobv1.WorkflowJob{job("a")}, ""),
+		Entry("diamond", []*jobv1.WorkflowJob{
+			job("a"), job("b", "a"), job("c", "a"), job("d", "b", "c"),
+		}, ""),
+		Entry("dependency listed before the job it depends on", []*jobv1.WorkflowJob{
+			job("b", "a"), job("a"),
+		}, ""),
+		Entry("no jobs", nil, "at least one job"),
+		Entry("invalid name", []*jobv1.WorkflowJob{job("-a")}, `invalid job name "-a"`),
+		Entry("duplicate name", []*jobv1.WorkflowJob{job("a"), job("a")}, `duplicate job name "a"`),
+		Entry("missing spec", []*jobv1.WorkflowJob{{Name: "a"}}, `job "a": spec is required`),
+		Entry("invalid spec", []*jobv1.WorkflowJob{{
+			Name: "a",
+			Spec: &jobv1.JobSpec{Command: &jobv1.CommandSpec{Command: "true", Workdir: "tmp"}},
+		}}, `job "a": invalid command spec`),
+		Entry("unknown dependency", []*jobv1.WorkflowJob{
+			job("a"), job("b", "c"),
+		}, `job "b" depends on unknown job "c"`),
+		Entry("self dependency", []*jobv1.WorkflowJob{job("a", "a")}, "dependency cycle"),
+		Entry("cycle", []*jobv1.WorkflowJob{
+			job("a"), job("b", "a", "d"), job("c", "b"), job("d", "c"),
+		}, "dependency cycle"),
+	)
+})
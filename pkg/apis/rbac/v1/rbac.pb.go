@@ -144,6 +144,15 @@ type Role struct {
 	// not be qualified with the service name. All methods must exist in the
 	// named service. For example, `rpc Bar` in `service Foo` should be "Bar".
 	AllowedMethods []*AllowedMethod `protobuf:"bytes,3,rep,name=allowed_methods,json=allowedMethods,proto3" json:"allowed_methods,omitempty"`
+	// A list of identities that users bound to this role may run jobs as.
+	// Jobs that do not request an identity run as the same user and group as
+	// the job server. Requests for any identity, including the job server's
+	// own, are denied unless they match at least one identity allowed by one
+	// of the user's roles for the service. If none of the user's roles for the
+	// service allow any identities, jobs that do not request an identity are
+	// allowed (and run as the job server's user), and all other requests are
+	// denied.
+	AllowedIdentities []*Identity `protobuf:"bytes,4,rep,name=allowed_identities,json=allowedIdentities,proto3" json:"allowed_identities,omitempty"`
 }
 
 func (x *Role) Reset() {
@@ -199,6 +208,72 @@ func (x *Role) GetAllowedMethods() []*AllowedMethod {
 	return nil
 }
 
+func (x *Role) GetAllowedIdentities() []*Identity {
+	if x != nil {
+		return x.AllowedIdentities
+	}
+	return nil
+}
+
+// Describes a set of user and group ids that jobs may run as.
+type Identity struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// A list of user ids. At least one is required.
+	Uids []uint32 `protobuf:"varint,1,rep,packed,name=uids,proto3" json:"uids,omitempty"`
+	// A list of group ids that jobs running as one of the above users may use
+	// as their primary or supplementary groups.
+	Gids []uint32 `protobuf:"varint,2,rep,packed,name=gids,proto3" json:"gids,omitempty"`
+}
+
+func (x *Identity) Reset() {
+	*x = Identity{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_kralicky_jobserver_pkg_apis_rbac_v1_rbac_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Identity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Identity) ProtoMessage() {}
+
+func (x *Identity) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_kralicky_jobserver_pkg_apis_rbac_v1_rbac_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Identity.ProtoReflect.Descriptor instead.
+func (*Identity) Descriptor() ([]byte, []int) {
+	return file_github_com_kralicky_jobserver_pkg_apis_rbac_v1_rbac_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Identity) GetUids() []uint32 {
+	if x != nil {
+		return x.Uids
+	}
+	return nil
+}
+
+func (x *Identity) GetGids() []uint32 {
+	if x != nil {
+		return x.Gids
+	}
+	return nil
+}
+
 type AllowedMethod struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -213,7 +288,7 @@ type AllowedMethod struct {
 func (x *AllowedMethod) Reset() {
 	*x = AllowedMethod{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_github_com_kralicky_jobserver_pkg_apis_rbac_v1_rbac_proto_msgTypes[2]
+		mi := &file_github_com_kralicky_jobserver_pkg_apis_rbac_v1_rbac_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -226,7 +301,7 @@ func (x *AllowedMethod) String() string {
 func (*AllowedMethod) ProtoMessage() {}
 
 func (x *AllowedMethod) ProtoReflect() protoreflect.Message {
-	mi := &file_github_com_kralicky_jobserver_pkg_apis_rbac_v1_rbac_proto_msgTypes[2]
+	mi := &file_github_com_kralicky_jobserver_pkg_apis_rbac_v1_rbac_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -239,7 +314,7 @@ func (x *AllowedMethod) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AllowedMethod.ProtoReflect.Descriptor instead.
 func (*AllowedMethod) Descriptor() ([]byte, []int) {
-	return file_github_com_kralicky_jobserver_pkg_apis_rbac_v1_rbac_proto_rawDescGZIP(), []int{2}
+	return file_github_com_kralicky_jobserver_pkg_apis_rbac_v1_rbac_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *AllowedMethod) GetName() string {
@@ -273,7 +348,7 @@ type RoleBinding struct {
 func (x *RoleBinding) Reset() {
 	*x = RoleBinding{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_github_com_kralicky_jobserver_pkg_apis_rbac_v1_rbac_proto_msgTypes[3]
+		mi := &file_github_com_kralicky_jobserver_pkg_apis_rbac_v1_rbac_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -286,7 +361,7 @@ func (x *RoleBinding) String() string {
 func (*RoleBinding) ProtoMessage() {}
 
 func (x *RoleBinding) ProtoReflect() protoreflect.Message {
-	mi := &file_github_com_kralicky_jobserver_pkg_apis_rbac_v1_rbac_proto_msgTypes[3]
+	mi := &file_github_com_kralicky_jobserver_pkg_apis_rbac_v1_rbac_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -299,7 +374,7 @@ func (x *RoleBinding) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RoleBinding.ProtoReflect.Descriptor instead.
 func (*RoleBinding) Descriptor() ([]byte, []int) {
-	return file_github_com_kralicky_jobserver_pkg_apis_rbac_v1_rbac_proto_rawDescGZIP(), []int{3}
+	return file_github_com_kralicky_jobserver_pkg_apis_rbac_v1_rbac_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *RoleBinding) GetId() string {
@@ -335,7 +410,7 @@ type ScopeOptions struct {
 func (x *ScopeOptions) Reset() {
 	*x = ScopeOptions{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_github_com_kralicky_jobserver_pkg_apis_rbac_v1_rbac_proto_msgTypes[4]
+		mi := &file_github_com_kralicky_jobserver_pkg_apis_rbac_v1_rbac_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -348,7 +423,7 @@ func (x *ScopeOptions) String() string {
 func (*ScopeOptions) ProtoMessage() {}
 
 func (x *ScopeOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_github_com_kralicky_jobserver_pkg_apis_rbac_v1_rbac_proto_msgTypes[4]
+	mi := &file_github_com_kralicky_jobserver_pkg_apis_rbac_v1_rbac_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -361,7 +436,7 @@ func (x *ScopeOptions) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ScopeOptions.ProtoReflect.Descriptor instead.
 func (*ScopeOptions) Descriptor() ([]byte, []int) {
-	return file_github_com_kralicky_jobserver_pkg_apis_rbac_v1_rbac_proto_rawDescGZIP(), []int{4}
+	return file_github_com_kralicky_jobserver_pkg_apis_rbac_v1_rbac_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *ScopeOptions) GetEnabled() bool {
@@ -404,39 +479,46 @@ var file_github_com_kralicky_jobserver_pkg_apis_rbac_v1_rbac_proto_rawDesc = []b
 	0x6e, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x72,
 	0x62, 0x61, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x6f, 0x6c, 0x65, 0x42, 0x69, 0x6e, 0x64, 0x69,
 	0x6e, 0x67, 0x52, 0x0c, 0x72, 0x6f, 0x6c, 0x65, 0x42, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x73,
-	0x22, 0x71, 0x0a, 0x04, 0x52, 0x6f, 0x6c, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x65, 0x72, 0x76,
-	0x69, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x65, 0x72, 0x76, 0x69,
-	0x63, 0x65, 0x12, 0x3f, 0x0a, 0x0f, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x5f, 0x6d, 0x65,
-	0x74, 0x68, 0x6f, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x72, 0x62,
-	0x61, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x4d, 0x65, 0x74,
-	0x68, 0x6f, 0x64, 0x52, 0x0e, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x4d, 0x65, 0x74, 0x68,
-	0x6f, 0x64, 0x73, 0x22, 0x58, 0x0a, 0x0d, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x4d, 0x65,
-	0x74, 0x68, 0x6f, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x29, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x70,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0e, 0x2e, 0x72, 0x62, 0x61, 0x63, 0x2e, 0x76,
-	0x31, 0x2e, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x48, 0x00, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x70, 0x65,
-	0x88, 0x01, 0x01, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x22, 0x4c, 0x0a,
-	0x0b, 0x52, 0x6f, 0x6c, 0x65, 0x42, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x0e, 0x0a, 0x02,
-	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x17, 0x0a, 0x07,
-	0x72, 0x6f, 0x6c, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72,
-	0x6f, 0x6c, 0x65, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x75, 0x73, 0x65, 0x72, 0x73, 0x18, 0x03,
-	0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x75, 0x73, 0x65, 0x72, 0x73, 0x22, 0x28, 0x0a, 0x0c, 0x53,
-	0x63, 0x6f, 0x70, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x65,
-	0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x6e,
-	0x61, 0x62, 0x6c, 0x65, 0x64, 0x2a, 0x32, 0x0a, 0x05, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x12, 0x08,
-	0x0a, 0x04, 0x4e, 0x4f, 0x4e, 0x45, 0x10, 0x00, 0x12, 0x10, 0x0a, 0x0c, 0x43, 0x55, 0x52, 0x52,
-	0x45, 0x4e, 0x54, 0x5f, 0x55, 0x53, 0x45, 0x52, 0x10, 0x01, 0x12, 0x0d, 0x0a, 0x09, 0x41, 0x4c,
-	0x4c, 0x5f, 0x55, 0x53, 0x45, 0x52, 0x53, 0x10, 0x02, 0x3a, 0x4d, 0x0a, 0x05, 0x73, 0x63, 0x6f,
-	0x70, 0x65, 0x12, 0x1e, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f,
-	0x6e, 0x73, 0x18, 0xd0, 0x86, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x72, 0x62, 0x61,
-	0x63, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
-	0x73, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x42, 0x37, 0x5a, 0x35, 0x67, 0x69, 0x74, 0x68,
-	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6b, 0x72, 0x61, 0x6c, 0x69, 0x63, 0x6b, 0x79, 0x2f,
-	0x6a, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x61, 0x70,
-	0x69, 0x73, 0x2f, 0x72, 0x62, 0x61, 0x63, 0x2f, 0x76, 0x31, 0x3b, 0x72, 0x62, 0x61, 0x63, 0x76,
-	0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x22, 0xb3, 0x01, 0x0a, 0x04, 0x52, 0x6f, 0x6c, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x12, 0x3f, 0x0a, 0x0f, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x5f, 0x6d,
+	0x65, 0x74, 0x68, 0x6f, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x72,
+	0x62, 0x61, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x4d, 0x65,
+	0x74, 0x68, 0x6f, 0x64, 0x52, 0x0e, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x4d, 0x65, 0x74,
+	0x68, 0x6f, 0x64, 0x73, 0x12, 0x40, 0x0a, 0x12, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x5f,
+	0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x11, 0x2e, 0x72, 0x62, 0x61, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x64, 0x65, 0x6e, 0x74,
+	0x69, 0x74, 0x79, 0x52, 0x11, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x49, 0x64, 0x65, 0x6e,
+	0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x22, 0x32, 0x0a, 0x08, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69,
+	0x74, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0d,
+	0x52, 0x04, 0x75, 0x69, 0x64, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x67, 0x69, 0x64, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0d, 0x52, 0x04, 0x67, 0x69, 0x64, 0x73, 0x22, 0x58, 0x0a, 0x0d, 0x41, 0x6c,
+	0x6c, 0x6f, 0x77, 0x65, 0x64, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x29, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0e,
+	0x2e, 0x72, 0x62, 0x61, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x48, 0x00,
+	0x52, 0x05, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x88, 0x01, 0x01, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x73,
+	0x63, 0x6f, 0x70, 0x65, 0x22, 0x4c, 0x0a, 0x0b, 0x52, 0x6f, 0x6c, 0x65, 0x42, 0x69, 0x6e, 0x64,
+	0x69, 0x6e, 0x67, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x02, 0x69, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x72, 0x6f, 0x6c, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x6f, 0x6c, 0x65, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05,
+	0x75, 0x73, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x75, 0x73, 0x65,
+	0x72, 0x73, 0x22, 0x28, 0x0a, 0x0c, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x2a, 0x32, 0x0a, 0x05,
+	0x53, 0x63, 0x6f, 0x70, 0x65, 0x12, 0x08, 0x0a, 0x04, 0x4e, 0x4f, 0x4e, 0x45, 0x10, 0x00, 0x12,
+	0x10, 0x0a, 0x0c, 0x43, 0x55, 0x52, 0x52, 0x45, 0x4e, 0x54, 0x5f, 0x55, 0x53, 0x45, 0x52, 0x10,
+	0x01, 0x12, 0x0d, 0x0a, 0x09, 0x41, 0x4c, 0x4c, 0x5f, 0x55, 0x53, 0x45, 0x52, 0x53, 0x10, 0x02,
+	0x3a, 0x4d, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x12, 0x1e, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4d, 0x65, 0x74, 0x68,
+	0x6f, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xd0, 0x86, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x15, 0x2e, 0x72, 0x62, 0x61, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x63, 0x6f, 0x70,
+	0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x42,
+	0x37, 0x5a, 0x35, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6b, 0x72,
+	0x61, 0x6c, 0x69, 0x63, 0x6b, 0x79, 0x2f, 0x6a, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x61, 0x70, 0x69, 0x73, 0x2f, 0x72, 0x62, 0x61, 0x63, 0x2f, 0x76,
+	0x31, 0x3b, 0x72, 0x62, 0x61, 0x63, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -452,28 +534,30 @@ func file_github_com_kralicky_jobserver_pkg_apis_rbac_v1_rbac_proto_rawDescGZIP(
 }
 
 var file_github_com_kralicky_jobserver_pkg_apis_rbac_v1_rbac_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_github_com_kralicky_jobserver_pkg_apis_rbac_v1_rbac_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_github_com_kralicky_jobserver_pkg_apis_rbac_v1_rbac_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
 var file_github_com_kralicky_jobserver_pkg_apis_rbac_v1_rbac_proto_goTypes = []interface{}{
 	(Scope)(0),                         // 0: rbac.v1.Scope
 	(*Config)(nil),                     // 1: rbac.v1.Config
 	(*Role)(nil),                       // 2: rbac.v1.Role
-	(*AllowedMethod)(nil),              // 3: rbac.v1.AllowedMethod
-	(*RoleBinding)(nil),                // 4: rbac.v1.RoleBinding
-	(*ScopeOptions)(nil),               // 5: rbac.v1.ScopeOptions
-	(*descriptorpb.MethodOptions)(nil), // 6: google.protobuf.MethodOptions
+	(*Identity)(nil),                   // 3: rbac.v1.Identity
+	(*AllowedMethod)(nil),              // 4: rbac.v1.AllowedMethod
+	(*RoleBinding)(nil),                // 5: rbac.v1.RoleBinding
+	(*ScopeOptions)(nil),               // 6: rbac.v1.ScopeOptions
+	(*descriptorpb.MethodOptions)(nil), // 7: google.protobuf.MethodOptions
 }
 var file_github_com_kralicky_jobserver_pkg_apis_rbac_v1_rbac_proto_depIdxs = []int32{
 	2, // 0: rbac.v1.Config.roles:type_name -> rbac.v1.Role
-	4, // 1: rbac.v1.Config.role_bindings:type_name -> rbac.v1.RoleBinding
-	3, // 2: rbac.v1.Role.allowed_methods:type_name -> rbac.v1.AllowedMethod
-	0, // 3: rbac.v1.AllowedMethod.scope:type_name -> rbac.v1.Scope
-	6, // 4: rbac.v1.scope:extendee -> google.protobuf.MethodOptions
-	5, // 5: rbac.v1.scope:type_name -> rbac.v1.ScopeOptions
-	6, // [6:6] is the sub-list for method output_type
-	6, // [6:6] is the sub-list for method input_type
-	5, // [5:6] is the sub-list for extension type_name
-	4, // [4:5] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	5, // 1: rbac.v1.Config.role_bindings:type_name -> rbac.v1.RoleBinding
+	4, // 2: rbac.v1.Role.allowed_methods:type_name -> rbac.v1.AllowedMethod
+	3, // 3: rbac.v1.Role.allowed_identities:type_name -> rbac.v1.Identity
+	0, // 4: rbac.v1.AllowedMethod.scope:type_name -> rbac.v1.Scope
+	7, // 5: rbac.v1.scope:extendee -> google.protobuf.MethodOptions
+	6, // 6: rbac.v1.scope:type_name -> rbac.v1.ScopeOptions
+	7, // [7:7] is the sub-list for method output_type
+	7, // [7:7] is the sub-list for method input_type
+	6, // [6:7] is the sub-list for extension type_name
+	5, // [5:6] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_github_com_kralicky_jobserver_pkg_apis_rbac_v1_rbac_proto_init() }
@@ -507,7 +591,7 @@ func file_github_com_kralicky_jobserver_pkg_apis_rbac_v1_rbac_proto_init() {
 			}
 		}
 		file_github_com_kralicky_jobserver_pkg_apis_rbac_v1_rbac_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AllowedMethod); i {
+			switch v := v.(*Identity); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -519,7 +603,7 @@ func file_github_com_kralicky_jobserver_pkg_apis_rbac_v1_rbac_proto_init() {
 			}
 		}
 		file_github_com_kralicky_jobserver_pkg_apis_rbac_v1_rbac_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RoleBinding); i {
+			switch v := v.(*AllowedMethod); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -531,6 +615,18 @@ func file_github_com_kralicky_jobserver_pkg_apis_rbac_v1_rbac_proto_init() {
 			}
 		}
 		file_github_com_kralicky_jobserver_pkg_apis_rbac_v1_rbac_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RoleBinding); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_kralicky_jobserver_pkg_apis_rbac_v1_rbac_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ScopeOptions); i {
 			case 0:
 				return &v.state
@@ -543,14 +639,14 @@ func file_github_com_kralicky_jobserver_pkg_apis_rbac_v1_rbac_proto_init() {
 			}
 		}
 	}
-	file_github_com_kralicky_jobserver_pkg_apis_rbac_v1_rbac_proto_msgTypes[2].OneofWrappers = []interface{}{}
+	file_github_com_kralicky_jobserver_pkg_apis_rbac_v1_rbac_proto_msgTypes[3].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_github_com_kralicky_jobserver_pkg_apis_rbac_v1_rbac_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   5,
+			NumMessages:   6,
 			NumExtensions: 1,
 			NumServices:   0,
 		},
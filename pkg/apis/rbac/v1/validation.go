@@ -72,6 +72,11 @@ func (c *Config) validateRoles() error {
 				return fmt.Errorf("invalid role %q: method %q requires a scope", roleId, methodName)
 			}
 		}
+		for i, id := range r.GetAllowedIdentities() {
+			if len(id.GetUids()) == 0 {
+				return fmt.Errorf("invalid role %q: allowed identity %d must contain at least one uid", roleId, i)
+			}
+		}
 	}
 
 	return nil
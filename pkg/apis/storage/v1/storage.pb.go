@@ -0,0 +1,286 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0-devel
+// 	protoc        (unknown)
+// source: github.com/kralicky/jobserver/pkg/apis/storage/v1/storage.proto
+
+package storagev1
+
+import (
+	v1 "github.com/kralicky/jobserver/pkg/apis/job/v1"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// JobRecord is the persisted representation of a single job, containing
+// everything the server needs to answer queries about the job after a restart.
+type JobRecord struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The job's unique id.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// The name of the authenticated user that created the job.
+	Owner string `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`
+	// The most recently observed status of the job. This includes the job's
+	// original spec.
+	Status *v1.JobStatus `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+}
+
+func (x *JobRecord) Reset() {
+	*x = JobRecord{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_kralicky_jobserver_pkg_apis_storage_v1_storage_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JobRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobRecord) ProtoMessage() {}
+
+func (x *JobRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_kralicky_jobserver_pkg_apis_storage_v1_storage_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobRecord.ProtoReflect.Descriptor instead.
+func (*JobRecord) Descriptor() ([]byte, []int) {
+	return file_github_com_kralicky_jobserver_pkg_apis_storage_v1_storage_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *JobRecord) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *JobRecord) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *JobRecord) GetStatus() *v1.JobStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+// ScheduleRecord is the persisted representation of a single schedule.
+type ScheduleRecord struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The schedule's unique id.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// The name of the authenticated user that created the schedule.
+	Owner string           `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`
+	Spec  *v1.ScheduleSpec `protobuf:"bytes,3,opt,name=spec,proto3" json:"spec,omitempty"`
+	// The last time at which the schedule fired, if any.
+	LastScheduleTime *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=last_schedule_time,json=lastScheduleTime,proto3" json:"last_schedule_time,omitempty"`
+}
+
+func (x *ScheduleRecord) Reset() {
+	*x = ScheduleRecord{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_kralicky_jobserver_pkg_apis_storage_v1_storage_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ScheduleRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleRecord) ProtoMessage() {}
+
+func (x *ScheduleRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_kralicky_jobserver_pkg_apis_storage_v1_storage_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleRecord.ProtoReflect.Descriptor instead.
+func (*ScheduleRecord) Descriptor() ([]byte, []int) {
+	return file_github_com_kralicky_jobserver_pkg_apis_storage_v1_storage_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ScheduleRecord) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ScheduleRecord) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *ScheduleRecord) GetSpec() *v1.ScheduleSpec {
+	if x != nil {
+		return x.Spec
+	}
+	return nil
+}
+
+func (x *ScheduleRecord) GetLastScheduleTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastScheduleTime
+	}
+	return nil
+}
+
+var File_github_com_kralicky_jobserver_pkg_apis_storage_v1_storage_proto protoreflect.FileDescriptor
+
+var file_github_com_kralicky_jobserver_pkg_apis_storage_v1_storage_proto_rawDesc = []byte{
+	0x0a, 0x3f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6b, 0x72, 0x61,
+	0x6c, 0x69, 0x63, 0x6b, 0x79, 0x2f, 0x6a, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f,
+	0x70, 0x6b, 0x67, 0x2f, 0x61, 0x70, 0x69, 0x73, 0x2f, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65,
+	0x2f, 0x76, 0x31, 0x2f, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x0a, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x1a, 0x37, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6b, 0x72, 0x61, 0x6c, 0x69, 0x63,
+	0x6b, 0x79, 0x2f, 0x6a, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x70, 0x6b, 0x67,
+	0x2f, 0x61, 0x70, 0x69, 0x73, 0x2f, 0x6a, 0x6f, 0x62, 0x2f, 0x76, 0x31, 0x2f, 0x6a, 0x6f, 0x62,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x5c, 0x0a, 0x09, 0x4a, 0x6f, 0x62, 0x52, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x12, 0x29, 0x0a, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6a, 0x6f, 0x62,
+	0x2e, 0x76, 0x31, 0x2e, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0xaa, 0x01, 0x0a, 0x0e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75,
+	0x6c, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65,
+	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x12, 0x28,
+	0x0a, 0x04, 0x73, 0x70, 0x65, 0x63, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6a,
+	0x6f, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x53, 0x70,
+	0x65, 0x63, 0x52, 0x04, 0x73, 0x70, 0x65, 0x63, 0x12, 0x48, 0x0a, 0x12, 0x6c, 0x61, 0x73, 0x74,
+	0x5f, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x10, 0x6c, 0x61, 0x73, 0x74, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x54, 0x69,
+	0x6d, 0x65, 0x42, 0x3d, 0x5a, 0x3b, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x6b, 0x72, 0x61, 0x6c, 0x69, 0x63, 0x6b, 0x79, 0x2f, 0x6a, 0x6f, 0x62, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x61, 0x70, 0x69, 0x73, 0x2f, 0x73, 0x74, 0x6f,
+	0x72, 0x61, 0x67, 0x65, 0x2f, 0x76, 0x31, 0x3b, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x76,
+	0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_github_com_kralicky_jobserver_pkg_apis_storage_v1_storage_proto_rawDescOnce sync.Once
+	file_github_com_kralicky_jobserver_pkg_apis_storage_v1_storage_proto_rawDescData = file_github_com_kralicky_jobserver_pkg_apis_storage_v1_storage_proto_rawDesc
+)
+
+func file_github_com_kralicky_jobserver_pkg_apis_storage_v1_storage_proto_rawDescGZIP() []byte {
+	file_github_com_kralicky_jobserver_pkg_apis_storage_v1_storage_proto_rawDescOnce.Do(func() {
+		file_github_com_kralicky_jobserver_pkg_apis_storage_v1_storage_proto_rawDescData = protoimpl.X.CompressGZIP(file_github_com_kralicky_jobserver_pkg_apis_storage_v1_storage_proto_rawDescData)
+	})
+	return file_github_com_kralicky_jobserver_pkg_apis_storage_v1_storage_proto_rawDescData
+}
+
+var file_github_com_kralicky_jobserver_pkg_apis_storage_v1_storage_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_github_com_kralicky_jobserver_pkg_apis_storage_v1_storage_proto_goTypes = []interface{}{
+	(*JobRecord)(nil),             // 0: storage.v1.JobRecord
+	(*ScheduleRecord)(nil),        // 1: storage.v1.ScheduleRecord
+	(*v1.JobStatus)(nil),          // 2: job.v1.JobStatus
+	(*v1.ScheduleSpec)(nil),       // 3: job.v1.ScheduleSpec
+	(*timestamppb.Timestamp)(nil), // 4: google.protobuf.Timestamp
+}
+var file_github_com_kralicky_jobserver_pkg_apis_storage_v1_storage_proto_depIdxs = []int32{
+	2, // 0: storage.v1.JobRecord.status:type_name -> job.v1.JobStatus
+	3, // 1: storage.v1.ScheduleRecord.spec:type_name -> job.v1.ScheduleSpec
+	4, // 2: storage.v1.ScheduleRecord.last_schedule_time:type_name -> google.protobuf.Timestamp
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_github_com_kralicky_jobserver_pkg_apis_storage_v1_storage_proto_init() }
+func file_github_com_kralicky_jobserver_pkg_apis_storage_v1_storage_proto_init() {
+	if File_github_com_kralicky_jobserver_pkg_apis_storage_v1_storage_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_github_com_kralicky_jobserver_pkg_apis_storage_v1_storage_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JobRecord); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_kralicky_jobserver_pkg_apis_storage_v1_storage_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ScheduleRecord); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_github_com_kralicky_jobserver_pkg_apis_storage_v1_storage_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_github_com_kralicky_jobserver_pkg_apis_storage_v1_storage_proto_goTypes,
+		DependencyIndexes: file_github_com_kralicky_jobserver_pkg_apis_storage_v1_storage_proto_depIdxs,
+		MessageInfos:      file_github_com_kralicky_jobserver_pkg_apis_storage_v1_storage_proto_msgTypes,
+	}.Build()
+	File_github_com_kralicky_jobserver_pkg_apis_storage_v1_storage_proto = out.File
+	file_github_com_kralicky_jobserver_pkg_apis_storage_v1_storage_proto_rawDesc = nil
+	file_github_com_kralicky_jobserver_pkg_apis_storage_v1_storage_proto_goTypes = nil
+	file_github_com_kralicky_jobserver_pkg_apis_storage_v1_storage_proto_depIdxs = nil
+}
@@ -14,6 +14,13 @@ var authnUserKey authnUserKeyType
 
 type AuthenticatedUser string
 
+// NewContextWithUser returns a context in which the given user is
+// authenticated. It is used by the authentication middleware, and can be used
+// to act on behalf of a user outside of a request.
+func NewContextWithUser(ctx context.Context, user AuthenticatedUser) context.Context {
+	return context.WithValue(ctx, authnUserKey, user)
+}
+
 // Returns the authorized user name from the context. Must only be called from
 // within a server handler, where the server is configured with the authz
 // interceptors.
@@ -27,7 +27,7 @@ func (m *authnMiddleware) Eval(ctx context.Context) (context.Context, error) {
 	if err != nil {
 		return ctx, err
 	}
-	return context.WithValue(ctx, authnUserKey, user), nil
+	return NewContextWithUser(ctx, user), nil
 }
 
 func UnaryServerInterceptor(middlewares []Middleware) grpc.UnaryServerInterceptor {
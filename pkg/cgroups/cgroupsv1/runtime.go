@@ -13,7 +13,7 @@ const (
 )
 
 func init() {
-	jobs.RegisterRuntime(cgroups.NewFilesystemRuntimeID(Magic), func() (jobs.Runtime, error) {
+	jobs.RegisterRuntime(cgroups.NewFilesystemRuntimeID(Magic), func(jobs.RuntimeOptions) (jobs.Runtime, error) {
 		return nil, errors.New("cgroupsv1 not supported")
 	})
 }
@@ -7,6 +7,8 @@ import (
 	"os"
 	"path/filepath"
 	"slices"
+	"strings"
+	"sync"
 
 	jobv1 "github.com/kralicky/jobserver/pkg/apis/job/v1"
 )
@@ -20,6 +22,10 @@ var requiredControllers = []string{"cpu", "memory", "io"}
 
 type cgroupManager struct {
 	path string
+
+	// optional controllers that have been enabled for job cgroups
+	optionalMu      sync.Mutex
+	optionalEnabled map[string]bool
 }
 
 func newCgroupManager() (*cgroupManager, error) {
@@ -55,40 +61,216 @@ func (m *cgroupManager) CreateCgroupWithLimits(id string, limits *jobv1.Resource
 	}
 	slog.Info("created cgroup", "path", path, "job", id)
 
+	if err := m.UpdateCgroupLimits(path, limits); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// UpdateCgroupLimits writes the limits that are present in the given limits to
+// the cgroup at path. Limits that are not present are left unchanged.
+//
+// Everything that can be checked without modifying the cgroup (such as the
+// availability of the cpuset controller, and the ids of IO devices) is checked
+// before any limits are written. If writing one of the limits still fails,
+// the limits that were already written are restored to their previous values,
+// so that the cgroup's limits are either all updated or left unchanged.
+func (m *cgroupManager) UpdateCgroupLimits(path string, limits *jobv1.ResourceLimits) error {
 	if limits == nil {
-		return path, nil
+		return nil
+	}
+	writes, err := m.planLimitWrites(path, limits)
+	if err != nil {
+		return err
 	}
 
-	// set all present limits
-	if limits.Cpu != nil {
-		if err := writeCpuMaxQuota(path, mcpusToCfsQuota(limits.GetCpu())); err != nil {
-			return "", fmt.Errorf("failed to set cpu.max: %w", err)
-		}
+	type applied struct {
+		write    limitWrite
+		previous string
 	}
-	if limits.Memory != nil {
-		if limits.Memory.SoftLimit != nil {
-			if err := writeMemoryHigh(path, *limits.Memory.SoftLimit); err != nil {
-				return "", fmt.Errorf("failed to set memory.high: %w", err)
-			}
+	var done []applied
+	for _, w := range writes {
+		previous, err := w.current(path)
+		if err == nil {
+			err = w.write()
 		}
-		if limits.Memory.Limit != nil {
-			if err := writeMemoryMax(path, *limits.Memory.Limit); err != nil {
-				return "", fmt.Errorf("failed to set memory.max: %w", err)
+		if err != nil {
+			err = fmt.Errorf("failed to set %s: %w", w, err)
+			for i := len(done) - 1; i >= 0; i-- {
+				if rerr := sysFsWrite(filepath.Join(path, done[i].write.file), done[i].previous); rerr != nil {
+					err = errors.Join(err, fmt.Errorf("failed to restore %s: %w", done[i].write, rerr))
+				}
 			}
+			return err
+		}
+		done = append(done, applied{write: w, previous: previous})
+	}
+	return nil
+}
+
+// limitWrite is a pending write of a single limit to a file in a cgroup.
+type limitWrite struct {
+	file   string // the name of the file that is written
+	device string // for io.max, the id of the device whose limits are written
+	write  func() error
+}
+
+func (w limitWrite) String() string {
+	if w.device != "" {
+		return fmt.Sprintf("%s for device %s", w.file, w.device)
+	}
+	return w.file
+}
+
+// current returns the current contents of the file that the write replaces,
+// in a form that can be written back to the file to restore them.
+func (w limitWrite) current(path string) (string, error) {
+	contents, err := os.ReadFile(filepath.Join(path, w.file))
+	if err != nil {
+		return "", err
+	}
+	if w.device == "" {
+		return strings.TrimSpace(string(contents)), nil
+	}
+	// io.max contains one line for each device with limits
+	for _, line := range strings.Split(string(contents), "\n") {
+		if fields := strings.Fields(line); len(fields) > 0 && fields[0] == w.device {
+			return line, nil
+		}
+	}
+	return w.device + " rbps=max wbps=max riops=max wiops=max", nil
+}
+
+// planLimitWrites returns the writes needed to apply the limits that are
+// present in the given limits to the cgroup at path, in the order in which
+// they must be written. No limits are written.
+func (m *cgroupManager) planLimitWrites(path string, limits *jobv1.ResourceLimits) ([]limitWrite, error) {
+	var writes []limitWrite
+	add := func(file string, write func() error) {
+		writes = append(writes, limitWrite{file: file, write: write})
+	}
+	if limits.Cpu != nil || limits.CpuPeriod != nil {
+		quota, period, err := cpuMaxForLimits(path, limits)
+		if err != nil {
+			return nil, fmt.Errorf("failed to set cpu.max: %w", err)
+		}
+		add("cpu.max", func() error { return writeCpuMax(path, quota, period) })
+	}
+	if limits.CpuWeight != nil {
+		add("cpu.weight", func() error { return writeCpuWeight(path, limits.GetCpuWeight()) })
+	}
+	if cpuset := limits.GetCpuset(); cpuset != nil {
+		if err := m.enableOptionalController("cpuset"); err != nil {
+			return nil, err
+		}
+		// mems must be set first, in case the current cpus are not allowed
+		// with the new mems (or vice versa, which fails either way)
+		if cpuset.GetMems() != "" {
+			add("cpuset.mems", func() error { return writeCpusetMems(path, cpuset.GetMems()) })
+		}
+		if cpuset.GetCpus() != "" {
+			add("cpuset.cpus", func() error { return writeCpusetCpus(path, cpuset.GetCpus()) })
+		}
+	}
+	if limits.Pids != nil {
+		if err := m.enableOptionalController("pids"); err != nil {
+			return nil, err
+		}
+		add("pids.max", func() error { return writePidsMax(path, limits.GetPids()) })
+	}
+	if mem := limits.Memory; mem != nil {
+		if mem.SoftLimit != nil {
+			add("memory.high", func() error { return writeMemoryHigh(path, mem.GetSoftLimit()) })
+		}
+		if mem.Limit != nil {
+			add("memory.max", func() error { return writeMemoryMax(path, mem.GetLimit()) })
+		}
+		if mem.SwapLimit != nil {
+			add("memory.swap.max", func() error { return writeMemorySwapMax(path, mem.GetSwapLimit()) })
+		}
+		if mem.Low != nil {
+			add("memory.low", func() error { return writeMemoryLow(path, mem.GetLow()) })
+		}
+		if mem.Min != nil {
+			add("memory.min", func() error { return writeMemoryMin(path, mem.GetMin()) })
+		}
+		if mem.OomGroup != nil {
+			add("memory.oom.group", func() error { return writeMemoryOomGroup(path, mem.GetOomGroup()) })
 		}
 	}
 	for _, dev := range limits.GetIo() {
 		id, err := lookupDeviceId(dev.Device)
 		if err != nil {
-			return "", fmt.Errorf("failed to lookup device id for %s: %w", dev.Device, err)
+			return nil, fmt.Errorf("failed to lookup device id for %s: %w", dev.Device, err)
 		}
 		if dev.Limits != nil {
-			if err := writeIoMax(path, id, dev.Limits); err != nil {
-				return "", fmt.Errorf("failed to set io.max for device %s: %w", id, err)
-			}
+			ioLimits := dev.Limits
+			writes = append(writes, limitWrite{
+				file:   "io.max",
+				device: id,
+				write:  func() error { return writeIoMax(path, id, ioLimits) },
+			})
 		}
 	}
-	return path, nil
+	return writes, nil
+}
+
+// cpuMaxForLimits returns the quota and period to write to cpu.max for the
+// cpu limit and period that are present in the given limits. If only one of
+// them is present, the other is derived from the current contents of cpu.max;
+// if only the period changes, the quota is scaled so that the job can still
+// use the same fraction of CPU time.
+func cpuMaxForLimits(path string, limits *jobv1.ResourceLimits) (quota, period int64, err error) {
+	quota, period, err = readCpuMax(path)
+	if err != nil {
+		return 0, 0, err
+	}
+	newPeriod := period
+	if limits.CpuPeriod != nil {
+		newPeriod = limits.GetCpuPeriod().AsDuration().Microseconds()
+	}
+	switch {
+	case limits.Cpu != nil:
+		quota = mcpusToCfsQuota(limits.GetCpu(), newPeriod)
+	case quota >= 0:
+		quota = max(cfsMinQuota, quota*newPeriod/period)
+	}
+	return quota, newPeriod, nil
+}
+
+// enableOptionalController enables the given controller for job cgroups, if
+// it is not enabled already. Unlike the required controllers, optional
+// controllers are only enabled once a job needs them, so that the server can
+// run on hosts where they are not available.
+func (m *cgroupManager) enableOptionalController(controller string) error {
+	m.optionalMu.Lock()
+	defer m.optionalMu.Unlock()
+	if m.optionalEnabled[controller] {
+		return nil
+	}
+	file := filepath.Join(m.path, "cgroup.subtree_control")
+	enabled, err := listControllers(file)
+	if err != nil {
+		return fmt.Errorf("failed to read controllers: %w", err)
+	}
+	if !slices.Contains(enabled, controller) {
+		available, err := listControllers(filepath.Join(m.path, "cgroup.controllers"))
+		if err != nil {
+			return fmt.Errorf("failed to read controllers: %w", err)
+		}
+		if !slices.Contains(available, controller) {
+			return fmt.Errorf("cgroup controller %q is not available in %s", controller, m.path)
+		}
+		slog.Info("enabling controller", "controller", controller, "file", file)
+		if err := enableController(file, controller); err != nil {
+			return fmt.Errorf("failed to enable controller %q: %w", controller, err)
+		}
+	}
+	if m.optionalEnabled == nil {
+		m.optionalEnabled = make(map[string]bool)
+	}
+	m.optionalEnabled[controller] = true
+	return nil
 }
 
 func requiredControllersEnabled(file string) (bool, error) {
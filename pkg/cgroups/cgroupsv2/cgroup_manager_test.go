@@ -0,0 +1,132 @@
+package cgroupsv2
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	jobv1 "github.com/kralicky/jobserver/pkg/apis/job/v1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+var _ = Describe("UpdateCgroupLimits", func() {
+	// the files of a fake cgroup, which is a regular directory
+	var path string
+	var mgr *cgroupManager
+	// sets the controllers that are enabled for job cgroups, and the
+	// controllers that are available to be enabled
+	setControllers := func(enabled, available string) {
+		Expect(os.WriteFile(filepath.Join(mgr.path, "cgroup.subtree_control"), []byte(enabled+"\n"), 0o644)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(mgr.path, "cgroup.controllers"), []byte(available+"\n"), 0o644)).To(Succeed())
+	}
+	BeforeEach(func() {
+		path = GinkgoT().TempDir()
+		mgr = &cgroupManager{path: GinkgoT().TempDir()}
+		for file, contents := range map[string]string{
+			"cpu.max":    "max 100000\n",
+			"cpu.weight": "100\n",
+			"pids.max":   "max\n",
+			"io.max":     "8:0 rbps=1000 wbps=max riops=max wiops=max\n",
+		} {
+			Expect(os.WriteFile(filepath.Join(path, file), []byte(contents), 0o644)).To(Succeed())
+		}
+		setControllers("cpu memory io pids", "cpu memory io pids")
+	})
+	read := func(file string) string {
+		contents, err := os.ReadFile(filepath.Join(path, file))
+		Expect(err).NotTo(HaveOccurred())
+		return strings.TrimSpace(string(contents))
+	}
+
+	It("should write every limit that is present", func() {
+		Expect(mgr.UpdateCgroupLimits(path, &jobv1.ResourceLimits{
+			CpuWeight: proto.Uint32(5000),
+			Pids:      proto.Int64(100),
+			Io: []*jobv1.IODeviceLimits{
+				{Device: "8:16", Limits: &jobv1.IOLimits{WriteBps: proto.Int64(2000)}},
+			},
+		})).To(Succeed())
+		Expect(read("cpu.weight")).To(Equal("5000"))
+		Expect(read("pids.max")).To(Equal("100"))
+		Expect(read("cpu.max")).To(Equal("max 100000"))
+		// writes to a regular file don't truncate it, unlike io.max
+		Expect(read("io.max")).To(HavePrefix("8:16 wbps=2000\n"))
+	})
+	It("should not write anything if a device can't be found", func() {
+		err := mgr.UpdateCgroupLimits(path, &jobv1.ResourceLimits{
+			CpuWeight: proto.Uint32(5000),
+			Io: []*jobv1.IODeviceLimits{
+				{Device: filepath.Join(path, "missing"), Limits: &jobv1.IOLimits{WriteBps: proto.Int64(2000)}},
+			},
+		})
+		Expect(err).To(MatchError(ContainSubstring("failed to lookup device id")))
+		Expect(read("cpu.weight")).To(Equal("100"))
+		Expect(read("io.max")).To(Equal("8:0 rbps=1000 wbps=max riops=max wiops=max"))
+	})
+	It("should not write anything if the cpuset controller is not available", func() {
+		setControllers("cpu memory io pids", "cpu memory io pids")
+		err := mgr.UpdateCgroupLimits(path, &jobv1.ResourceLimits{
+			CpuWeight: proto.Uint32(5000),
+			Cpuset:    &jobv1.CpusetLimits{Cpus: "0"},
+		})
+		Expect(err).To(MatchError(ContainSubstring(`"cpuset" is not available`)))
+		Expect(read("cpu.weight")).To(Equal("100"))
+	})
+	It("should scale the cpu quota if only the period changes", func() {
+		Expect(os.WriteFile(filepath.Join(path, "cpu.max"), []byte("50000 100000\n"), 0o644)).To(Succeed())
+		Expect(mgr.UpdateCgroupLimits(path, &jobv1.ResourceLimits{
+			CpuPeriod: durationpb.New(200 * time.Millisecond),
+		})).To(Succeed())
+		Expect(read("cpu.max")).To(Equal("100000 200000"))
+	})
+	It("should keep an unlimited cpu quota if only the period changes", func() {
+		Expect(mgr.UpdateCgroupLimits(path, &jobv1.ResourceLimits{
+			CpuPeriod: durationpb.New(50 * time.Millisecond),
+		})).To(Succeed())
+		Expect(read("cpu.max")).To(Equal("max 50000"))
+	})
+	It("should enable the pids controller once a job needs it", func() {
+		setControllers("cpu memory io", "cpu memory io pids")
+		Expect(mgr.UpdateCgroupLimits(path, &jobv1.ResourceLimits{CpuWeight: proto.Uint32(5000)})).To(Succeed())
+		Expect(os.ReadFile(filepath.Join(mgr.path, "cgroup.subtree_control"))).To(HavePrefix("cpu memory io\n"))
+
+		Expect(mgr.UpdateCgroupLimits(path, &jobv1.ResourceLimits{Pids: proto.Int64(100)})).To(Succeed())
+		// writes to a regular file don't truncate it, unlike cgroup.subtree_control
+		Expect(os.ReadFile(filepath.Join(mgr.path, "cgroup.subtree_control"))).To(HavePrefix("+pids\n"))
+		Expect(read("pids.max")).To(Equal("100"))
+	})
+	It("should not write anything if the pids controller is not available", func() {
+		setControllers("cpu memory io", "cpu memory io")
+		err := mgr.UpdateCgroupLimits(path, &jobv1.ResourceLimits{
+			CpuWeight: proto.Uint32(5000),
+			Pids:      proto.Int64(100),
+		})
+		Expect(err).To(MatchError(ContainSubstring(`"pids" is not available`)))
+		Expect(read("cpu.weight")).To(Equal("100"))
+		Expect(read("pids.max")).To(Equal("max"))
+	})
+	It("should restore the limits already written if a later write fails", func() {
+		// memory.max doesn't exist in the fake cgroup
+		err := mgr.UpdateCgroupLimits(path, &jobv1.ResourceLimits{
+			CpuWeight: proto.Uint32(5000),
+			Pids:      proto.Int64(100),
+			Memory:    &jobv1.MemoryLimits{Limit: proto.Int64(1024 * 1024)},
+		})
+		Expect(err).To(MatchError(ContainSubstring("failed to set memory.max")))
+		Expect(read("cpu.weight")).To(Equal("100"))
+		Expect(read("pids.max")).To(Equal("max"))
+	})
+	It("should restore the previous io.max limits of each device", func() {
+		for device, expected := range map[string]string{
+			"8:0":  "8:0 rbps=1000 wbps=max riops=max wiops=max",
+			"8:16": "8:16 rbps=max wbps=max riops=max wiops=max",
+		} {
+			Expect(limitWrite{file: "io.max", device: device}.current(path)).To(Equal(expected))
+		}
+		Expect(limitWrite{file: "cpu.max"}.current(path)).To(Equal("max 100000"))
+	})
+})
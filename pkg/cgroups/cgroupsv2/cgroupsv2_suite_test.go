@@ -0,0 +1,13 @@
+package cgroupsv2
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestCgroupsv2(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Cgroupsv2 Suite")
+}
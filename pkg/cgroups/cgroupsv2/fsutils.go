@@ -6,7 +6,9 @@ import (
 	"log/slog"
 	"os"
 	"path/filepath"
+	"regexp"
 	"runtime"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
@@ -15,6 +17,8 @@ import (
 	"golang.org/x/sys/unix"
 )
 
+var deviceIdRegex = regexp.MustCompile(`^[0-9]+:[0-9]+$`)
+
 func listControllers(file string) ([]string, error) {
 	info, err := os.ReadFile(file)
 	if err != nil {
@@ -36,10 +40,7 @@ func enableController(file, name string) (retErr error) {
 	return sysFsWrite(file, fmt.Sprintf("+%s\n", name))
 }
 
-const (
-	cfsPeriod   = 100000
-	cfsMinQuota = 1000
-)
+const cfsMinQuota = 1000
 
 // For the purposes of this project, we assume that the jobserver has the
 // full resources of the machine available to it. This may not be true in
@@ -47,12 +48,52 @@ const (
 // in a cgroup with limited resources).
 var availableMilliCpus = int64(runtime.NumCPU() * 1000)
 
-func mcpusToCfsQuota(milliCores int64) int64 {
-	return max(cfsMinQuota, int64(min(float64(milliCores)/float64(availableMilliCpus), 1.0)*cfsPeriod))
+func mcpusToCfsQuota(milliCores, period int64) int64 {
+	return max(cfsMinQuota, int64(min(float64(milliCores)/float64(availableMilliCpus), 1.0)*float64(period)))
+}
+
+// readCpuMax returns the quota and period in cpu.max, in microseconds. The
+// quota is -1 if it is not limited.
+func readCpuMax(path string) (quota, period int64, err error) {
+	contents, err := os.ReadFile(filepath.Join(path, "cpu.max"))
+	if err != nil {
+		return 0, 0, err
+	}
+	fields := strings.Fields(string(contents))
+	if len(fields) != 2 {
+		return 0, 0, fmt.Errorf("invalid cpu.max contents: %q", contents)
+	}
+	if period, err = strconv.ParseInt(fields[1], 10, 64); err != nil {
+		return 0, 0, fmt.Errorf("invalid cpu.max period: %w", err)
+	}
+	if fields[0] == "max" {
+		return -1, period, nil
+	}
+	if quota, err = strconv.ParseInt(fields[0], 10, 64); err != nil {
+		return 0, 0, fmt.Errorf("invalid cpu.max quota: %w", err)
+	}
+	return quota, period, nil
+}
+
+// writeCpuMax writes the quota and period to cpu.max. If the quota is
+// negative, it is not limited.
+func writeCpuMax(path string, quota, period int64) error {
+	if quota < 0 {
+		return sysFsWrite(filepath.Join(path, "cpu.max"), fmt.Sprintf("max %d\n", period))
+	}
+	return sysFsWrite(filepath.Join(path, "cpu.max"), fmt.Sprintf("%d %d\n", quota, period))
+}
+
+func writeCpuWeight(path string, weight uint32) error {
+	return sysFsWrite(filepath.Join(path, "cpu.weight"), fmt.Sprintf("%d\n", weight))
 }
 
-func writeCpuMaxQuota(path string, quota int64) error {
-	return sysFsWrite(filepath.Join(path, "cpu.max"), fmt.Sprintf("%d %d\n", quota, cfsPeriod))
+func writeCpusetCpus(path string, cpus string) error {
+	return sysFsWrite(filepath.Join(path, "cpuset.cpus"), cpus)
+}
+
+func writeCpusetMems(path string, mems string) error {
+	return sysFsWrite(filepath.Join(path, "cpuset.mems"), mems)
 }
 
 func writeMemoryHigh(path string, high int64) error {
@@ -63,6 +104,30 @@ func writeMemoryMax(path string, max int64) error {
 	return sysFsWrite(filepath.Join(path, "memory.max"), fmt.Sprintf("%d\n", max))
 }
 
+func writeMemorySwapMax(path string, max int64) error {
+	return sysFsWrite(filepath.Join(path, "memory.swap.max"), fmt.Sprintf("%d\n", max))
+}
+
+func writeMemoryLow(path string, low int64) error {
+	return sysFsWrite(filepath.Join(path, "memory.low"), fmt.Sprintf("%d\n", low))
+}
+
+func writeMemoryMin(path string, min int64) error {
+	return sysFsWrite(filepath.Join(path, "memory.min"), fmt.Sprintf("%d\n", min))
+}
+
+func writeMemoryOomGroup(path string, enabled bool) error {
+	value := "0\n"
+	if enabled {
+		value = "1\n"
+	}
+	return sysFsWrite(filepath.Join(path, "memory.oom.group"), value)
+}
+
+func writePidsMax(path string, max int64) error {
+	return sysFsWrite(filepath.Join(path, "pids.max"), fmt.Sprintf("%d\n", max))
+}
+
 func writeIoMax(path, deviceId string, ioLimits *jobv1.IOLimits) error {
 	builder := strings.Builder{}
 	builder.WriteString(deviceId)
@@ -136,6 +201,25 @@ func killCgroup(path string) error {
 	return nil
 }
 
+// signalCgroup sends a signal to every process in the cgroup. Processes that
+// exit before they can be signaled are ignored.
+func signalCgroup(path string, sig syscall.Signal) error {
+	contents, err := os.ReadFile(filepath.Join(path, "cgroup.procs"))
+	if err != nil {
+		return err
+	}
+	for _, field := range strings.Fields(string(contents)) {
+		pid, err := strconv.Atoi(field)
+		if err != nil {
+			return fmt.Errorf("invalid pid in cgroup.procs: %q", field)
+		}
+		if err := syscall.Kill(pid, sig); err != nil && !errors.Is(err, syscall.ESRCH) {
+			return fmt.Errorf("failed to signal process %d: %w", pid, err)
+		}
+	}
+	return nil
+}
+
 func isCgroupPopulated(path string) (bool, error) {
 	contents, err := os.ReadFile(filepath.Join(path, "cgroup.events"))
 	if err != nil {
@@ -153,7 +237,12 @@ func isCgroupPopulated(path string) (bool, error) {
 	return false, nil
 }
 
+// lookupDeviceId returns the id (major:minor) of the device at the given path.
+// If the path is already a device id, it is returned unchanged.
 func lookupDeviceId(path string) (string, error) {
+	if deviceIdRegex.MatchString(path) {
+		return path, nil
+	}
 	info, err := os.Stat(path)
 	if err != nil {
 		return "", err
@@ -3,10 +3,13 @@ package cgroupsv2
 import (
 	"context"
 	"errors"
+	"io"
 	"log/slog"
+	"os"
 	"os/exec"
 	"sync"
 	"syscall"
+	"time"
 
 	jobv1 "github.com/kralicky/jobserver/pkg/apis/job/v1"
 	"github.com/kralicky/jobserver/pkg/jobs"
@@ -19,11 +22,26 @@ type v2Process struct {
 	id         string
 	cmd        *exec.Cmd
 	cmdContext context.Context
-	streamBuf  *util.StreamBuffer
+	streamBuf  jobs.OutputBuffer
+	stdin      io.WriteCloser // nil if stdin is not enabled
+	term       *terminal      // nil if tty is not enabled
+	cgroupPath string
+	mgr        *cgroupManager
 	done       chan struct{}
 
 	statusMu sync.Mutex
 	status   *jobv1.JobStatus
+	stopped  bool // true if Stop was called
+	// true if the job was stopped by its timeout or deadline
+	deadlineExceeded bool
+
+	usageMu sync.Mutex
+	// the usage measured before the job's cgroup was removed, or nil
+	finalUsage *jobv1.ResourceUsage
+	// the pids.events max count measured before the job's cgroup was removed
+	finalPidsLimitHits uint64
+	// true once the job's cgroup is about to be removed
+	cgroupReleased bool
 }
 
 func (j *v2Process) ID() string {
@@ -32,7 +50,7 @@ func (j *v2Process) ID() string {
 
 func (j *v2Process) start() {
 	lg := slog.With(
-		"command", j.cmd.Path,
+		"command", j.status.GetSpec().GetCommand().GetCommand(),
 		"driver", "cgroupsv2",
 	)
 
@@ -41,6 +59,9 @@ func (j *v2Process) start() {
 
 	if err := j.cmd.Start(); err != nil {
 		lg.Error("failed to start command")
+		if j.term != nil {
+			j.term.close()
+		}
 		j.streamBuf.Close()
 		close(j.done)
 		j.status.State = jobv1.State_FAILED
@@ -52,11 +73,35 @@ func (j *v2Process) start() {
 	j.status.Message = jobv1.State_RUNNING.String()
 	j.status.Pid = int32(j.cmd.Process.Pid)
 	lg.Info("command started")
+	stopAfterFunc := context.AfterFunc(j.cmdContext, func() {
+		j.statusMu.Lock()
+		defer j.statusMu.Unlock()
+		if j.status.State == jobv1.State_RUNNING {
+			lg.Debug("context canceled; attempting graceful shutdown")
+			j.stopLocked(jobs.StopOptionsForSpec(j.status.GetSpec()))
+		}
+	})
+	var deadlineTimer *time.Timer
+	if deadline, ok := jobs.DeadlineForSpec(j.status.GetSpec(), j.status.StartTime.AsTime()); ok {
+		deadlineTimer = time.AfterFunc(time.Until(deadline), j.stopAtDeadline)
+	}
+	if j.term != nil {
+		j.term.started(jobs.StreamWriter(j.streamBuf, jobv1.Stream_STDOUT))
+	}
 
 	go func() {
 		defer j.streamBuf.Close()
 		defer close(j.done)
 		j.cmd.Wait()
+		// the job's context may outlive the process (e.g. across restarts),
+		// so don't keep the process reachable from it
+		stopAfterFunc()
+		if deadlineTimer != nil {
+			deadlineTimer.Stop()
+		}
+		if j.term != nil {
+			j.term.drain()
+		}
 		endTime := timestamppb.Now()
 
 		j.statusMu.Lock()
@@ -64,8 +109,9 @@ func (j *v2Process) start() {
 
 		ws := j.cmd.ProcessState.Sys().(syscall.WaitStatus)
 		term := &jobv1.TerminationStatus{
-			Stopped: errors.Is(context.Cause(j.cmdContext), jobs.ErrStoppedByUser),
-			Time:    endTime,
+			Stopped:          j.stopped || errors.Is(context.Cause(j.cmdContext), jobs.ErrStoppedByUser),
+			DeadlineExceeded: j.deadlineExceeded,
+			Time:             endTime,
 		}
 		if ws.Exited() {
 			term.ExitCode = int32(ws.ExitStatus())
@@ -82,19 +128,188 @@ func (j *v2Process) start() {
 			"exitCode", ws.ExitStatus(),
 			"signal", ws.Signal(),
 			"stopped", term.Stopped,
+			"deadlineExceeded", term.DeadlineExceeded,
 			"duration", endTime.AsTime().Sub(j.status.GetStartTime().AsTime()),
 		).Info("command terminated")
 	}()
 }
 
-func (j *v2Process) Output(ctx context.Context) <-chan []byte {
-	return j.streamBuf.NewStream(ctx)
+func (j *v2Process) Output(ctx context.Context, offset int64) <-chan util.StreamChunk {
+	return j.streamBuf.NewChunkStreamFrom(ctx, offset)
 }
 
-func (j *v2Process) Status() *jobv1.JobStatus {
+func (j *v2Process) ReadOutputBackward(end int64, fn func(util.StreamChunk) bool) error {
+	return j.streamBuf.ReadChunksBackward(end, fn)
+}
+
+func (j *v2Process) OutputSize() int64 {
+	return j.streamBuf.Size()
+}
+
+func (j *v2Process) Stdin() io.WriteCloser {
+	return j.stdin
+}
+
+func (j *v2Process) ResizeTerminal(size *jobv1.TerminalSize) error {
+	if j.term == nil {
+		return jobs.ErrNoTerminal
+	}
+	return j.term.resize(size)
+}
+
+func (j *v2Process) Signal(event *jobv1.SignalEvent) error {
 	j.statusMu.Lock()
 	defer j.statusMu.Unlock()
-	return proto.Clone(j.status).(*jobv1.JobStatus)
+	if j.status.State != jobv1.State_RUNNING {
+		return jobs.ErrNotRunning
+	}
+	sig := syscall.Signal(event.GetSignal())
+	if event.GetAllProcesses() {
+		if err := signalCgroup(j.cgroupPath, sig); err != nil {
+			return err
+		}
+	} else if err := j.cmd.Process.Signal(sig); err != nil {
+		if errors.Is(err, os.ErrProcessDone) {
+			return jobs.ErrNotRunning
+		}
+		return err
+	}
+	event = proto.Clone(event).(*jobv1.SignalEvent)
+	event.Time = timestamppb.Now()
+	j.status.Signals = append(j.status.Signals, event)
+	slog.With(
+		"id", j.id,
+		"signal", sig,
+		"allProcesses", event.AllProcesses,
+		"user", event.User,
+	).Info("sent signal to job")
+	return nil
+}
+
+func (j *v2Process) UpdateLimits(limits *jobv1.ResourceLimits) error {
+	j.statusMu.Lock()
+	defer j.statusMu.Unlock()
+	if j.status.State != jobv1.State_RUNNING {
+		return jobs.ErrNotRunning
+	}
+	if err := j.mgr.UpdateCgroupLimits(j.cgroupPath, limits); err != nil {
+		return err
+	}
+	slog.With("id", j.id, "limits", limits).Info("updated job limits")
+	return nil
+}
+
+func (j *v2Process) Stop(opts jobs.StopOptions) error {
+	j.statusMu.Lock()
+	defer j.statusMu.Unlock()
+	if j.status.State != jobv1.State_RUNNING {
+		return jobs.ErrNotRunning
+	}
+	j.stopped = true
+	j.stopLocked(opts)
+	return nil
+}
+
+// stopLocked sends the stop signal to the process, then kills every process
+// in the job's cgroup if the process has not exited after the grace period.
+// If opts.Force is set, the cgroup is killed immediately. The caller must
+// hold statusMu, and the process must be running.
+func (j *v2Process) stopLocked(opts jobs.StopOptions) {
+	lg := slog.With("id", j.id)
+	if opts.Force {
+		lg.Info("killing job")
+		go j.kill()
+		return
+	}
+	lg.With(
+		"signal", opts.Signal,
+		"gracePeriod", opts.GracePeriod,
+	).Info("stopping job")
+	if err := j.cmd.Process.Signal(opts.Signal); err != nil && !errors.Is(err, os.ErrProcessDone) {
+		lg.With("error", err).Error("failed to send stop signal")
+	}
+	go func() {
+		timer := time.NewTimer(opts.GracePeriod)
+		defer timer.Stop()
+		select {
+		case <-j.done:
+		case <-timer.C:
+			lg.Warn("job did not exit within its grace period; killing")
+			j.kill()
+		}
+	}()
+}
+
+// stopAtDeadline stops the job if it is still running when its timeout or
+// deadline is reached, unless it is already being stopped by the user.
+func (j *v2Process) stopAtDeadline() {
+	j.statusMu.Lock()
+	defer j.statusMu.Unlock()
+	if j.status.State != jobv1.State_RUNNING || j.stopped {
+		return
+	}
+	slog.With("id", j.id).Info("job reached its deadline")
+	j.deadlineExceeded = true
+	j.stopLocked(jobs.StopOptionsForSpec(j.status.GetSpec()))
+}
+
+// kill kills every process in the job's cgroup.
+func (j *v2Process) kill() {
+	if err := killCgroup(j.cgroupPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		slog.Error("failed to kill cgroup", "path", j.cgroupPath, "error", err)
+	}
+}
+
+func (j *v2Process) Status() *jobv1.JobStatus {
+	j.statusMu.Lock()
+	status := proto.Clone(j.status).(*jobv1.JobStatus)
+	j.statusMu.Unlock()
+	status.PidsLimitHits = j.pidsLimitHits()
+	return status
+}
+
+// pidsLimitHits returns the number of times the job failed to create a
+// process because it reached its process limit.
+func (j *v2Process) pidsLimitHits() uint64 {
+	j.usageMu.Lock()
+	defer j.usageMu.Unlock()
+	if j.cgroupReleased {
+		return j.finalPidsLimitHits
+	}
+	hits, err := readPidsLimitHits(j.cgroupPath)
+	if err != nil {
+		return 0
+	}
+	return hits
+}
+
+func (j *v2Process) Usage() (*jobv1.ResourceUsage, error) {
+	j.usageMu.Lock()
+	defer j.usageMu.Unlock()
+	if j.finalUsage != nil {
+		return proto.Clone(j.finalUsage).(*jobv1.ResourceUsage), nil
+	}
+	if j.cgroupReleased {
+		return nil, jobs.ErrUsageUnavailable
+	}
+	return readUsage(j.cgroupPath)
+}
+
+// releaseCgroup records the final usage of the job's cgroup, which must be
+// called after every process in the cgroup has exited, and before the cgroup
+// is removed.
+func (j *v2Process) releaseCgroup() {
+	j.usageMu.Lock()
+	defer j.usageMu.Unlock()
+	usage, err := readUsage(j.cgroupPath)
+	if err != nil {
+		slog.Error("failed to read final cgroup usage", "path", j.cgroupPath, "error", err)
+	}
+	j.finalUsage = usage
+	if hits, err := readPidsLimitHits(j.cgroupPath); err == nil {
+		j.finalPidsLimitHits = hits
+	}
+	j.cgroupReleased = true
 }
 
 func (j *v2Process) Done() <-chan struct{} {
@@ -2,68 +2,109 @@ package cgroupsv2
 
 import (
 	"context"
-	"encoding/hex"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
 	"os/exec"
 	"syscall"
 	"time"
 
-	"github.com/google/uuid"
 	jobv1 "github.com/kralicky/jobserver/pkg/apis/job/v1"
 	"github.com/kralicky/jobserver/pkg/cgroups"
 	"github.com/kralicky/jobserver/pkg/jobs"
-	"github.com/kralicky/jobserver/pkg/util"
 )
 
-const gracePeriod = 10 * time.Second
+// How long to wait for the job's stdout and stderr to be closed after its
+// main process exits. Any processes left behind by the main process may keep
+// them open until the job's cgroup is killed.
+const ioWaitDelay = 10 * time.Second
 
 type v2Runtime struct {
-	mgr *cgroupManager
+	mgr    *cgroupManager
+	output jobs.OutputBackend
 }
 
-func newRuntime() (jobs.Runtime, error) {
+func newRuntime(opts jobs.RuntimeOptions) (jobs.Runtime, error) {
 	mgr, err := newCgroupManager()
 	if err != nil {
 		return nil, fmt.Errorf("failed to setup jobserver cgroup: %w", err)
 	}
+	if opts.Output == nil {
+		opts.Output = jobs.NewMemoryOutputBackend(0)
+	}
 
 	return &v2Runtime{
-		mgr: mgr,
+		mgr:    mgr,
+		output: opts.Output,
 	}, nil
 }
 
 // Execute implements jobs.Runtime.
-func (l *v2Runtime) Execute(ctx context.Context, spec *jobv1.JobSpec) (jobs.Process, error) {
+func (l *v2Runtime) Execute(ctx context.Context, id string, spec *jobv1.JobSpec) (jobs.Process, error) {
 	cmdSpec := spec.GetCommand()
 
-	// generate a uuid for the process, but encode it in the raw hex format.
-	// this makes it slightly easier to use from the command line, since many
-	// terminals treat '-' as a word separator, making it difficult to copy
-	// the whole string using a double-click.
-	u := uuid.New()
-	id := hex.EncodeToString(u[:])
-
-	cmd := exec.CommandContext(ctx, cmdSpec.GetCommand(), cmdSpec.GetArgs()...)
+	// the context is handled by the process itself (see v2Process.start), so
+	// that the job can be stopped according to its stop policy
+	cmd := exec.Command(cmdSpec.GetCommand(), cmdSpec.GetArgs()...)
 	cmd.Env = append(os.Environ(), cmdSpec.GetEnv()...)
+	cmd.Dir = cmdSpec.GetWorkdir()
 
-	streamBuf := util.NewStreamBuffer()
+	streamBuf, err := l.output.NewBuffer(id, spec.GetOutput())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create output buffer for job %s: %w", id, err)
+	}
 	done := make(chan struct{})
 
-	cmd.Stdout = streamBuf
-	cmd.Stderr = streamBuf
-	cmd.Stdin = nil
-	cmd.WaitDelay = gracePeriod
-	cmd.Cancel = func() error {
-		slog.With("id", id).Debug("context canceled; attempting graceful shutdown")
-		streamBuf.Close() // NB: leaving this open will cause cmd.Wait to hang
-		return cmd.Process.Signal(syscall.SIGTERM)
+	var stdin io.WriteCloser
+	var term *terminal
+	if cmdSpec.GetTty() {
+		term, err = newTerminal()
+		if err != nil {
+			streamBuf.Close()
+			return nil, fmt.Errorf("failed to allocate terminal for job %s: %w", id, err)
+		}
+		cmd.Stdin = term.tty
+		cmd.Stdout = term.tty
+		cmd.Stderr = term.tty
+		cmd.SysProcAttr = &syscall.SysProcAttr{
+			Setsid:  true,
+			Setctty: true,
+			Ctty:    0, // stdin in the child process
+		}
+		stdin = term
+	} else {
+		cmd.Stdout = jobs.StreamWriter(streamBuf, jobv1.Stream_STDOUT)
+		cmd.Stderr = jobs.StreamWriter(streamBuf, jobv1.Stream_STDERR)
+		if cmdSpec.GetStdin() {
+			pipe, err := cmd.StdinPipe()
+			if err != nil {
+				streamBuf.Close()
+				return nil, fmt.Errorf("failed to create stdin pipe for job %s: %w", id, err)
+			}
+			stdin = &stdinWriter{w: pipe}
+		}
+	}
+	cmd.WaitDelay = ioWaitDelay
+	if cred := cmdSpec.GetCredential(); cred != nil {
+		if cmd.SysProcAttr == nil {
+			cmd.SysProcAttr = &syscall.SysProcAttr{}
+		}
+		cmd.SysProcAttr.Credential = &syscall.Credential{
+			Uid:    cred.GetUid(),
+			Gid:    cred.GetGid(),
+			Groups: cred.GetGroups(),
+		}
+	}
+	if cmdSpec.Umask != nil {
+		withUmask(cmd, cmdSpec.GetUmask())
 	}
 
 	job := &v2Process{
 		id:         id,
 		streamBuf:  streamBuf,
+		stdin:      stdin,
+		term:       term,
 		cmd:        cmd,
 		cmdContext: ctx,
 		done:       done,
@@ -77,6 +118,13 @@ func (l *v2Runtime) Execute(ctx context.Context, spec *jobv1.JobSpec) (jobs.Proc
 	if err := l.configureCgroup(job, id, spec.GetLimits()); err != nil {
 		job.status.State = jobv1.State_FAILED
 		job.status.Message = err.Error()
+		if term != nil {
+			term.close()
+		}
+		streamBuf.Close()
+		if err := l.output.Remove(id); err != nil {
+			slog.Error("failed to remove job output", "id", id, "error", err)
+		}
 		return nil, err
 	}
 
@@ -102,10 +150,13 @@ func (l *v2Runtime) configureCgroup(job *v2Process, id string, limits *jobv1.Res
 		}
 		break
 	}
-	job.cmd.SysProcAttr = &syscall.SysProcAttr{
-		UseCgroupFD: true,
-		CgroupFD:    cf,
+	if job.cmd.SysProcAttr == nil {
+		job.cmd.SysProcAttr = &syscall.SysProcAttr{}
 	}
+	job.cgroupPath = path
+	job.mgr = l.mgr
+	job.cmd.SysProcAttr.UseCgroupFD = true
+	job.cmd.SysProcAttr.CgroupFD = cf
 	go func() {
 		<-job.Done()
 		if err := syscall.Close(cf); err != nil {
@@ -114,6 +165,7 @@ func (l *v2Runtime) configureCgroup(job *v2Process, id string, limits *jobv1.Res
 		if err := killCgroup(path); err != nil {
 			slog.Error("failed to kill cgroup", "path", path, "error", err)
 		}
+		job.releaseCgroup()
 		if err := os.Remove(path); err != nil {
 			slog.Error("failed to remove cgroup", "path", path, "error", err)
 		} else {
@@ -128,7 +180,7 @@ var _ jobs.Runtime = (*v2Runtime)(nil)
 const Magic = 0x63677270
 
 func init() {
-	jobs.RegisterRuntime(cgroups.NewFilesystemRuntimeID(Magic), func() (jobs.Runtime, error) {
-		return newRuntime()
+	jobs.RegisterRuntime(cgroups.NewFilesystemRuntimeID(Magic), func(opts jobs.RuntimeOptions) (jobs.Runtime, error) {
+		return newRuntime(opts)
 	})
 }
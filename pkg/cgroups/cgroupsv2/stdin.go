@@ -0,0 +1,33 @@
+package cgroupsv2
+
+import (
+	"io"
+	"sync"
+)
+
+// stdinWriter serializes writes to a process's stdin pipe, and allows Close
+// to be called more than once. Writes after Close return io.ErrClosedPipe.
+type stdinWriter struct {
+	mu     sync.Mutex
+	w      io.WriteCloser
+	closed bool
+}
+
+func (s *stdinWriter) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return 0, io.ErrClosedPipe
+	}
+	return s.w.Write(p)
+}
+
+func (s *stdinWriter) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	return s.w.Close()
+}
@@ -0,0 +1,110 @@
+package cgroupsv2
+
+import (
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/creack/pty"
+	jobv1 "github.com/kralicky/jobserver/pkg/apis/job/v1"
+)
+
+// ttyDrainTimeout is the maximum amount of time to wait for remaining output
+// to be read from a job's terminal after its process exits. Output can only
+// be read until every process holding the terminal open has exited, which
+// may never happen if the process left any children behind.
+const ttyDrainTimeout = 1 * time.Second
+
+var defaultTerminalSize = &jobv1.TerminalSize{Rows: 24, Cols: 80}
+
+// terminal is a pseudo-terminal allocated for a job's process. The process
+// is given the terminal's slave side as its stdin, stdout, and stderr, while
+// the job server holds the master side.
+type terminal struct {
+	ptmx *os.File
+	tty  *os.File
+
+	mu     sync.Mutex
+	closed bool // true once stdin has been closed
+
+	copyDone chan struct{}
+}
+
+func newTerminal() (*terminal, error) {
+	ptmx, tty, err := pty.Open()
+	if err != nil {
+		return nil, err
+	}
+	t := &terminal{
+		ptmx:     ptmx,
+		tty:      tty,
+		copyDone: make(chan struct{}),
+	}
+	if err := t.resize(defaultTerminalSize); err != nil {
+		t.close()
+		return nil, err
+	}
+	return t, nil
+}
+
+// started must be called after the process has been started. It closes the
+// job server's copy of the slave side of the terminal, and starts copying
+// the terminal's output to w.
+func (t *terminal) started(w io.Writer) {
+	t.tty.Close()
+	go func() {
+		defer close(t.copyDone)
+		// reading from the master side returns EIO once all processes have
+		// closed the slave side
+		io.Copy(w, t.ptmx)
+	}()
+}
+
+// drain waits for the remaining output to be copied from the terminal (up to
+// ttyDrainTimeout), then closes the terminal.
+func (t *terminal) drain() {
+	select {
+	case <-t.copyDone:
+	case <-time.After(ttyDrainTimeout):
+	}
+	t.ptmx.Close()
+	<-t.copyDone
+}
+
+// close closes both sides of the terminal. It is only used if the process
+// could not be started.
+func (t *terminal) close() {
+	t.tty.Close()
+	t.ptmx.Close()
+}
+
+func (t *terminal) resize(size *jobv1.TerminalSize) error {
+	return pty.Setsize(t.ptmx, &pty.Winsize{
+		Rows: uint16(size.GetRows()),
+		Cols: uint16(size.GetCols()),
+	})
+}
+
+// Write writes input to the terminal, as if it were typed by the user.
+func (t *terminal) Write(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.closed {
+		return 0, io.ErrClosedPipe
+	}
+	return t.ptmx.Write(p)
+}
+
+// Close sends an end-of-file character (Ctrl-D) to the terminal. The
+// terminal itself remains open until the process exits.
+func (t *terminal) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.closed {
+		return nil
+	}
+	t.closed = true
+	_, err := t.ptmx.Write([]byte{0x04})
+	return err
+}
@@ -0,0 +1,49 @@
+package cgroupsv2
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"syscall"
+)
+
+// The umask is shared by every thread in the process, so it can't be changed
+// for a single child process without also affecting files created by the job
+// server in the meantime. Instead, commands with a umask are started through
+// a shim: the job server executes itself with umaskShimArg0 as argv[0], and
+// the shim sets the umask in the new process before executing the command.
+const umaskShimArg0 = "jobserver-umask-shim"
+
+func init() {
+	// argv: shim, umask, command path, command argv...
+	if len(os.Args) >= 4 && os.Args[0] == umaskShimArg0 {
+		runUmaskShim(os.Args[1], os.Args[2], os.Args[3:])
+	}
+}
+
+// withUmask changes cmd so that it is started through the umask shim, and runs
+// with the given umask. The job server's executable must be executable by the
+// user the command runs as.
+func withUmask(cmd *exec.Cmd, umask uint32) {
+	if cmd.Err != nil {
+		// the command was not found; leave the error to cmd.Start
+		return
+	}
+	cmd.Args = append([]string{umaskShimArg0, strconv.FormatUint(uint64(umask), 8), cmd.Path}, cmd.Args...)
+	cmd.Path = "/proc/self/exe"
+}
+
+// runUmaskShim sets the umask of the current process, then replaces it with
+// the command at path. It only returns by exiting the process.
+func runUmaskShim(umask, path string, argv []string) {
+	mask, err := strconv.ParseUint(umask, 8, 32)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: invalid umask %q\n", umaskShimArg0, umask)
+		os.Exit(127)
+	}
+	syscall.Umask(int(mask))
+	err = syscall.Exec(path, argv, os.Environ())
+	fmt.Fprintf(os.Stderr, "%s: %v\n", path, err)
+	os.Exit(127)
+}
@@ -0,0 +1,42 @@
+package cgroupsv2
+
+import (
+	"os/exec"
+	"strings"
+	"syscall"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Umask", func() {
+	It("should start the command with the given umask", func() {
+		cmd := exec.Command("sh", "-c", "umask; echo \"$0\" \"$@\"", "arg0", "arg1")
+		withUmask(cmd, 0o077)
+		out, err := cmd.Output()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(strings.Split(strings.TrimSpace(string(out)), "\n")).To(Equal([]string{"0077", "arg0 arg1"}))
+	})
+	It("should not change the umask of the current process", func() {
+		prev := syscall.Umask(0o022)
+		defer syscall.Umask(prev)
+
+		cmd := exec.Command("true")
+		withUmask(cmd, 0o077)
+		Expect(cmd.Start()).To(Succeed())
+		Expect(syscall.Umask(0o022)).To(Equal(0o022))
+		Expect(cmd.Wait()).To(Succeed())
+	})
+	It("should report the command's exit code", func() {
+		cmd := exec.Command("sh", "-c", "exit 3")
+		withUmask(cmd, 0o022)
+		err := cmd.Run()
+		Expect(err).To(BeAssignableToTypeOf(&exec.ExitError{}))
+		Expect(cmd.ProcessState.ExitCode()).To(Equal(3))
+	})
+	It("should keep the error of a command that was not found", func() {
+		cmd := exec.Command("this-command-does-not-exist")
+		withUmask(cmd, 0o022)
+		Expect(cmd.Start()).To(MatchError(exec.ErrNotFound))
+	})
+})
@@ -0,0 +1,165 @@
+package cgroupsv2
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	jobv1 "github.com/kralicky/jobserver/pkg/apis/job/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// readUsage reads the resource usage of the cgroup at the given path.
+func readUsage(path string) (*jobv1.ResourceUsage, error) {
+	usage := &jobv1.ResourceUsage{
+		Time: timestamppb.Now(),
+	}
+	cpuStat, err := readFlatKeyed(filepath.Join(path, "cpu.stat"))
+	if err != nil {
+		return nil, err
+	}
+	usage.Cpu = &jobv1.CpuUsage{
+		UsageUsec:     cpuStat["usage_usec"],
+		UserUsec:      cpuStat["user_usec"],
+		SystemUsec:    cpuStat["system_usec"],
+		NrPeriods:     cpuStat["nr_periods"],
+		NrThrottled:   cpuStat["nr_throttled"],
+		ThrottledUsec: cpuStat["throttled_usec"],
+	}
+
+	memoryStat, err := readFlatKeyed(filepath.Join(path, "memory.stat"))
+	if err != nil {
+		return nil, err
+	}
+	usage.Memory = &jobv1.MemoryUsage{
+		Anon:   memoryStat["anon"],
+		File:   memoryStat["file"],
+		Kernel: memoryStat["kernel"],
+		Shmem:  memoryStat["shmem"],
+		Sock:   memoryStat["sock"],
+	}
+	if usage.Memory.Current, err = readSingleValue(filepath.Join(path, "memory.current")); err != nil {
+		return nil, err
+	}
+	// memory.peak was added in linux 5.19
+	if peak, err := readSingleValue(filepath.Join(path, "memory.peak")); err == nil {
+		usage.Memory.Peak = &peak
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	if usage.Io, err = readIoStat(filepath.Join(path, "io.stat")); err != nil {
+		return nil, err
+	}
+
+	// pids.current only exists if the pids controller is enabled
+	if current, err := readSingleValue(filepath.Join(path, "pids.current")); err == nil {
+		usage.Pids = &jobv1.PidsUsage{Current: current}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	return usage, nil
+}
+
+// readPidsLimitHits returns the number of times the cgroup at the given path
+// failed to create a process because it reached pids.max.
+func readPidsLimitHits(path string) (uint64, error) {
+	events, err := readFlatKeyed(filepath.Join(path, "pids.events"))
+	if err != nil {
+		return 0, err
+	}
+	return events["max"], nil
+}
+
+// readSingleValue reads a file containing a single unsigned integer.
+func readSingleValue(file string) (uint64, error) {
+	contents, err := os.ReadFile(file)
+	if err != nil {
+		return 0, err
+	}
+	value, err := strconv.ParseUint(strings.TrimSpace(string(contents)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse %s: %w", file, err)
+	}
+	return value, nil
+}
+
+// readFlatKeyed reads a file in the flat keyed format, where each line
+// contains a key and an unsigned integer value separated by a space.
+func readFlatKeyed(file string) (map[string]uint64, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	values := make(map[string]uint64)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), " ")
+		if !ok {
+			continue
+		}
+		n, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: invalid value for %s: %w", file, key, err)
+		}
+		values[key] = n
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", file, err)
+	}
+	return values, nil
+}
+
+// readIoStat reads io.stat, which is in the nested keyed format:
+//
+//	8:16 rbytes=1459200 wbytes=314773504 rios=192 wios=353 dbytes=0 dios=0
+func readIoStat(file string) ([]*jobv1.DeviceIOUsage, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var devices []*jobv1.DeviceIOUsage
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		dev := &jobv1.DeviceIOUsage{Device: fields[0]}
+		for _, field := range fields[1:] {
+			key, value, ok := strings.Cut(field, "=")
+			if !ok {
+				continue
+			}
+			n, err := strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse %s: invalid value for %s: %w", file, key, err)
+			}
+			switch key {
+			case "rbytes":
+				dev.ReadBytes = n
+			case "wbytes":
+				dev.WriteBytes = n
+			case "rios":
+				dev.ReadIos = n
+			case "wios":
+				dev.WriteIos = n
+			case "dbytes":
+				dev.DiscardBytes = n
+			case "dios":
+				dev.DiscardIos = n
+			}
+		}
+		devices = append(devices, dev)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", file, err)
+	}
+	return devices, nil
+}
@@ -0,0 +1,164 @@
+package cgroupsv2
+
+import (
+	"os"
+	"path/filepath"
+
+	jobv1 "github.com/kralicky/jobserver/pkg/apis/job/v1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+var _ = Describe("Usage", func() {
+	// sample files from the cgroup of a running job
+	const fixture = "testdata/usage"
+
+	// copies the fixture to a temporary directory, so that files can be added
+	// or removed
+	copyFixture := func() string {
+		dir := GinkgoT().TempDir()
+		entries, err := os.ReadDir(fixture)
+		Expect(err).NotTo(HaveOccurred())
+		for _, entry := range entries {
+			contents, err := os.ReadFile(filepath.Join(fixture, entry.Name()))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(os.WriteFile(filepath.Join(dir, entry.Name()), contents, 0o644)).To(Succeed())
+		}
+		return dir
+	}
+	writeFile := func(name, contents string) string {
+		file := filepath.Join(GinkgoT().TempDir(), name)
+		Expect(os.WriteFile(file, []byte(contents), 0o644)).To(Succeed())
+		return file
+	}
+
+	Describe("readUsage", func() {
+		expectedIo := []*jobv1.DeviceIOUsage{
+			{Device: "8:16", ReadBytes: 1459200, WriteBytes: 314773504, ReadIos: 192, WriteIos: 353},
+			{Device: "253:0", ReadBytes: 4096, ReadIos: 1, DiscardBytes: 8192, DiscardIos: 2},
+		}
+
+		It("should read the usage of a cgroup without memory.peak", func() {
+			usage, err := readUsage(fixture)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(usage.GetTime()).NotTo(BeNil())
+			Expect(usage.GetCpu()).To(BeComparableTo(&jobv1.CpuUsage{
+				UsageUsec:     1523471,
+				UserUsec:      1204812,
+				SystemUsec:    318659,
+				NrPeriods:     412,
+				NrThrottled:   37,
+				ThrottledUsec: 2208034,
+			}, protocmp.Transform()))
+			Expect(usage.GetMemory()).To(BeComparableTo(&jobv1.MemoryUsage{
+				Current: 164651008,
+				Anon:    42029056,
+				File:    118784000,
+				Kernel:  3891200,
+				Shmem:   4096,
+				Sock:    8192,
+			}, protocmp.Transform()))
+			Expect(usage.GetMemory().Peak).To(BeNil())
+			Expect(usage.GetIo()).To(BeComparableTo(expectedIo, protocmp.Transform()))
+			Expect(usage.GetPids().GetCurrent()).To(BeEquivalentTo(4))
+		})
+		It("should read memory.peak if it exists", func() {
+			dir := copyFixture()
+			Expect(os.WriteFile(filepath.Join(dir, "memory.peak"), []byte("201326592\n"), 0o644)).To(Succeed())
+			usage, err := readUsage(dir)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(usage.GetMemory().Peak).NotTo(BeNil())
+			Expect(usage.GetMemory().GetPeak()).To(BeEquivalentTo(201326592))
+		})
+		It("should omit the pids usage if the pids controller is not enabled", func() {
+			dir := copyFixture()
+			Expect(os.Remove(filepath.Join(dir, "pids.current"))).To(Succeed())
+			usage, err := readUsage(dir)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(usage.GetPids()).To(BeNil())
+		})
+		It("should fail if a required file is missing", func() {
+			dir := copyFixture()
+			Expect(os.Remove(filepath.Join(dir, "memory.current"))).To(Succeed())
+			_, err := readUsage(dir)
+			Expect(err).To(MatchError(os.ErrNotExist))
+		})
+	})
+
+	Describe("readPidsLimitHits", func() {
+		It("should read the max event count from pids.events", func() {
+			Expect(readPidsLimitHits(fixture)).To(BeEquivalentTo(3))
+		})
+	})
+
+	Describe("pids limit hits in the job status", func() {
+		var proc *v2Process
+		BeforeEach(func() {
+			proc = &v2Process{cgroupPath: copyFixture(), status: &jobv1.JobStatus{}}
+		})
+
+		It("should be read from pids.events", func() {
+			Expect(proc.Status().GetPidsLimitHits()).To(BeEquivalentTo(3))
+		})
+		It("should be 0 if the pids controller is not enabled", func() {
+			Expect(os.Remove(filepath.Join(proc.cgroupPath, "pids.events"))).To(Succeed())
+			Expect(proc.Status().GetPidsLimitHits()).To(BeZero())
+		})
+		It("should be kept after the job's cgroup is released", func() {
+			proc.releaseCgroup()
+			Expect(os.RemoveAll(proc.cgroupPath)).To(Succeed())
+			Expect(proc.Status().GetPidsLimitHits()).To(BeEquivalentTo(3))
+		})
+	})
+
+	Describe("readFlatKeyed", func() {
+		It("should read every key", func() {
+			values, err := readFlatKeyed(filepath.Join(fixture, "cpu.stat"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(HaveLen(9))
+			Expect(values).To(HaveKeyWithValue("core_sched.force_idle_usec", uint64(0)))
+			Expect(values).To(HaveKeyWithValue("throttled_usec", uint64(2208034)))
+		})
+		It("should skip lines without a value", func() {
+			values, err := readFlatKeyed(writeFile("pids.events", "max 3\n\ninvalid\n"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(Equal(map[string]uint64{"max": 3}))
+		})
+		It("should fail on invalid values", func() {
+			_, err := readFlatKeyed(writeFile("cpu.stat", "usage_usec 12\nuser_usec -1\n"))
+			Expect(err).To(MatchError(ContainSubstring("invalid value for user_usec")))
+		})
+	})
+
+	Describe("readIoStat", func() {
+		It("should skip empty lines and unknown keys", func() {
+			devices, err := readIoStat(writeFile("io.stat", "\n8:0 rbytes=10 foo=1 bar\n"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(devices).To(BeComparableTo([]*jobv1.DeviceIOUsage{
+				{Device: "8:0", ReadBytes: 10},
+			}, protocmp.Transform()))
+		})
+		It("should return no devices for an empty file", func() {
+			Expect(readIoStat(writeFile("io.stat", ""))).To(BeEmpty())
+		})
+		It("should fail on invalid values", func() {
+			_, err := readIoStat(writeFile("io.stat", "8:0 rbytes=max\n"))
+			Expect(err).To(MatchError(ContainSubstring("invalid value for rbytes")))
+		})
+	})
+
+	Describe("readSingleValue", func() {
+		It("should read a single value", func() {
+			Expect(readSingleValue(filepath.Join(fixture, "memory.current"))).To(BeEquivalentTo(164651008))
+		})
+		It("should fail on values that are not numbers", func() {
+			_, err := readSingleValue(writeFile("memory.max", "max\n"))
+			Expect(err).To(MatchError(ContainSubstring("failed to parse")))
+		})
+		It("should fail if the file doesn't exist", func() {
+			_, err := readSingleValue(filepath.Join(fixture, "memory.peak"))
+			Expect(err).To(MatchError(os.ErrNotExist))
+		})
+	})
+})
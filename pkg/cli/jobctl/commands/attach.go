@@ -0,0 +1,159 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	jobv1 "github.com/kralicky/jobserver/pkg/apis/job/v1"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+func BuildJobAttachCmd() *cobra.Command {
+	var opts attachOptions
+	cmd := &cobra.Command{
+		Use:     "attach <job-id>",
+		GroupID: GroupIdClientCommands,
+		Short:   "Attach to an existing job.",
+		Long: fmt.Sprintf(`
+Streams the output of an existing job, in the same way as the 'logs' command.
+
+If the --stdin flag is set, input to this command is also forwarded to the
+job's stdin. The job must have been started with '%[1]s run --stdin'. When
+this command reaches the end of its input, the job's stdin is closed.
+
+Interrupting this command with Ctrl-C detaches from the job without closing
+its stdin.
+
+If the job was started with '%[1]s run --tty', the --tty flag puts the local
+terminal in raw mode and keeps the size of the job's terminal in sync with it.
+In this mode, all input (including Ctrl-C) is sent to the job, and this
+command exits when the job terminates.
+`[1:], os.Args[0]),
+		Example: fmt.Sprintf(`
+  Start a job that reads from stdin, then send it some input later:
+    $ %[1]s run --stdin -- cat
+    $ echo "hello" | %[1]s attach --stdin <id>
+`[1:], os.Args[0]),
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeJobIds,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, ok := jobClientFromContext(cmd.Context())
+			if !ok {
+				cmd.PrintErrln("failed to get client from context")
+				return nil
+			}
+			return attach(cmd, client, &jobv1.JobId{Id: args[0]}, opts)
+		},
+	}
+	cmd.Flags().BoolVarP(&opts.stdin, "stdin", "i", false, "forward stdin to the job")
+	cmd.Flags().BoolVarP(&opts.tty, "tty", "t", false, "attach the local terminal to the job's terminal (implies --stdin)")
+	return cmd
+}
+
+type attachOptions struct {
+	// Forward the command's stdin to the job until EOF, after which the
+	// job's stdin is closed.
+	stdin bool
+	// Put the local terminal in raw mode, and keep the size of the job's
+	// terminal in sync with it. Implies stdin.
+	tty bool
+}
+
+// attach streams the output of the job to the command's stdout, optionally
+// forwarding the command's stdin to the job.
+func attach(cmd *cobra.Command, client jobv1.JobClient, id *jobv1.JobId, opts attachOptions) error {
+	stdinFd := int(os.Stdin.Fd())
+	if opts.tty && !term.IsTerminal(stdinFd) {
+		return errors.New("--tty requires stdin to be a terminal")
+	}
+	stream, err := client.Attach(cmd.Context())
+	if err != nil {
+		return err
+	}
+	if err := stream.Send(&jobv1.AttachRequest{
+		Request: &jobv1.AttachRequest_Id{Id: id},
+	}); err != nil {
+		return err
+	}
+	if !opts.stdin && !opts.tty {
+		if err := stream.CloseSend(); err != nil {
+			return err
+		}
+		return copyOutput(cmd, stream, outputOptions{})
+	}
+
+	var resize <-chan os.Signal
+	if opts.tty {
+		state, err := term.MakeRaw(stdinFd)
+		if err != nil {
+			return fmt.Errorf("failed to put terminal in raw mode: %w", err)
+		}
+		defer term.Restore(stdinFd, state)
+
+		winch := make(chan os.Signal, 1)
+		signal.Notify(winch, syscall.SIGWINCH)
+		defer signal.Stop(winch)
+		winch <- syscall.SIGWINCH // send the initial size
+		resize = winch
+	}
+
+	input := make(chan []byte)
+	go func() {
+		defer close(input)
+		for {
+			buf := make([]byte, 32*1024)
+			n, err := cmd.InOrStdin().Read(buf)
+			if n > 0 {
+				select {
+				case input <- buf[:n]:
+				case <-stream.Context().Done():
+					return
+				}
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	// grpc streams don't support concurrent sends, so all requests are sent
+	// from this goroutine
+	go func() {
+		defer stream.CloseSend()
+		for {
+			var req *jobv1.AttachRequest
+			select {
+			case data, ok := <-input:
+				if !ok {
+					stream.Send(&jobv1.AttachRequest{
+						Request: &jobv1.AttachRequest_CloseStdin{CloseStdin: true},
+					})
+					return
+				}
+				req = &jobv1.AttachRequest{
+					Request: &jobv1.AttachRequest_Input{Input: data},
+				}
+			case <-resize:
+				cols, rows, err := term.GetSize(stdinFd)
+				if err != nil {
+					continue
+				}
+				req = &jobv1.AttachRequest{
+					Request: &jobv1.AttachRequest_Resize{
+						Resize: &jobv1.TerminalSize{Rows: uint32(rows), Cols: uint32(cols)},
+					},
+				}
+			case <-stream.Context().Done():
+				return
+			}
+			if err := stream.Send(req); err != nil {
+				return
+			}
+		}
+	}()
+	return copyOutput(cmd, stream, outputOptions{})
+}
@@ -3,8 +3,8 @@ package commands
 import (
 	"slices"
 
+	jobv1 "github.com/kralicky/jobserver/pkg/apis/job/v1"
 	"github.com/spf13/cobra"
-	"google.golang.org/protobuf/types/known/emptypb"
 )
 
 func completeJobIds(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
@@ -15,17 +15,23 @@ func completeJobIds(cmd *cobra.Command, args []string, toComplete string) ([]str
 	if !ok {
 		return nil, cobra.ShellCompDirectiveError
 	}
-	resp, err := client.List(cmd.Context(), &emptypb.Empty{})
+	jobs, err := listJobs(cmd, client, &jobv1.ListRequest{})
 	if err != nil {
 		return nil, cobra.ShellCompDirectiveError
 	}
-	ids := make([]string, 0, len(resp.GetItems()))
-	for _, job := range resp.GetItems() {
-		jobId := job.GetId()
-		if slices.Contains(args, jobId) {
+	// names can only be used to refer to the user's own jobs
+	user, _ := userFromContext(cmd.Context())
+	ids := make([]string, 0, len(jobs))
+	for _, job := range jobs {
+		jobId := job.GetId().GetId()
+		name := job.GetStatus().GetSpec().GetName()
+		if slices.Contains(args, jobId) || (name != "" && slices.Contains(args, name)) {
 			continue
 		}
 		ids = append(ids, jobId)
+		if name != "" && job.GetOwner() == user {
+			ids = append(ids, name)
+		}
 	}
 	slices.Sort(ids)
 	return ids, cobra.ShellCompDirectiveNoFileComp
@@ -8,9 +8,13 @@ import (
 
 type (
 	jobClientContextKeyType struct{}
+	userContextKeyType      struct{}
 )
 
-var jobClientContextKey jobClientContextKeyType
+var (
+	jobClientContextKey jobClientContextKeyType
+	userContextKey      userContextKeyType
+)
 
 func ContextWithJobClient(ctx context.Context, client jobv1.JobClient) context.Context {
 	return context.WithValue(ctx, jobClientContextKey, client)
@@ -20,3 +24,14 @@ func jobClientFromContext(ctx context.Context) (jobv1.JobClient, bool) {
 	client, ok := ctx.Value(jobClientContextKey).(jobv1.JobClient)
 	return client, ok
 }
+
+// ContextWithUser stores the name of the user the client authenticates as,
+// i.e. the common name of its certificate.
+func ContextWithUser(ctx context.Context, user string) context.Context {
+	return context.WithValue(ctx, userContextKey, user)
+}
+
+func userFromContext(ctx context.Context) (string, bool) {
+	user, ok := ctx.Value(userContextKey).(string)
+	return user, ok
+}
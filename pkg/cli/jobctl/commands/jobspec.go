@@ -0,0 +1,281 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	jobv1 "github.com/kralicky/jobserver/pkg/apis/job/v1"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// jobSpecFlags holds the flags shared by commands that build a job spec from
+// a command line.
+type jobSpecFlags struct {
+	env               []string
+	workdir           string
+	uid, gid          uint32
+	groups            []string
+	umask             string
+	limits            limitFlags
+	retainOutput      string
+	stopSignal        string
+	stopTimeout       time.Duration
+	timeout           time.Duration
+	restart           string
+	maxRetries        uint32
+	restartBackoff    time.Duration
+	restartMaxBackoff time.Duration
+	labels            []string
+}
+
+func (f *jobSpecFlags) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVarP(&f.env, "env", "e", nil,
+		"environment variables                       (ex: 'FOO=bar' or 'BAZ=qux')")
+	cmd.Flags().StringVarP(&f.workdir, "workdir", "w", "", "working directory for the command (default is the server's working directory)")
+	cmd.Flags().Uint32Var(&f.uid, "uid", 0, "user id to run the command as (default is the server's user)")
+	cmd.Flags().Uint32Var(&f.gid, "gid", 0, "primary group id to run the command as (required with --uid)")
+	cmd.Flags().StringSliceVar(&f.groups, "groups", nil,
+		"supplementary group ids for the command     (ex: '100' or '100,1001')")
+	cmd.Flags().StringVar(&f.umask, "umask", "",
+		"file mode creation mask, in octal           (ex: '022' or '077')")
+	f.limits.addFlags(cmd)
+	cmd.Flags().StringVar(&f.retainOutput, "retain-output", "",
+		"only keep the most recent output in memory  (ex: '1Mi' or '512k')")
+	cmd.Flags().StringVar(&f.stopSignal, "stop-signal", "",
+		"signal used to stop the job                 (ex: 'INT' or 'SIGQUIT')")
+	cmd.Flags().DurationVar(&f.stopTimeout, "stop-timeout", 0,
+		"time to wait before killing a stopped job   (ex: '30s' or '1m')")
+	cmd.Flags().DurationVar(&f.timeout, "timeout", 0,
+		"stop the job if it runs longer than this    (ex: '30m' or '2h')")
+	cmd.Flags().StringVar(&f.restart, "restart", "",
+		"when to restart the job after it exits      (ex: 'never' or 'on-failure' or 'always')")
+	cmd.Flags().Uint32Var(&f.maxRetries, "max-retries", 0,
+		"maximum number of restarts (default no limit)")
+	cmd.Flags().DurationVar(&f.restartBackoff, "restart-backoff", 0,
+		"delay before the first restart (default 1s) (ex: '500ms' or '10s')")
+	cmd.Flags().DurationVar(&f.restartMaxBackoff, "restart-max-backoff", 0,
+		"maximum delay between restarts (default 5m) (ex: '30s' or '1h')")
+	cmd.Flags().StringSliceVarP(&f.labels, "label", "l", nil,
+		"labels to attach to the job                 (ex: 'team=infra' or 'env=prod,tier=web')")
+	cmd.RegisterFlagCompletionFunc("restart", cobra.FixedCompletions([]string{"never", "on-failure", "always"}, cobra.ShellCompDirectiveNoFileComp))
+	cmd.RegisterFlagCompletionFunc("stop-signal", completeSignals)
+	cmd.MarkFlagsRequiredTogether("uid", "gid")
+	cmd.MarkFlagDirname("workdir")
+}
+
+// buildSpec builds a job spec from the flags, running the command given by
+// the positional args.
+func (f *jobSpecFlags) buildSpec(cmd *cobra.Command, args []string) (*jobv1.JobSpec, error) {
+	cmdSpec := &jobv1.CommandSpec{
+		Command: args[0],
+		Env:     f.env,
+		Workdir: f.workdir,
+	}
+	if cmd.Flags().Changed("uid") {
+		cmdSpec.Credential = &jobv1.Credential{
+			Uid: f.uid,
+			Gid: f.gid,
+		}
+		for _, g := range f.groups {
+			n, err := strconv.ParseUint(g, 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value for --groups: %w", err)
+			}
+			cmdSpec.Credential.Groups = append(cmdSpec.Credential.Groups, uint32(n))
+		}
+	} else if len(f.groups) > 0 {
+		return nil, fmt.Errorf("--groups requires --uid and --gid")
+	}
+	if f.umask != "" {
+		mask, err := strconv.ParseUint(f.umask, 8, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for --umask: %w", err)
+		}
+		cmdSpec.Umask = proto.Uint32(uint32(mask))
+	}
+	if len(args) > 1 {
+		cmdSpec.Args = args[1:]
+	}
+	limits, err := f.limits.buildLimits(cmd)
+	if err != nil {
+		return nil, err
+	}
+	var output *jobv1.OutputSpec
+	if f.retainOutput != "" {
+		retainBytes, err := parseMemoryLimit(f.retainOutput)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for output retention limit: %w", err)
+		}
+		output = &jobv1.OutputSpec{RetainBytes: &retainBytes}
+	}
+	var stop *jobv1.StopPolicy
+	if f.stopSignal != "" || cmd.Flags().Changed("stop-timeout") {
+		stop = &jobv1.StopPolicy{}
+		if f.stopSignal != "" {
+			sig, err := parseSignal(f.stopSignal)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value for --stop-signal: %w", err)
+			}
+			stop.Signal = sig
+		}
+		if cmd.Flags().Changed("stop-timeout") {
+			stop.GracePeriod = durationpb.New(f.stopTimeout)
+		}
+	}
+	spec := &jobv1.JobSpec{
+		Command: cmdSpec,
+		Limits:  limits,
+		Output:  output,
+		Stop:    stop,
+	}
+	if cmd.Flags().Changed("timeout") {
+		spec.MaxRuntime = &jobv1.JobSpec_Timeout{Timeout: durationpb.New(f.timeout)}
+	}
+	if f.restart != "" {
+		mode, ok := jobv1.RestartMode_value[strings.ToUpper(strings.ReplaceAll(f.restart, "-", "_"))]
+		if !ok {
+			return nil, fmt.Errorf("invalid value for --restart: %q (expecting never, on-failure, or always)", f.restart)
+		}
+		spec.Restart = &jobv1.RestartPolicy{Mode: jobv1.RestartMode(mode)}
+	}
+	if cmd.Flags().Changed("max-retries") || cmd.Flags().Changed("restart-backoff") || cmd.Flags().Changed("restart-max-backoff") {
+		if spec.GetRestart().GetMode() == jobv1.RestartMode_NEVER {
+			return nil, fmt.Errorf("--max-retries, --restart-backoff, and --restart-max-backoff require --restart")
+		}
+		if cmd.Flags().Changed("max-retries") {
+			spec.Restart.MaxRetries = &f.maxRetries
+		}
+		if cmd.Flags().Changed("restart-backoff") {
+			spec.Restart.InitialBackoff = durationpb.New(f.restartBackoff)
+		}
+		if cmd.Flags().Changed("restart-max-backoff") {
+			spec.Restart.MaxBackoff = durationpb.New(f.restartMaxBackoff)
+		}
+	}
+	if len(f.labels) > 0 {
+		spec.Labels = make(map[string]string, len(f.labels))
+		for _, l := range f.labels {
+			key, value, ok := strings.Cut(l, "=")
+			if !ok {
+				return nil, fmt.Errorf("invalid value for --label: %q (expecting key=value)", l)
+			}
+			spec.Labels[key] = value
+		}
+	}
+	return spec, nil
+}
+
+// limitFlags holds the flags that set a job's resource limits.
+type limitFlags struct {
+	cpus            string
+	cpuWeight       uint32
+	cpuPeriod       time.Duration
+	cpusetCpus      string
+	cpusetMems      string
+	pids            int64
+	memory          string
+	memorySoftLimit string
+	memorySwap      string
+	memoryLow       string
+	memoryMin       string
+	memoryOomGroup  bool
+	deviceReadBps   []string
+	deviceWriteBps  []string
+	deviceReadIops  []string
+	deviceWriteIops []string
+}
+
+func (f *limitFlags) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&f.cpus, "cpus", "c", "",
+		"number of CPUs to allocate to the job       (ex: '4' '100m')")
+	cmd.Flags().Uint32Var(&f.cpuWeight, "cpu-weight", 0,
+		"relative share of CPU time, from 1 to 10000 (ex: '100' or '500')")
+	cmd.Flags().DurationVar(&f.cpuPeriod, "cpu-period", 0,
+		"period over which --cpus is enforced        (ex: '10ms' or '100ms')")
+	cmd.Flags().StringVar(&f.cpusetCpus, "cpuset-cpus", "",
+		"CPUs the job is allowed to run on           (ex: '0-3' or '0,2')")
+	cmd.Flags().StringVar(&f.cpusetMems, "cpuset-mems", "",
+		"memory nodes the job is allowed to use      (ex: '0' or '0-1')")
+	cmd.Flags().Int64Var(&f.pids, "pids", 0,
+		"maximum number of processes in the job      (ex: '100' or '1000')")
+	cmd.Flags().StringVarP(&f.memory, "memory", "m", "",
+		"amount of memory to allocate to the job     (ex: '100Mi' or '256k' or '4G')")
+	cmd.Flags().StringVar(&f.memorySoftLimit, "memory-soft-limit", "",
+		"soft limit for memory usage                 (ex: '100Mi' or '256k' or '4G')")
+	cmd.Flags().StringVar(&f.memorySwap, "memory-swap", "",
+		"amount of swap the job can use (0 for none) (ex: '0' or '1Gi')")
+	cmd.Flags().StringVar(&f.memoryLow, "memory-low", "",
+		"memory protected from reclaim if possible   (ex: '100Mi' or '1G')")
+	cmd.Flags().StringVar(&f.memoryMin, "memory-min", "",
+		"memory that is never reclaimed from the job (ex: '100Mi' or '1G')")
+	cmd.Flags().BoolVar(&f.memoryOomGroup, "memory-oom-group", false,
+		"kill all of the job's processes together if any of them is OOM-killed")
+	cmd.Flags().StringSliceVar(&f.deviceReadBps, "device-read-bps", nil,
+		"device read bandwidth limits (id|path=bps)  (ex: '8:16=2097152' or '/dev/sda=2097152')")
+	cmd.Flags().StringSliceVar(&f.deviceWriteBps, "device-write-bps", nil,
+		"device write bandwidth limits (id|path=bps) (ex: '8:16=2097152' or '/dev/sda=2097152')")
+	cmd.Flags().StringSliceVar(&f.deviceReadIops, "device-read-iops", nil,
+		"device read IOPS limits (id|path=iops)      (ex: '8:16=200' or '/dev/sda=200')")
+	cmd.Flags().StringSliceVar(&f.deviceWriteIops, "device-write-iops", nil,
+		"device write IOPS limits (id|path=iops)     (ex: '8:16=200' or '/dev/sda=200')")
+}
+
+// buildLimits builds resource limits from the flags. Only the limits given by
+// flags are set.
+func (f *limitFlags) buildLimits(cmd *cobra.Command) (*jobv1.ResourceLimits, error) {
+	limits := &jobv1.ResourceLimits{}
+	if f.cpus != "" {
+		mcpus, err := parseCpuLimits(f.cpus)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for cpu limit: %w", err)
+		}
+		limits.Cpu = &mcpus
+	}
+	if f.cpuWeight != 0 {
+		limits.CpuWeight = &f.cpuWeight
+	}
+	if f.cpuPeriod != 0 {
+		limits.CpuPeriod = durationpb.New(f.cpuPeriod)
+	}
+	if f.pids != 0 {
+		limits.Pids = &f.pids
+	}
+	if f.cpusetCpus != "" || f.cpusetMems != "" {
+		limits.Cpuset = &jobv1.CpusetLimits{
+			Cpus: f.cpusetCpus,
+			Mems: f.cpusetMems,
+		}
+	}
+	if f.memorySoftLimit != "" || f.memory != "" || f.memorySwap != "" || f.memoryLow != "" || f.memoryMin != "" ||
+		cmd.Flags().Changed("memory-oom-group") {
+		mem, err := parseMemoryLimits(f.memorySoftLimit, f.memory)
+		if err != nil {
+			return nil, err
+		}
+		if mem.SwapLimit, err = parseOptionalMemoryLimit("swap limit", f.memorySwap); err != nil {
+			return nil, err
+		}
+		if mem.Low, err = parseOptionalMemoryLimit("memory low", f.memoryLow); err != nil {
+			return nil, err
+		}
+		if mem.Min, err = parseOptionalMemoryLimit("memory min", f.memoryMin); err != nil {
+			return nil, err
+		}
+		if cmd.Flags().Changed("memory-oom-group") {
+			mem.OomGroup = &f.memoryOomGroup
+		}
+		limits.Memory = mem
+	}
+	if len(f.deviceReadBps) > 0 || len(f.deviceWriteBps) > 0 || len(f.deviceReadIops) > 0 || len(f.deviceWriteIops) > 0 {
+		devices, err := parseIoLimits(f.deviceReadBps, f.deviceWriteBps, f.deviceReadIops, f.deviceWriteIops)
+		if err != nil {
+			return nil, err
+		}
+		limits.Io = devices
+	}
+	return limits, nil
+}
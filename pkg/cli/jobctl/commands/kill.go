@@ -0,0 +1,87 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	jobv1 "github.com/kralicky/jobserver/pkg/apis/job/v1"
+	"github.com/spf13/cobra"
+	"golang.org/x/sys/unix"
+)
+
+func BuildJobKillCmd() *cobra.Command {
+	var signal string
+	var all bool
+	cmd := &cobra.Command{
+		Use:     "kill [-s <signal>] <job-id>",
+		GroupID: GroupIdClientCommands,
+		Short:   "Send a signal to a running job.",
+		Long: fmt.Sprintf(`
+Sends a signal to a running job. The signal is sent to the job's main process,
+or with --all, to every process in the job.
+
+Unlike '%[1]s stop', this command does not wait for the job to handle the
+signal. The signals sent to a job are shown in its status.
+`[1:], os.Args[0]),
+		Example: fmt.Sprintf(`
+  Ask a job to reload its configuration:
+    $ %[1]s kill -s HUP <id>
+
+  Interrupt every process in a job:
+    $ %[1]s kill -s SIGINT --all <id>
+`[1:], os.Args[0]),
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeJobIds,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, ok := jobClientFromContext(cmd.Context())
+			if !ok {
+				cmd.PrintErrln("failed to get client from context")
+				return nil
+			}
+			sig, err := parseSignal(signal)
+			if err != nil {
+				return err
+			}
+			_, err = client.Signal(cmd.Context(), &jobv1.SignalRequest{
+				Id:           &jobv1.JobId{Id: args[0]},
+				Signal:       sig,
+				AllProcesses: all,
+			})
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), args[0])
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&signal, "signal", "s", "TERM", "the signal to send, by name or number (ex: 'HUP' or 'SIGUSR1' or '9')")
+	cmd.Flags().BoolVar(&all, "all", false, "send the signal to every process in the job")
+	cmd.RegisterFlagCompletionFunc("signal", completeSignals)
+	return cmd
+}
+
+var completeSignals = cobra.FixedCompletions([]string{
+	"SIGHUP", "SIGINT", "SIGQUIT", "SIGKILL", "SIGUSR1", "SIGUSR2", "SIGTERM", "SIGCONT", "SIGSTOP", "SIGWINCH",
+}, cobra.ShellCompDirectiveNoFileComp)
+
+// parseSignal parses a signal given by name (with or without the 'SIG'
+// prefix, in any case) or by number.
+func parseSignal(signal string) (int32, error) {
+	if n, err := strconv.ParseInt(signal, 10, 32); err == nil {
+		if n <= 0 {
+			return 0, fmt.Errorf("invalid signal number: %d", n)
+		}
+		return int32(n), nil
+	}
+	name := strings.ToUpper(signal)
+	if !strings.HasPrefix(name, "SIG") {
+		name = "SIG" + name
+	}
+	sig := unix.SignalNum(name)
+	if sig == 0 {
+		return 0, fmt.Errorf("unknown signal: %s", signal)
+	}
+	return int32(sig), nil
+}
@@ -0,0 +1,32 @@
+package commands
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("parseSignal", func() {
+	DescribeTable("valid signals",
+		func(signal string, expected int32) {
+			Expect(parseSignal(signal)).To(Equal(expected))
+		},
+		Entry("number", "9", int32(9)),
+		Entry("name", "TERM", int32(15)),
+		Entry("name with prefix", "SIGKILL", int32(9)),
+		Entry("lowercase name", "usr1", int32(10)),
+		Entry("lowercase name with prefix", "sighup", int32(1)),
+		Entry("number above the standard signals", "40", int32(40)),
+	)
+	DescribeTable("invalid signals",
+		func(signal string, expectedErr string) {
+			_, err := parseSignal(signal)
+			Expect(err).To(MatchError(expectedErr))
+		},
+		Entry("zero", "0", "invalid signal number: 0"),
+		Entry("negative number", "-9", "invalid signal number: -9"),
+		Entry("number out of range", "4294967296", "unknown signal: 4294967296"),
+		Entry("unknown name", "foo", "unknown signal: foo"),
+		Entry("empty", "", "unknown signal: "),
+		Entry("prefix only", "SIG", "unknown signal: SIG"),
+	)
+})
@@ -1,49 +1,129 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"os"
 	"slices"
+	"strings"
 	"time"
 
 	"github.com/jedib0t/go-pretty/v6/table"
+	jobv1 "github.com/kralicky/jobserver/pkg/apis/job/v1"
 	"github.com/spf13/cobra"
-	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func BuildJobListCmd() *cobra.Command {
+	var watch bool
+	var states []string
+	var owner string
+	var command string
+	var startedAfter string
+	var startedBefore string
+	var schedule string
+	var selector string
+	var labelColumns []string
 	cmd := &cobra.Command{
 		Use:     "list",
 		GroupID: GroupIdClientCommands,
 		Short:   "Show all existing jobs.",
-		Args:    cobra.NoArgs,
+		Long: `
+Shows all existing jobs.
+
+The list can be narrowed down using the filter flags; only jobs matching all
+of the given filters are shown. Times given to --started-after and
+--started-before can be either RFC3339 timestamps, or durations relative to
+the current time (e.g. '1h' for one hour ago).
+
+The --selector flag takes a comma-separated list of label requirements, all of
+which must match: 'key=value', 'key!=value', 'key' (the label is set), or
+'!key' (the label is not set). With --label-columns, the values of the given
+labels are shown as additional columns.
+
+With --watch, a line is printed for each existing job, followed by a new line
+each time the state of any job changes, until the command is interrupted with
+Ctrl-C. Filters do not apply when watching.
+`[1:],
+		Example: fmt.Sprintf(`
+  Show all running jobs started by user1 in the last 2 hours:
+    $ %[1]s list --state=running --owner=user1 --started-after=2h
+
+  Show all jobs for the infra team outside of production, with their 'env' label:
+    $ %[1]s list -l 'team=infra,env!=prod' -L env
+`[1:], os.Args[0]),
+		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client, ok := jobClientFromContext(cmd.Context())
 			if !ok {
 				cmd.PrintErrln("failed to get client from context")
 				return nil
 			}
-			list, err := client.List(cmd.Context(), &emptypb.Empty{})
+			if watch {
+				return watchJobs(cmd, client)
+			}
+			req := &jobv1.ListRequest{}
+			for _, s := range states {
+				state, ok := jobv1.State_value[strings.ToUpper(s)]
+				if !ok {
+					return fmt.Errorf("invalid state %q", s)
+				}
+				req.States = append(req.States, jobv1.State(state))
+			}
+			if cmd.Flags().Changed("owner") {
+				req.Owner = &owner
+			}
+			if cmd.Flags().Changed("command") {
+				req.Command = &command
+			}
+			if cmd.Flags().Changed("schedule") {
+				req.ScheduleId = &schedule
+			}
+			req.LabelSelector = selector
+			if startedAfter != "" {
+				t, err := parseTimeFlag(startedAfter)
+				if err != nil {
+					return fmt.Errorf("invalid value for --started-after: %w", err)
+				}
+				req.StartedAfter = timestamppb.New(t)
+			}
+			if startedBefore != "" {
+				t, err := parseTimeFlag(startedBefore)
+				if err != nil {
+					return fmt.Errorf("invalid value for --started-before: %w", err)
+				}
+				req.StartedBefore = timestamppb.New(t)
+			}
+
+			jobs, err := listJobs(cmd, client, req)
 			if err != nil {
 				return err
 			}
 			tab := table.NewWriter()
-			tab.AppendHeader(table.Row{"JOB ID", "COMMAND", "CREATED", "STATUS"})
-			rows := make([]table.Row, 0, len(list.Items))
-			for _, id := range list.Items {
-				stat, err := client.Status(cmd.Context(), id)
-				if err != nil {
-					fmt.Fprintf(cmd.ErrOrStderr(), "error obtaining status for %s: %s\n", id.Id, err.Error())
-					continue
-				}
-				rows = append(rows, table.Row{
-					id.GetId(),
+			header := table.Row{"JOB ID", "NAME", "OWNER", "COMMAND", "CREATED", "STATUS"}
+			for _, key := range labelColumns {
+				header = append(header, strings.ToUpper(key))
+			}
+			tab.AppendHeader(header)
+			rows := make([]table.Row, 0, len(jobs))
+			for _, job := range jobs {
+				stat := job.GetStatus()
+				row := table.Row{
+					job.GetId().GetId(),
+					stat.GetSpec().GetName(),
+					job.GetOwner(),
 					stat.GetSpec().GetCommand().GetCommand(),
 					stat.GetStartTime().AsTime(),
 					stat.GetMessage(),
-				})
+				}
+				for _, key := range labelColumns {
+					row = append(row, stat.GetSpec().GetLabels()[key])
+				}
+				rows = append(rows, row)
 			}
 			slices.SortFunc(rows, func(a, b table.Row) int {
-				return a[2].(time.Time).Compare(b[2].(time.Time))
+				return a[4].(time.Time).Compare(b[4].(time.Time))
 			})
 			tab.AppendRows(rows)
 			fmt.Fprintln(cmd.OutOrStdout(), tab.Render())
@@ -51,5 +131,72 @@ func BuildJobListCmd() *cobra.Command {
 		},
 	}
 
+	cmd.Flags().BoolVarP(&watch, "watch", "w", false, "watch for changes to the state of all jobs")
+	cmd.Flags().StringSliceVar(&states, "state", nil, "only show jobs in the given state(s) (pending|failed|running|terminated|restarting)")
+	cmd.Flags().StringVar(&owner, "owner", "", "only show jobs started by the given user")
+	cmd.Flags().StringVar(&command, "command", "", "only show jobs whose command line contains the given string")
+	cmd.Flags().StringVar(&startedAfter, "started-after", "", "only show jobs started at or after the given time (ex: '2006-01-02T15:04:05Z' or '1h')")
+	cmd.Flags().StringVar(&startedBefore, "started-before", "", "only show jobs started before the given time (ex: '2006-01-02T15:04:05Z' or '1h')")
+	cmd.Flags().StringVar(&schedule, "schedule", "", "only show jobs started by the schedule with the given id")
+	cmd.Flags().StringVarP(&selector, "selector", "l", "", "only show jobs whose labels match the selector (ex: 'team=infra,env!=prod')")
+	cmd.Flags().StringSliceVarP(&labelColumns, "label-columns", "L", nil, "show the values of the given labels as columns (ex: 'team' or 'team,env')")
+	cmd.RegisterFlagCompletionFunc("schedule", completeScheduleIds)
+	cmd.RegisterFlagCompletionFunc("state", cobra.FixedCompletions([]string{"pending", "failed", "running", "terminated", "restarting"}, cobra.ShellCompDirectiveNoFileComp))
 	return cmd
 }
+
+// listJobs returns all jobs matching the request, following pagination until
+// all pages have been received.
+func listJobs(cmd *cobra.Command, client jobv1.JobClient, req *jobv1.ListRequest) ([]*jobv1.JobInfo, error) {
+	var jobs []*jobv1.JobInfo
+	for {
+		resp, err := client.List(cmd.Context(), req)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, resp.GetItems()...)
+		if resp.GetNextPageToken() == "" {
+			return jobs, nil
+		}
+		req.PageToken = resp.GetNextPageToken()
+	}
+}
+
+// parseTimeFlag parses either an RFC3339 timestamp, or a duration which is
+// interpreted relative to the current time (in the past).
+func parseTimeFlag(value string) (time.Time, error) {
+	if d, err := time.ParseDuration(value); err == nil {
+		return time.Now().Add(-d), nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+const watchLineFormat = "%-32s  %-16s  %-25s  %s\n"
+
+func watchJobs(cmd *cobra.Command, client jobv1.JobClient) error {
+	stream, err := client.Watch(cmd.Context(), &jobv1.WatchRequest{})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), watchLineFormat, "JOB ID", "COMMAND", "CREATED", "STATUS")
+	for {
+		event, err := stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		stat := event.GetStatus()
+		var created string
+		if stat.GetStartTime() != nil {
+			created = stat.GetStartTime().AsTime().Format(time.RFC3339)
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), watchLineFormat,
+			event.GetId().GetId(),
+			stat.GetSpec().GetCommand().GetCommand(),
+			created,
+			stat.GetMessage(),
+		)
+	}
+}
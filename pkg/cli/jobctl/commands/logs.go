@@ -1,27 +1,60 @@
 package commands
 
 import (
+	"bytes"
 	"errors"
+	"fmt"
 	"io"
+	"os"
+	"time"
 
 	jobv1 "github.com/kralicky/jobserver/pkg/apis/job/v1"
 	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func BuildJobLogsCmd() *cobra.Command {
+	var timestamps bool
+	var stdoutOnly bool
+	var stderrOnly bool
+	var tail int64
+	var since string
+	var fromOffset int64
+	var attempt uint32
 	cmd := &cobra.Command{
 		Use:     "logs <job-id>",
 		GroupID: GroupIdClientCommands,
 		Short:   "Stream the output of an existing job.",
 		Long: `
-Streams the output of the combined stdout and stderr of an existing job.
+Streams the stdout and stderr of an existing job. The job's stdout is written
+to stdout, and its stderr is written to stderr.
 
 If the job is still running, this will continue to stream the output in real
 time until either the job terminates, or the command is interrupted with Ctrl-C.
 
 If the job has already terminated, its full output will be written and the
 command will exit.
+
+If the server only retains a limited amount of output for the job, any output
+that was discarded will be replaced by a marker (written to stderr) indicating
+how many bytes were dropped.
+
+By default, the output starts from the beginning. To skip older output, use
+--tail to start with the last few lines of output, --since to start with
+output written after a given time, or --from-offset to start at a given byte
+offset (counting the output of both stdout and stderr).
+
+If the job has been restarted according to its restart policy, each attempt
+has its own output. The output of the latest attempt is shown by default; use
+--attempt to show the output of a previous attempt.
 `[1:],
+		Example: fmt.Sprintf(`
+  Show the last 10 lines of output, then follow new output:
+    $ %[1]s logs --tail=10 <id>
+
+  Show output from the last 5 minutes, with timestamps:
+    $ %[1]s logs --since=5m --timestamps <id>
+`[1:], os.Args[0]),
 		Args:              cobra.ExactArgs(1),
 		ValidArgsFunction: completeJobIds,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -30,21 +63,97 @@ command will exit.
 				cmd.PrintErrln("failed to get client from context")
 				return nil
 			}
-			stream, err := client.Output(cmd.Context(), &jobv1.JobId{Id: args[0]})
-			if err != nil {
-				return err
+			req := &jobv1.OutputRequest{
+				Id:     &jobv1.JobId{Id: args[0]},
+				Offset: fromOffset,
 			}
-			for {
-				resp, err := stream.Recv()
+			if cmd.Flags().Changed("attempt") {
+				req.Attempt = &attempt
+			}
+			if tail >= 0 {
+				req.TailLines = &tail
+			}
+			if since != "" {
+				t, err := parseTimeFlag(since)
 				if err != nil {
-					if errors.Is(err, io.EOF) {
-						return nil
-					}
-					return err
+					return fmt.Errorf("invalid value for --since: %w", err)
 				}
-				cmd.OutOrStdout().Write(resp.GetOutput())
+				req.Since = timestamppb.New(t)
+			}
+			switch {
+			case stdoutOnly:
+				req.Streams = []jobv1.Stream{jobv1.Stream_STDOUT}
+			case stderrOnly:
+				req.Streams = []jobv1.Stream{jobv1.Stream_STDERR}
+			}
+			stream, err := client.Output(cmd.Context(), req)
+			if err != nil {
+				return err
 			}
+			return copyOutput(cmd, stream, outputOptions{timestamps: timestamps})
 		},
 	}
+	cmd.Flags().BoolVarP(&timestamps, "timestamps", "t", false, "prefix each line of output with the time it was written")
+	cmd.Flags().BoolVar(&stdoutOnly, "stdout-only", false, "only show the job's stdout")
+	cmd.Flags().BoolVar(&stderrOnly, "stderr-only", false, "only show the job's stderr")
+	cmd.Flags().Int64Var(&tail, "tail", -1, "start with the last N lines of output (-1 for all output)")
+	cmd.Flags().StringVar(&since, "since", "", "only show output written at or after the given time (ex: '2006-01-02T15:04:05Z' or '1h')")
+	cmd.Flags().Int64Var(&fromOffset, "from-offset", 0, "start at the given byte offset in the job's output")
+	cmd.Flags().Uint32Var(&attempt, "attempt", 0, "show the output of the given attempt of a restarted job (the first is 0)")
+	cmd.MarkFlagsMutuallyExclusive("stdout-only", "stderr-only")
 	return cmd
 }
+
+// outputStream is implemented by the client streams of both the Output and
+// Attach methods.
+type outputStream interface {
+	Recv() (*jobv1.ProcessOutput, error)
+}
+
+type outputOptions struct {
+	// Prefix each line of output with the time it was written.
+	timestamps bool
+}
+
+// copyOutput writes all output received from the stream to the command's
+// stdout or stderr (according to the stream it was written to) until the
+// stream is closed. If the server reports that any output was discarded, a
+// marker is written to stderr in its place.
+func copyOutput(cmd *cobra.Command, stream outputStream, opts outputOptions) error {
+	// whether the last output written to each stream ended mid-line
+	midLine := map[jobv1.Stream]bool{}
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if dropped := resp.GetDroppedBytes(); dropped > 0 {
+			fmt.Fprintf(cmd.ErrOrStderr(), "[... %d bytes dropped ...]\n", dropped)
+		}
+		out := cmd.OutOrStdout()
+		if resp.GetStream() == jobv1.Stream_STDERR {
+			out = cmd.ErrOrStderr()
+		}
+		data := resp.GetOutput()
+		if !opts.timestamps {
+			out.Write(data)
+			continue
+		}
+		prefix := resp.GetTime().AsTime().Local().Format(time.RFC3339Nano) + " "
+		for len(data) > 0 {
+			line := data
+			if i := bytes.IndexByte(data, '\n'); i >= 0 {
+				line = data[:i+1]
+			}
+			data = data[len(line):]
+			if !midLine[resp.GetStream()] {
+				io.WriteString(out, prefix)
+			}
+			out.Write(line)
+			midLine[resp.GetStream()] = line[len(line)-1] != '\n'
+		}
+	}
+}
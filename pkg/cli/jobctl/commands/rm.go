@@ -0,0 +1,41 @@
+package commands
+
+import (
+	"fmt"
+
+	jobv1 "github.com/kralicky/jobserver/pkg/apis/job/v1"
+	"github.com/spf13/cobra"
+)
+
+func BuildJobRmCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "rm <job-id> [<job-id>...]",
+		Aliases: []string{"delete"},
+		GroupID: GroupIdClientCommands,
+		Short:   "Delete one or more completed jobs.",
+		Long: `
+Deletes completed jobs, along with their status and output.
+
+Jobs that are still running cannot be deleted; use the 'stop' command to stop
+them first.
+`[1:],
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: completeJobIds,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, ok := jobClientFromContext(cmd.Context())
+			if !ok {
+				cmd.PrintErrln("failed to get client from context")
+				return nil
+			}
+			for _, id := range args {
+				_, err := client.Delete(cmd.Context(), &jobv1.JobId{Id: id})
+				if err != nil {
+					return err
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), id)
+			}
+			return nil
+		},
+	}
+	return cmd
+}
@@ -2,30 +2,28 @@ package commands
 
 import (
 	"cmp"
-	"errors"
 	"fmt"
-	"io"
 	"os"
 	"path/filepath"
 	"slices"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/google/uuid"
 	jobv1 "github.com/kralicky/jobserver/pkg/apis/job/v1"
 	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func BuildJobRunCmd() *cobra.Command {
-	var env []string
-	var workdir string
-	var cpus string
-	var memory string
-	var memorySoftLimit string
-	var deviceReadBps []string
-	var deviceWriteBps []string
-	var deviceReadIops []string
-	var deviceWriteIops []string
+	var specFlags jobSpecFlags
 	var follow bool
+	var stdin bool
+	var tty bool
+	var name string
+	var idempotencyKey string
 
 	cmd := &cobra.Command{
 		Use:     "run [flags] -- <command> [args...]",
@@ -40,6 +38,35 @@ to complete.
 
 To check the status of the job, use the command '%[1]s status <id>'.
 To stream the output of the job, use the command '%[1]s logs <id>'.
+
+With --stdin, the job is started with its stdin open, and this command attaches
+to the job: input to this command is forwarded to the job, and the job's output
+is streamed until it terminates. Once the input reaches EOF, the job's stdin is
+closed.
+
+With --tty, the job is run with a pseudo-terminal as its stdin, stdout, and
+stderr, and the local terminal is put in raw mode while attached to the job.
+The size of the job's terminal follows the size of the local terminal.
+
+With --timeout, the job is stopped if it is still running once the timeout has
+elapsed, in the same way as '%[1]s stop'. Its termination status will show
+that it exceeded its deadline.
+
+With --restart, the job is restarted after it exits: 'on-failure' restarts it
+only if it fails to start, exits with a non-zero exit code, or is killed by a
+signal, and 'always' restarts it regardless. The delay between restarts starts
+at --restart-backoff and doubles after each restart, up to
+--restart-max-backoff. Jobs that are stopped, or that exceed their timeout, are
+not restarted.
+
+With --name, the job is given a name which can be used instead of its ID in
+other commands. Names must be unique among the jobs started by the same user.
+
+If the server cannot be reached, starting the job is retried a few times. Each
+request carries an idempotency key, so that a retry never starts the job twice
+if the server did receive an earlier request. A random key is generated unless
+one is given with --idempotency-key; running this command again with the same
+key prints the ID of the existing job instead of starting a new one.
 `[1:], os.Args[0]),
 		Example: fmt.Sprintf(`
   Commands that don't require flag args can be passed as-is:
@@ -63,84 +90,69 @@ To stream the output of the job, use the command '%[1]s logs <id>'.
 				cmd.PrintErrln("failed to get client from context")
 				return nil
 			}
-			cmdSpec := &jobv1.CommandSpec{
-				Command: args[0],
-				Env:     env,
-			}
-			if len(args) > 1 {
-				cmdSpec.Args = args[1:]
-			}
-			limits := &jobv1.ResourceLimits{}
-			if cpus != "" {
-				mcpus, err := parseCpuLimits(cpus)
-				if err != nil {
-					return fmt.Errorf("invalid value for cpu limit: %w", err)
-				}
-				limits.Cpu = &mcpus
+			spec, err := specFlags.buildSpec(cmd, args)
+			if err != nil {
+				return err
 			}
-			if memorySoftLimit != "" || memory != "" {
-				mem, err := parseMemoryLimits(memorySoftLimit, memory)
-				if err != nil {
-					return err
-				}
-				limits.Memory = mem
+			spec.Command.Stdin = stdin
+			spec.Command.Tty = tty
+			spec.Name = name
+			spec.IdempotencyKey = idempotencyKey
+			if spec.IdempotencyKey == "" {
+				spec.IdempotencyKey = uuid.NewString()
 			}
-			if len(deviceReadBps) > 0 || len(deviceWriteBps) > 0 || len(deviceReadIops) > 0 || len(deviceWriteIops) > 0 {
-				devices, err := parseIoLimits(deviceReadBps, deviceWriteBps, deviceReadIops, deviceWriteIops)
-				if err != nil {
-					return err
-				}
-				limits.Io = devices
-			}
-			id, err := client.Start(cmd.Context(), &jobv1.JobSpec{
-				Command: cmdSpec,
-				Limits:  limits,
-			})
+			id, err := startWithRetry(cmd, client, spec)
 			if err != nil {
 				return err
 			}
+			if stdin || tty {
+				return attach(cmd, client, id, attachOptions{stdin: stdin, tty: tty})
+			}
 			if follow {
-				stream, err := client.Output(cmd.Context(), id)
+				stream, err := client.Output(cmd.Context(), &jobv1.OutputRequest{Id: id})
 				if err != nil {
 					return err
 				}
-				for {
-					resp, err := stream.Recv()
-					if err != nil {
-						if errors.Is(err, io.EOF) {
-							return nil
-						}
-						return err
-					}
-					cmd.OutOrStdout().Write(resp.GetOutput())
-				}
+				return copyOutput(cmd, stream, outputOptions{})
 			} else {
 				fmt.Fprintln(cmd.OutOrStdout(), id.Id)
 			}
 			return nil
 		},
 	}
-	cmd.Flags().StringSliceVarP(&env, "env", "e", nil,
-		"environment variables                       (ex: 'FOO=bar' or 'BAZ=qux')")
-	cmd.Flags().StringVarP(&workdir, "workdir", "w", "", "working directory for the command (default is the server's working directory)")
-	cmd.Flags().StringVarP(&cpus, "cpus", "c", "",
-		"number of CPUs to allocate to the job       (ex: '4' '100m')")
-	cmd.Flags().StringVarP(&memory, "memory", "m", "",
-		"amount of memory to allocate to the job     (ex: '100Mi' or '256k' or '4G')")
-	cmd.Flags().StringVar(&memorySoftLimit, "memory-soft-limit", "",
-		"soft limit for memory usage                 (ex: '100Mi' or '256k' or '4G')")
-	cmd.Flags().StringSliceVar(&deviceReadBps, "device-read-bps", nil,
-		"device read bandwidth limits (id|path=bps)  (ex: '8:16=2097152' or '/dev/sda=2097152')")
-	cmd.Flags().StringSliceVar(&deviceWriteBps, "device-write-bps", nil,
-		"device write bandwidth limits (id|path=bps) (ex: '8:16=2097152' or '/dev/sda=2097152')")
-	cmd.Flags().StringSliceVar(&deviceReadIops, "device-read-iops", nil,
-		"device read IOPS limits (id|path=iops)      (ex: '8:16=200' or '/dev/sda=200')")
-	cmd.Flags().StringSliceVar(&deviceWriteIops, "device-write-iops", nil,
-		"device write IOPS limits (id|path=iops)     (ex: '8:16=200' or '/dev/sda=200')")
+	specFlags.addFlags(cmd)
 	cmd.Flags().BoolVarP(&follow, "follow", "f", false, "follow the output of the job")
+	cmd.Flags().BoolVarP(&stdin, "stdin", "i", false, "forward stdin to the job and follow its output (implies --follow)")
+	cmd.Flags().BoolVarP(&tty, "tty", "t", false, "run the job in a terminal attached to the local terminal (implies --stdin)")
+	cmd.Flags().StringVar(&name, "name", "", "a name for the job, unique among your jobs")
+	cmd.Flags().StringVar(&idempotencyKey, "idempotency-key", "", "a key identifying this request, to avoid starting the same job twice (default is a random key)")
 	return cmd
 }
 
+const (
+	maxStartAttempts   = 5
+	startRetryInterval = 500 * time.Millisecond
+)
+
+// startWithRetry starts a job, retrying with an exponential backoff if the
+// server is unavailable. The spec's idempotency key ensures that a retry does
+// not start a second job if an earlier request was received by the server.
+func startWithRetry(cmd *cobra.Command, client jobv1.JobClient, spec *jobv1.JobSpec) (*jobv1.JobId, error) {
+	delay := startRetryInterval
+	for attempt := 1; ; attempt++ {
+		id, err := client.Start(cmd.Context(), spec)
+		if status.Code(err) != codes.Unavailable || attempt == maxStartAttempts {
+			return id, err
+		}
+		select {
+		case <-time.After(delay):
+		case <-cmd.Context().Done():
+			return nil, cmd.Context().Err()
+		}
+		delay *= 2
+	}
+}
+
 func parseCpuLimits(cpus string) (int64, error) {
 	// valid formats:
 	// - integer whole number (e.g. 2)
@@ -179,8 +191,23 @@ func parseMemoryLimits(softLimit, limit string) (*jobv1.MemoryLimits, error) {
 	return limits, nil
 }
 
+// parseOptionalMemoryLimit parses a memory limit in the same format as
+// parseMemoryLimit, returning nil if the limit is empty.
+func parseOptionalMemoryLimit(name, limit string) (*int64, error) {
+	if limit == "" {
+		return nil, nil
+	}
+	bytes, err := parseMemoryLimit(limit)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for %s: %w", name, err)
+	}
+	return &bytes, nil
+}
+
 func parseMemoryLimit(limit string) (int64, error) {
 	switch {
+	case limit == "0":
+		return 0, nil
 	case strings.HasSuffix(limit, "k"):
 		k, err := strconv.ParseInt(limit[:len(limit)-1], 10, 64)
 		if err != nil {
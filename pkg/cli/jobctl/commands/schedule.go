@@ -0,0 +1,217 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	jobv1 "github.com/kralicky/jobserver/pkg/apis/job/v1"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func BuildJobScheduleCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "schedule",
+		GroupID: GroupIdClientCommands,
+		Short:   "Manage scheduled jobs.",
+		Long: `
+Schedules start a new job periodically, at the times given by a cron
+expression. Jobs started by a schedule are owned by the user that created the
+schedule, and can be listed with 'list --schedule=<schedule-id>'.
+`[1:],
+	}
+	cmd.AddGroup(&cobra.Group{
+		ID:    GroupIdClientCommands,
+		Title: "Commands:",
+	})
+	cmd.AddCommand(
+		BuildScheduleCreateCmd(),
+		BuildScheduleListCmd(),
+		BuildScheduleRmCmd(),
+	)
+	return cmd
+}
+
+func BuildScheduleCreateCmd() *cobra.Command {
+	var specFlags jobSpecFlags
+	var cronExpr string
+	var concurrencyPolicy string
+	var historyLimit uint32
+	cmd := &cobra.Command{
+		Use:     "create --cron=<expr> [flags] -- <command> [args...]",
+		GroupID: GroupIdClientCommands,
+		Short:   "Create a new schedule.",
+		Long: fmt.Sprintf(`
+Creates a new schedule, and prints its ID if it was created successfully.
+
+The cron expression has five fields: minute, hour, day of month, month, and
+day of week. The macros @yearly, @monthly, @weekly, @daily, and @hourly can
+be used instead. Schedules are evaluated in the server's local time zone.
+
+The remaining flags describe the jobs started by the schedule, in the same
+way as '%[1]s run'.
+
+With --concurrency-policy, the schedule either starts a new job regardless of
+whether jobs it started earlier are still running ('allow'), skips starting a
+new job if any are still running ('forbid'), or stops them before starting a
+new job ('replace').
+
+With --history-limit, only the given number of completed jobs started by the
+schedule are kept; older jobs are deleted.
+`[1:], os.Args[0]),
+		Example: fmt.Sprintf(`
+  Run a backup every night at 2am, unless the previous backup is still running:
+    $ %[1]s schedule create --cron='0 2 * * *' --concurrency-policy=forbid \
+       -- /usr/local/bin/backup --all
+`[1:], os.Args[0]),
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, ok := jobClientFromContext(cmd.Context())
+			if !ok {
+				cmd.PrintErrln("failed to get client from context")
+				return nil
+			}
+			template, err := specFlags.buildSpec(cmd, args)
+			if err != nil {
+				return err
+			}
+			spec := &jobv1.ScheduleSpec{
+				Cron:         cronExpr,
+				JobTemplate:  template,
+				HistoryLimit: historyLimit,
+			}
+			if concurrencyPolicy != "" {
+				policy, ok := jobv1.ConcurrencyPolicy_value[strings.ToUpper(concurrencyPolicy)]
+				if !ok {
+					return fmt.Errorf("invalid value for --concurrency-policy: %q (expecting allow, forbid, or replace)", concurrencyPolicy)
+				}
+				spec.ConcurrencyPolicy = jobv1.ConcurrencyPolicy(policy)
+			}
+			id, err := client.CreateSchedule(cmd.Context(), spec)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), id.GetId())
+			return nil
+		},
+	}
+	specFlags.addFlags(cmd)
+	cmd.Flags().StringVar(&cronExpr, "cron", "",
+		"when to start jobs, as a cron expression    (ex: '*/15 * * * *' or '@daily')")
+	cmd.Flags().StringVar(&concurrencyPolicy, "concurrency-policy", "",
+		"what to do if the previous job is running   (ex: 'allow' or 'forbid' or 'replace')")
+	cmd.Flags().Uint32Var(&historyLimit, "history-limit", 0,
+		"number of completed jobs to keep (default is to keep all jobs)")
+	cmd.RegisterFlagCompletionFunc("concurrency-policy", cobra.FixedCompletions([]string{"allow", "forbid", "replace"}, cobra.ShellCompDirectiveNoFileComp))
+	cmd.MarkFlagRequired("cron")
+	return cmd
+}
+
+func BuildScheduleListCmd() *cobra.Command {
+	var owner string
+	cmd := &cobra.Command{
+		Use:     "list",
+		GroupID: GroupIdClientCommands,
+		Short:   "Show all existing schedules.",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, ok := jobClientFromContext(cmd.Context())
+			if !ok {
+				cmd.PrintErrln("failed to get client from context")
+				return nil
+			}
+			req := &jobv1.ListSchedulesRequest{}
+			if cmd.Flags().Changed("owner") {
+				req.Owner = &owner
+			}
+			resp, err := client.ListSchedules(cmd.Context(), req)
+			if err != nil {
+				return err
+			}
+			tab := table.NewWriter()
+			tab.AppendHeader(table.Row{"SCHEDULE ID", "OWNER", "CRON", "COMMAND", "LAST RUN", "NEXT RUN", "ACTIVE", "STATUS"})
+			for _, sched := range resp.GetItems() {
+				tab.AppendRow(table.Row{
+					sched.GetId().GetId(),
+					sched.GetOwner(),
+					sched.GetSpec().GetCron(),
+					sched.GetSpec().GetJobTemplate().GetCommand().GetCommand(),
+					formatScheduleTime(sched.GetLastScheduleTime()),
+					formatScheduleTime(sched.GetNextScheduleTime()),
+					len(sched.GetActiveJobs()),
+					sched.GetMessage(),
+				})
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), tab.Render())
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&owner, "owner", "", "only show schedules created by the given user")
+	return cmd
+}
+
+func formatScheduleTime(t *timestamppb.Timestamp) string {
+	if t == nil {
+		return ""
+	}
+	return t.AsTime().Local().Format(time.RFC3339)
+}
+
+func BuildScheduleRmCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "rm <schedule-id> [<schedule-id>...]",
+		Aliases: []string{"delete"},
+		GroupID: GroupIdClientCommands,
+		Short:   "Delete one or more schedules.",
+		Long: `
+Deletes schedules. Jobs that were already started by the schedules are not
+affected.
+`[1:],
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: completeScheduleIds,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, ok := jobClientFromContext(cmd.Context())
+			if !ok {
+				cmd.PrintErrln("failed to get client from context")
+				return nil
+			}
+			for _, id := range args {
+				_, err := client.DeleteSchedule(cmd.Context(), &jobv1.ScheduleId{Id: id})
+				if err != nil {
+					return err
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), id)
+			}
+			return nil
+		},
+	}
+	return cmd
+}
+
+func completeScheduleIds(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if err := cmd.Root().PersistentPreRunE(cmd, args); err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	client, ok := jobClientFromContext(cmd.Context())
+	if !ok {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	resp, err := client.ListSchedules(cmd.Context(), &jobv1.ListSchedulesRequest{})
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	ids := make([]string, 0, len(resp.GetItems()))
+	for _, sched := range resp.GetItems() {
+		id := sched.GetId().GetId()
+		if slices.Contains(args, id) {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids, cobra.ShellCompDirectiveNoFileComp
+}
@@ -1,7 +1,9 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
+	"io"
 
 	jobv1 "github.com/kralicky/jobserver/pkg/apis/job/v1"
 	"github.com/spf13/cobra"
@@ -11,6 +13,7 @@ import (
 
 func BuildJobStatusCmd() *cobra.Command {
 	var output string
+	var watch bool
 	cmd := &cobra.Command{
 		Use:     "status <job-id>",
 		GroupID: GroupIdClientCommands,
@@ -18,6 +21,9 @@ func BuildJobStatusCmd() *cobra.Command {
 		Long: `
 Shows the status of an existing job, including current state, pid, original
 spec, start and end time, and exit status (if applicable).
+
+With --watch, the status is printed again each time the job's state changes,
+until the job is no longer running.
 `[1:],
 		Args:              cobra.ExactArgs(1),
 		ValidArgsFunction: completeJobIds,
@@ -27,21 +33,44 @@ spec, start and end time, and exit status (if applicable).
 				cmd.PrintErrln("failed to get client from context")
 				return nil
 			}
+			if watch {
+				stream, err := client.Watch(cmd.Context(), &jobv1.WatchRequest{
+					Id: &jobv1.JobId{Id: args[0]},
+				})
+				if err != nil {
+					return err
+				}
+				for {
+					event, err := stream.Recv()
+					if err != nil {
+						if errors.Is(err, io.EOF) {
+							return nil
+						}
+						return err
+					}
+					printStatus(cmd, event.GetStatus(), output)
+				}
+			}
 			status, err := client.Status(cmd.Context(), &jobv1.JobId{Id: args[0]})
 			if err != nil {
 				return err
 			}
-			switch output {
-			case "json":
-				fmt.Fprintln(cmd.OutOrStdout(), protojson.Format(status))
-			case "text":
-				fmt.Fprintln(cmd.OutOrStdout(), prototext.Format(status))
-			}
+			printStatus(cmd, status, output)
 			return nil
 		},
 	}
 
 	cmd.Flags().StringVarP(&output, "output", "o", "json", "Output format (json|text)")
+	cmd.Flags().BoolVarP(&watch, "watch", "w", false, "print the job's status each time its state changes")
 	cmd.RegisterFlagCompletionFunc("output", cobra.FixedCompletions([]string{"json", "text"}, cobra.ShellCompDirectiveNoFileComp))
 	return cmd
 }
+
+func printStatus(cmd *cobra.Command, status *jobv1.JobStatus, output string) {
+	switch output {
+	case "json":
+		fmt.Fprintln(cmd.OutOrStdout(), protojson.Format(status))
+	case "text":
+		fmt.Fprintln(cmd.OutOrStdout(), prototext.Format(status))
+	}
+}
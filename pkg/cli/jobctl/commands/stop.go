@@ -2,24 +2,53 @@ package commands
 
 import (
 	"fmt"
+	"os"
+	"time"
 
 	jobv1 "github.com/kralicky/jobserver/pkg/apis/job/v1"
 	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 func BuildJobStopCmd() *cobra.Command {
+	var signal string
+	var timeout time.Duration
+	var force bool
+	var selector string
 	cmd := &cobra.Command{
-		Use:     "stop <job-id>",
+		Use:     "stop [flags] <job-id>|--selector=<selector>",
 		GroupID: GroupIdClientCommands,
 		Short:   "Stop a running job.",
-		Long: `
+		Long: fmt.Sprintf(`
 Stops a running job, then waits for the job to be terminated.
 
-This will first attempt to stop the job's process using SIGTERM, but if the
-process does not exit within a short grace period, it will be forcefully
-killed with SIGKILL.
-`[1:],
-		Args:              cobra.ExactArgs(1),
+This will first attempt to stop the job's process using its stop signal, but
+if the process does not exit within its grace period, every process in the job
+will be forcefully killed with SIGKILL. Unless they were set when the job was
+started ('%[1]s run --stop-signal --stop-timeout'), the stop signal is SIGTERM
+and the grace period is 10 seconds. Both can be overridden with --signal and
+--timeout.
+
+With --force, the job is killed immediately. This can also be used to kill a
+job that is already stopping.
+
+With --selector, every job whose labels match the selector is stopped instead,
+and the IDs of the stopped jobs are printed once all of them have terminated.
+See '%[1]s list --help' for the selector syntax.
+`[1:], os.Args[0]),
+		Example: fmt.Sprintf(`
+  Stop all jobs for the infra team outside of production:
+    $ %[1]s stop -l 'team=infra,env!=prod'
+`[1:], os.Args[0]),
+		Args: func(cmd *cobra.Command, args []string) error {
+			if selector != "" {
+				if len(args) > 0 {
+					return fmt.Errorf("a job id cannot be used together with --selector")
+				}
+				return nil
+			}
+			return cobra.ExactArgs(1)(cmd, args)
+		},
 		ValidArgsFunction: completeJobIds,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client, ok := jobClientFromContext(cmd.Context())
@@ -27,13 +56,39 @@ killed with SIGKILL.
 				cmd.PrintErrln("failed to get client from context")
 				return nil
 			}
-			_, err := client.Stop(cmd.Context(), &jobv1.JobId{Id: args[0]})
+			req := &jobv1.StopRequest{
+				LabelSelector: selector,
+				Force:         force,
+			}
+			if len(args) > 0 {
+				req.Id = &jobv1.JobId{Id: args[0]}
+			}
+			if signal != "" {
+				sig, err := parseSignal(signal)
+				if err != nil {
+					return err
+				}
+				req.Signal = sig
+			}
+			if cmd.Flags().Changed("timeout") {
+				req.GracePeriod = durationpb.New(timeout)
+			}
+			resp, err := client.Stop(cmd.Context(), req)
 			if err != nil {
 				return err
 			}
-			fmt.Fprintln(cmd.OutOrStdout(), args[0])
+			for _, id := range resp.GetStopped() {
+				fmt.Fprintln(cmd.OutOrStdout(), id.GetId())
+			}
 			return nil
 		},
 	}
+	cmd.Flags().StringVarP(&signal, "signal", "s", "", "the signal used to stop the job, by name or number (ex: 'INT' or 'SIGQUIT' or '2')")
+	cmd.Flags().DurationVarP(&timeout, "timeout", "t", 0, "how long to wait for the job to exit before killing it (ex: '30s' or '2m')")
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "kill the job immediately")
+	cmd.Flags().StringVarP(&selector, "selector", "l", "", "stop all jobs whose labels match the selector (ex: 'team=infra,env!=prod')")
+	cmd.RegisterFlagCompletionFunc("signal", completeSignals)
+	cmd.MarkFlagsMutuallyExclusive("force", "signal")
+	cmd.MarkFlagsMutuallyExclusive("force", "timeout")
 	return cmd
 }
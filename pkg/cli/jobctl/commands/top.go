@@ -0,0 +1,182 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	jobv1 "github.com/kralicky/jobserver/pkg/apis/job/v1"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func BuildJobTopCmd() *cobra.Command {
+	var interval time.Duration
+	var once bool
+	cmd := &cobra.Command{
+		Use:     "top [<job-id>]",
+		GroupID: GroupIdClientCommands,
+		Short:   "Show the resource usage of running jobs.",
+		Long: `
+Shows the resources used by a job, or by every running job if no job ID is
+given, as measured by the jobs' cgroups. The usage is refreshed periodically
+until the command is interrupted with Ctrl-C.
+
+CPU% is the CPU time used since the previous refresh, as a percentage of one
+CPU; jobs using more than one CPU can exceed 100%. It is shown from the second
+refresh onwards. PEAK is the highest memory usage recorded for the job, and
+READ and WRITE are the total bytes read from and written to all devices.
+`[1:],
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completeJobIds,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, ok := jobClientFromContext(cmd.Context())
+			if !ok {
+				cmd.PrintErrln("failed to get client from context")
+				return nil
+			}
+			if interval <= 0 {
+				return fmt.Errorf("--interval must be greater than 0")
+			}
+			clear := false
+			if f, ok := cmd.OutOrStdout().(*os.File); ok && !once {
+				clear = term.IsTerminal(int(f.Fd()))
+			}
+			var previous map[string]*jobv1.ResourceUsage
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+			for {
+				usages, err := collectUsage(cmd, client, args)
+				if err != nil {
+					return err
+				}
+				if clear {
+					fmt.Fprint(cmd.OutOrStdout(), "\033[H\033[2J")
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), renderUsage(usages, previous))
+				if once {
+					return nil
+				}
+				previous = make(map[string]*jobv1.ResourceUsage, len(usages))
+				for _, u := range usages {
+					previous[u.job.GetId().GetId()] = u.usage
+				}
+				select {
+				case <-ticker.C:
+				case <-cmd.Context().Done():
+					return nil
+				}
+			}
+		},
+	}
+	cmd.Flags().DurationVarP(&interval, "interval", "n", 2*time.Second, "time between refreshes (ex: '500ms' or '5s')")
+	cmd.Flags().BoolVar(&once, "once", false, "print the usage once and exit")
+	return cmd
+}
+
+type jobUsage struct {
+	job   *jobv1.JobInfo
+	usage *jobv1.ResourceUsage
+}
+
+// collectUsage returns the usage of the job given in args, or of every running
+// job if args is empty. Jobs whose usage is not available are skipped, unless
+// the job was given explicitly.
+func collectUsage(cmd *cobra.Command, client jobv1.JobClient, args []string) ([]jobUsage, error) {
+	if len(args) == 1 {
+		id := &jobv1.JobId{Id: args[0]}
+		stat, err := client.Status(cmd.Context(), id)
+		if err != nil {
+			return nil, err
+		}
+		usage, err := client.Usage(cmd.Context(), id)
+		if err != nil {
+			return nil, err
+		}
+		return []jobUsage{{job: &jobv1.JobInfo{Id: id, Status: stat}, usage: usage}}, nil
+	}
+	jobs, err := listJobs(cmd, client, &jobv1.ListRequest{
+		States: []jobv1.State{jobv1.State_RUNNING},
+	})
+	if err != nil {
+		return nil, err
+	}
+	usages := make([]jobUsage, 0, len(jobs))
+	for _, job := range jobs {
+		usage, err := client.Usage(cmd.Context(), job.GetId())
+		if err != nil {
+			// the job may have completed since it was listed
+			if status.Code(err) == codes.FailedPrecondition || status.Code(err) == codes.NotFound {
+				continue
+			}
+			return nil, err
+		}
+		usages = append(usages, jobUsage{job: job, usage: usage})
+	}
+	slices.SortFunc(usages, func(a, b jobUsage) int {
+		return strings.Compare(a.job.GetId().GetId(), b.job.GetId().GetId())
+	})
+	return usages, nil
+}
+
+func renderUsage(usages []jobUsage, previous map[string]*jobv1.ResourceUsage) string {
+	tab := table.NewWriter()
+	tab.AppendHeader(table.Row{"JOB ID", "NAME", "CPU%", "CPU TIME", "MEMORY", "PEAK", "READ", "WRITE", "PIDS"})
+	for _, u := range usages {
+		usage := u.usage
+		var cpuPercent, peak, pids string
+		if prev, ok := previous[u.job.GetId().GetId()]; ok {
+			elapsed := usage.GetTime().AsTime().Sub(prev.GetTime().AsTime())
+			// the usage is reset if the job was restarted
+			if elapsed > 0 && usage.GetCpu().GetUsageUsec() >= prev.GetCpu().GetUsageUsec() {
+				used := time.Duration(usage.GetCpu().GetUsageUsec()-prev.GetCpu().GetUsageUsec()) * time.Microsecond
+				cpuPercent = fmt.Sprintf("%.1f", 100*used.Seconds()/elapsed.Seconds())
+			}
+		}
+		if usage.GetMemory().Peak != nil {
+			peak = formatBytes(usage.GetMemory().GetPeak())
+		}
+		if usage.GetPids() != nil {
+			pids = fmt.Sprint(usage.GetPids().GetCurrent())
+		}
+		var read, write uint64
+		for _, dev := range usage.GetIo() {
+			read += dev.GetReadBytes()
+			write += dev.GetWriteBytes()
+		}
+		tab.AppendRow(table.Row{
+			u.job.GetId().GetId(),
+			u.job.GetStatus().GetSpec().GetName(),
+			cpuPercent,
+			(time.Duration(usage.GetCpu().GetUsageUsec()) * time.Microsecond).Round(time.Millisecond),
+			formatBytes(usage.GetMemory().GetCurrent()),
+			peak,
+			formatBytes(read),
+			formatBytes(write),
+			pids,
+		})
+	}
+	return tab.Render()
+}
+
+// formatBytes formats a number of bytes using the same binary suffixes that
+// are accepted for memory limits (Ki, Mi, Gi, Ti).
+func formatBytes(n uint64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d", n)
+	}
+	value := float64(n) / unit
+	for _, suffix := range []string{"Ki", "Mi", "Gi"} {
+		if value < unit {
+			return fmt.Sprintf("%.1f%s", value, suffix)
+		}
+		value /= unit
+	}
+	return fmt.Sprintf("%.1fTi", value)
+}
@@ -0,0 +1,61 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	jobv1 "github.com/kralicky/jobserver/pkg/apis/job/v1"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func BuildJobUpdateCmd() *cobra.Command {
+	var limits limitFlags
+	cmd := &cobra.Command{
+		Use:     "update [flags] <job-id>",
+		GroupID: GroupIdClientCommands,
+		Short:   "Change the resource limits of a job.",
+		Long: fmt.Sprintf(`
+Changes the resource limits of a job without restarting it, and prints the
+job's effective limits.
+
+Only the limits given by flags are changed; the job's other limits are left
+as they are. The flags are the same as the resource limit flags of
+'%[1]s run'. The new limits also apply if the job is restarted later.
+
+Lowering the memory limit below the job's current memory usage may cause the
+job to be OOM-killed.
+`[1:], os.Args[0]),
+		Example: fmt.Sprintf(`
+  Give a running job more memory:
+    $ %[1]s update --memory=4Gi <job-id>
+`[1:], os.Args[0]),
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeJobIds,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, ok := jobClientFromContext(cmd.Context())
+			if !ok {
+				cmd.PrintErrln("failed to get client from context")
+				return nil
+			}
+			req := &jobv1.UpdateLimitsRequest{
+				Id: &jobv1.JobId{Id: args[0]},
+			}
+			var err error
+			if req.Limits, err = limits.buildLimits(cmd); err != nil {
+				return err
+			}
+			if err := req.Validate(); err != nil {
+				return err
+			}
+			effective, err := client.UpdateLimits(cmd.Context(), req)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), protojson.Format(effective))
+			return nil
+		},
+	}
+	limits.addFlags(cmd)
+	return cmd
+}
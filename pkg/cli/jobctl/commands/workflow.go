@@ -0,0 +1,151 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/bufbuild/protoyaml-go"
+	"github.com/jedib0t/go-pretty/v6/table"
+	jobv1 "github.com/kralicky/jobserver/pkg/apis/job/v1"
+	"github.com/spf13/cobra"
+)
+
+func BuildJobWorkflowCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "workflow",
+		GroupID: GroupIdClientCommands,
+		Short:   "Run workflows of jobs that depend on each other.",
+		Long: `
+Workflows run a set of named jobs, each of which is started once the jobs it
+depends on have completed successfully. If a job does not complete
+successfully, the jobs that depend on it are skipped.
+`[1:],
+	}
+	cmd.AddGroup(&cobra.Group{
+		ID:    GroupIdClientCommands,
+		Title: "Commands:",
+	})
+	cmd.AddCommand(
+		BuildWorkflowRunCmd(),
+		BuildWorkflowStatusCmd(),
+	)
+	return cmd
+}
+
+func BuildWorkflowRunCmd() *cobra.Command {
+	var file string
+	cmd := &cobra.Command{
+		Use:     "run -f <file>",
+		GroupID: GroupIdClientCommands,
+		Short:   "Start a new workflow.",
+		Long: fmt.Sprintf(`
+Starts a new workflow described by a YAML file, and prints its ID if it was
+started successfully.
+
+The file contains a list of jobs, each with a unique name, the names of the
+jobs it depends on, and a job spec. Field names are the same as in the job
+server's API, in camelCase.
+
+To check the status of the workflow, use the command '%[1]s workflow status <id>'.
+`[1:], os.Args[0]),
+		Example: fmt.Sprintf(`
+  Start a workflow that builds, tests, and packages a Go module:
+    $ %[1]s workflow run -f examples/workflows/build.yaml
+
+  A workflow file contains a list of jobs:
+    jobs:
+      - name: build
+        spec:
+          command:
+            command: go
+            args: [build, ./...]
+      - name: test
+        dependsOn: [build]
+        spec:
+          command:
+            command: go
+            args: [test, ./...]
+          timeout: 600s
+`[1:], os.Args[0]),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, ok := jobClientFromContext(cmd.Context())
+			if !ok {
+				cmd.PrintErrln("failed to get client from context")
+				return nil
+			}
+			spec, err := loadWorkflowSpec(file)
+			if err != nil {
+				return err
+			}
+			id, err := client.StartWorkflow(cmd.Context(), spec)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), id.GetId())
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&file, "file", "f", "", "path to a YAML file describing the workflow")
+	cmd.MarkFlagRequired("file")
+	cmd.MarkFlagFilename("file", "yaml", "yml")
+	return cmd
+}
+
+func loadWorkflowSpec(path string) (*jobv1.WorkflowSpec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read workflow file: %w", err)
+	}
+	spec := &jobv1.WorkflowSpec{}
+	opts := protoyaml.UnmarshalOptions{
+		Path: path,
+	}
+	if err := opts.Unmarshal(data, spec); err != nil {
+		return nil, err
+	}
+	if err := spec.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid workflow: %w", err)
+	}
+	return spec, nil
+}
+
+func BuildWorkflowStatusCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "status <workflow-id>",
+		GroupID: GroupIdClientCommands,
+		Short:   "Show the status of a workflow.",
+		Long: `
+Shows the overall state of a workflow, followed by the state of each of its
+jobs. The jobs' IDs can be used with other commands, such as 'logs'.
+`[1:],
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, ok := jobClientFromContext(cmd.Context())
+			if !ok {
+				cmd.PrintErrln("failed to get client from context")
+				return nil
+			}
+			status, err := client.GetWorkflowStatus(cmd.Context(), &jobv1.WorkflowId{Id: args[0]})
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Workflow %s: %s\n",
+				args[0], strings.ToLower(strings.TrimPrefix(status.GetState().String(), "WORKFLOW_")))
+			tab := table.NewWriter()
+			tab.AppendHeader(table.Row{"NAME", "JOB ID", "STATE", "STATUS"})
+			for _, job := range status.GetJobs() {
+				tab.AppendRow(table.Row{
+					job.GetName(),
+					job.GetId().GetId(),
+					strings.ToLower(strings.TrimPrefix(job.GetState().String(), "WORKFLOW_JOB_")),
+					job.GetMessage(),
+				})
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), tab.Render())
+			return nil
+		},
+	}
+	return cmd
+}
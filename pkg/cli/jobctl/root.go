@@ -66,7 +66,11 @@ func BuildRootCmd() *cobra.Command {
 				return fmt.Errorf("failed to dial job server: %w", err)
 			}
 
-			cmd.SetContext(commands.ContextWithJobClient(cmd.Context(), jobv1.NewJobClient(cc)))
+			ctx := commands.ContextWithJobClient(cmd.Context(), jobv1.NewJobClient(cc))
+			if leaf, err := x509.ParseCertificate(clientCert.Certificate[0]); err == nil {
+				ctx = commands.ContextWithUser(ctx, leaf.Subject.CommonName)
+			}
+			cmd.SetContext(ctx)
 			return nil
 		},
 	}
@@ -94,8 +98,15 @@ func BuildRootCmd() *cobra.Command {
 		commands.BuildJobRunCmd(),
 		commands.BuildJobStopCmd(),
 		commands.BuildJobStatusCmd(),
+		commands.BuildJobTopCmd(),
+		commands.BuildJobUpdateCmd(),
 		commands.BuildJobListCmd(),
 		commands.BuildJobLogsCmd(),
+		commands.BuildJobRmCmd(),
+		commands.BuildJobAttachCmd(),
+		commands.BuildJobKillCmd(),
+		commands.BuildJobScheduleCmd(),
+		commands.BuildJobWorkflowCmd(),
 	)
 
 	return cmd
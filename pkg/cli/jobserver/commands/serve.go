@@ -3,6 +3,7 @@ package commands
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"github.com/bufbuild/protoyaml-go"
 	rbacv1 "github.com/kralicky/jobserver/pkg/apis/rbac/v1"
@@ -11,12 +12,16 @@ import (
 	"github.com/kralicky/jobserver/pkg/jobs"
 	"github.com/kralicky/jobserver/pkg/rbac"
 	"github.com/kralicky/jobserver/pkg/server"
+	"github.com/kralicky/jobserver/pkg/storage"
 	"github.com/spf13/cobra"
 )
 
 // ServeCmd represents the serve command
 func BuildServeCmd() *cobra.Command {
 	var rbacConfigFile string
+	var dataDir string
+	var outputBackend string
+	var outputRetainBytes int64
 	var serverConfig server.Options
 	cmd := &cobra.Command{
 		Use:   "serve",
@@ -31,6 +36,21 @@ func BuildServeCmd() *cobra.Command {
 				auth.NewMiddleware(auth.NewMTLSAuthenticator()),
 				rbac.NewAllowedMethodsMiddleware(config),
 			}
+			serverConfig.RbacConfig = config
+			switch outputBackend {
+			case "memory":
+				serverConfig.OutputBackend = jobs.NewMemoryOutputBackend(outputRetainBytes)
+			case "file":
+				if dataDir == "" {
+					return fmt.Errorf("--output-backend=file requires --data-dir to be set")
+				}
+				serverConfig.OutputBackend, err = jobs.NewFileOutputBackend(filepath.Join(dataDir, "output"))
+				if err != nil {
+					return fmt.Errorf("failed to initialize output backend: %w", err)
+				}
+			default:
+				return fmt.Errorf("unknown output backend %q", outputBackend)
+			}
 			runtimeId, err := cgroups.DetectFilesystemRuntime()
 			if err != nil {
 				return err
@@ -39,10 +59,24 @@ func BuildServeCmd() *cobra.Command {
 			if !ok {
 				return fmt.Errorf("no runtime found for %q", runtimeId)
 			}
-			rt, err := builder()
+			rt, err := builder(jobs.RuntimeOptions{
+				Output: serverConfig.OutputBackend,
+			})
 			if err != nil {
 				return fmt.Errorf("failed to initialize runtime: %w", err)
 			}
+			if dataDir != "" {
+				store, err := storage.NewFileStore(dataDir)
+				if err != nil {
+					return fmt.Errorf("failed to initialize job store: %w", err)
+				}
+				serverConfig.Store = store
+				scheduleStore, err := storage.NewFileScheduleStore(dataDir)
+				if err != nil {
+					return fmt.Errorf("failed to initialize schedule store: %w", err)
+				}
+				serverConfig.ScheduleStore = scheduleStore
+			}
 			srv := server.NewServer(rt, serverConfig)
 			return srv.ListenAndServe(cmd.Context())
 		},
@@ -53,6 +87,16 @@ func BuildServeCmd() *cobra.Command {
 	cmd.Flags().StringVar(&serverConfig.CaCertFile, "cacert", "", "path to the CA certificate")
 	cmd.Flags().StringVar(&serverConfig.CertFile, "cert", "", "path to the server certificate")
 	cmd.Flags().StringVar(&serverConfig.KeyFile, "key", "", "path to the server key")
+	cmd.Flags().StringVar(&dataDir, "data-dir", "", "directory in which to persist jobs and schedules across restarts (if empty, they are kept in memory only)")
+	cmd.Flags().StringVar(&outputBackend, "output-backend", "memory", "where to store job output (memory|file); 'file' writes output to --data-dir")
+	cmd.Flags().Int64Var(&outputRetainBytes, "output-retain-bytes", 0, "maximum number of bytes of output to keep in memory for each job (0 for unlimited); only applies to the memory output backend")
+	cmd.Flags().DurationVar(&serverConfig.Retention.TTL, "job-ttl", 0, "how long to keep completed jobs before deleting them (0 to keep forever)")
+	cmd.Flags().IntVar(&serverConfig.Retention.MaxCompletedPerUser, "max-completed-jobs-per-user", 0, "maximum number of completed jobs to keep for each user (0 for unlimited)")
+	cmd.Flags().IntVar(&serverConfig.Retention.MaxJobs, "max-jobs", 0, "maximum number of jobs to keep in total; the oldest completed jobs are deleted first (0 for unlimited)")
+	cmd.Flags().IntVar(&serverConfig.Admission.MaxRunning, "max-running-jobs", 0, "maximum number of jobs that can run at the same time; additional jobs wait in the pending state (0 for unlimited)")
+	cmd.Flags().IntVar(&serverConfig.Admission.MaxRunningPerUser, "max-running-jobs-per-user", 0, "maximum number of jobs that each user can run at the same time (0 for unlimited)")
+	cmd.Flags().Int64Var(&serverConfig.DefaultPidsLimit, "default-pids-limit", 4096, "maximum number of processes in each job that does not set its own limit with --pids (0 for unlimited; must be 0 if the cgroup pids controller is not available)")
+	cmd.RegisterFlagCompletionFunc("output-backend", cobra.FixedCompletions([]string{"memory", "file"}, cobra.ShellCompDirectiveNoFileComp))
 	cmd.MarkFlagRequired("rbac")
 	cmd.MarkFlagRequired("cacert")
 	cmd.MarkFlagRequired("cert")
@@ -0,0 +1,215 @@
+// Package cron parses standard five-field cron expressions, and computes the
+// times at which they fire.
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule is a parsed cron expression.
+type Schedule struct {
+	expr string
+
+	// bitsets of the values allowed in each field
+	minute, hour, dom, month, dow uint64
+	// whether the day of month and day of week fields are unrestricted ('*').
+	// If both fields are restricted, a day matches if it matches either field;
+	// otherwise, it must match both.
+	domStar, dowStar bool
+}
+
+type field struct {
+	name     string
+	min, max int
+	names    []string // names of the values starting at min, if any
+}
+
+var (
+	minuteField = field{name: "minute", min: 0, max: 59}
+	hourField   = field{name: "hour", min: 0, max: 23}
+	domField    = field{name: "day of month", min: 1, max: 31}
+	monthField  = field{name: "month", min: 1, max: 12, names: []string{
+		"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec",
+	}}
+	// 7 is also accepted for Sunday, and folded into 0 after parsing
+	dowField = field{name: "day of week", min: 0, max: 7, names: []string{
+		"sun", "mon", "tue", "wed", "thu", "fri", "sat",
+	}}
+)
+
+var macros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// Parse parses a cron expression. The expression must contain five fields
+// separated by whitespace: minute, hour, day of month, month, and day of week.
+// Each field is a comma-separated list of values, ranges ('a-b'), or '*',
+// each optionally followed by a step ('/n'). Months and days of the week can
+// also be given by their three-letter English names. In place of the five
+// fields, one of the macros @yearly (or @annually), @monthly, @weekly, @daily
+// (or @midnight), or @hourly can be used.
+func Parse(expr string) (*Schedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) == 1 && strings.HasPrefix(fields[0], "@") {
+		macro, ok := macros[strings.ToLower(fields[0])]
+		if !ok {
+			return nil, fmt.Errorf("unknown macro %q", fields[0])
+		}
+		fields = strings.Fields(macro)
+	}
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("expected 5 fields, got %d", len(fields))
+	}
+	s := &Schedule{
+		expr:    expr,
+		domStar: fields[2] == "*",
+		dowStar: fields[4] == "*",
+	}
+	var err error
+	if s.minute, err = minuteField.parse(fields[0]); err != nil {
+		return nil, err
+	}
+	if s.hour, err = hourField.parse(fields[1]); err != nil {
+		return nil, err
+	}
+	if s.dom, err = domField.parse(fields[2]); err != nil {
+		return nil, err
+	}
+	if s.month, err = monthField.parse(fields[3]); err != nil {
+		return nil, err
+	}
+	if s.dow, err = dowField.parse(fields[4]); err != nil {
+		return nil, err
+	}
+	if s.dow&(1<<7) != 0 {
+		s.dow = s.dow&^(1<<7) | 1
+	}
+	return s, nil
+}
+
+// String returns the expression the schedule was parsed from.
+func (s *Schedule) String() string {
+	return s.expr
+}
+
+func (f field) parse(expr string) (uint64, error) {
+	var set uint64
+	for _, part := range strings.Split(expr, ",") {
+		bits, err := f.parsePart(part)
+		if err != nil {
+			return 0, fmt.Errorf("invalid %s field %q: %w", f.name, expr, err)
+		}
+		set |= bits
+	}
+	return set, nil
+}
+
+func (f field) parsePart(part string) (uint64, error) {
+	rng, stepStr, hasStep := strings.Cut(part, "/")
+	step := 1
+	if hasStep {
+		var err error
+		step, err = strconv.Atoi(stepStr)
+		if err != nil || step <= 0 {
+			return 0, fmt.Errorf("invalid step %q", stepStr)
+		}
+	}
+	var lo, hi int
+	if rng == "*" {
+		lo, hi = f.min, f.max
+	} else {
+		loStr, hiStr, isRange := strings.Cut(rng, "-")
+		var err error
+		if lo, err = f.parseValue(loStr); err != nil {
+			return 0, err
+		}
+		switch {
+		case isRange:
+			if hi, err = f.parseValue(hiStr); err != nil {
+				return 0, err
+			}
+			if hi < lo {
+				return 0, fmt.Errorf("invalid range %q", rng)
+			}
+		case hasStep:
+			// 'a/n' is shorthand for 'a-max/n'
+			hi = f.max
+		default:
+			hi = lo
+		}
+	}
+	var set uint64
+	for v := lo; v <= hi; v += step {
+		set |= 1 << v
+	}
+	return set, nil
+}
+
+func (f field) parseValue(value string) (int, error) {
+	for i, name := range f.names {
+		if strings.EqualFold(value, name) {
+			return f.min + i, nil
+		}
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", value)
+	}
+	if n < f.min || n > f.max {
+		return 0, fmt.Errorf("value %d out of range [%d, %d]", n, f.min, f.max)
+	}
+	return n, nil
+}
+
+// The number of years Next searches before giving up. Schedules that only
+// fire on February 29th on a given day of the week can go several years
+// without firing, so this is more than strictly necessary for most schedules.
+const searchYears = 30
+
+// Next returns the first time after t at which the schedule fires, in t's
+// location. Returns the zero time if the schedule never fires (for example,
+// '0 0 31 2 *').
+func (s *Schedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.Year() + searchYears
+	for t.Year() <= limit {
+		switch {
+		case s.month&(1<<t.Month()) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+		case !s.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		case s.hour&(1<<t.Hour()) == 0:
+			next := time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			if !next.After(t) {
+				// the next hour is repeated due to a daylight saving time
+				// transition; skip ahead to the end of the current hour
+				next = t.Add(time.Duration(60-t.Minute()) * time.Minute)
+			}
+			t = next
+		case s.minute&(1<<t.Minute()) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func (s *Schedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<t.Day()) != 0
+	dowMatch := s.dow&(1<<t.Weekday()) != 0
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
@@ -0,0 +1,97 @@
+package cron_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/kralicky/jobserver/pkg/cron"
+)
+
+func mustParseTime(value string) time.Time {
+	t, err := time.Parse(time.RFC3339, value)
+	Expect(err).NotTo(HaveOccurred())
+	return t
+}
+
+var _ = Describe("Schedule", func() {
+	DescribeTable("computing the next time",
+		func(expr string, from string, expected ...string) {
+			s, err := cron.Parse(expr)
+			Expect(err).NotTo(HaveOccurred())
+			t := mustParseTime(from)
+			for _, e := range expected {
+				t = s.Next(t)
+				Expect(t).To(BeTemporally("==", mustParseTime(e)))
+			}
+		},
+		Entry("every minute", "* * * * *", "2024-01-01T00:00:30Z",
+			"2024-01-01T00:01:00Z", "2024-01-01T00:02:00Z"),
+		Entry("at a time that is exactly on a boundary", "* * * * *", "2024-01-01T00:01:00Z",
+			"2024-01-01T00:02:00Z"),
+		Entry("every 15 minutes", "*/15 * * * *", "2024-01-01T00:50:00Z",
+			"2024-01-01T01:00:00Z", "2024-01-01T01:15:00Z"),
+		Entry("lists and ranges", "0,30 9-10 * * *", "2024-01-01T00:00:00Z",
+			"2024-01-01T09:00:00Z", "2024-01-01T09:30:00Z", "2024-01-01T10:00:00Z", "2024-01-01T10:30:00Z", "2024-01-02T09:00:00Z"),
+		Entry("a step starting at a value", "5/20 * * * *", "2024-01-01T00:00:00Z",
+			"2024-01-01T00:05:00Z", "2024-01-01T00:25:00Z", "2024-01-01T00:45:00Z", "2024-01-01T01:05:00Z"),
+		Entry("weekdays by name", "0 12 * * mon-fri", "2024-01-05T13:00:00Z", // friday
+			"2024-01-08T12:00:00Z"),
+		Entry("sunday as 7", "0 0 * * 7", "2024-01-01T00:00:00Z",
+			"2024-01-07T00:00:00Z"),
+		Entry("months by name", "0 0 1 mar,SEP *", "2024-04-01T00:00:00Z",
+			"2024-09-01T00:00:00Z", "2025-03-01T00:00:00Z"),
+		Entry("day of month or day of week", "0 0 13 * fri", "2024-09-01T00:00:00Z",
+			"2024-09-06T00:00:00Z", "2024-09-13T00:00:00Z", "2024-09-20T00:00:00Z"),
+		Entry("leap days", "0 0 29 2 *", "2024-03-01T00:00:00Z",
+			"2028-02-29T00:00:00Z"),
+		Entry("@hourly", "@hourly", "2024-01-01T00:00:00Z",
+			"2024-01-01T01:00:00Z"),
+		Entry("@daily", "@daily", "2024-01-01T00:00:00Z",
+			"2024-01-02T00:00:00Z"),
+		Entry("@weekly", "@weekly", "2024-01-01T00:00:00Z",
+			"2024-01-07T00:00:00Z"),
+		Entry("@monthly", "@monthly", "2024-01-31T00:00:00Z",
+			"2024-02-01T00:00:00Z"),
+		Entry("@yearly", "@yearly", "2024-01-01T00:00:00Z",
+			"2025-01-01T00:00:00Z"),
+	)
+	It("should return the zero time if the schedule never fires", func() {
+		s, err := cron.Parse("0 0 31 2 *")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(s.Next(mustParseTime("2024-01-01T00:00:00Z")).IsZero()).To(BeTrue())
+	})
+	It("should use the location of the given time", func() {
+		loc, err := time.LoadLocation("America/New_York")
+		Expect(err).NotTo(HaveOccurred())
+		s, err := cron.Parse("30 2 * * *")
+		Expect(err).NotTo(HaveOccurred())
+		next := s.Next(time.Date(2024, 1, 1, 0, 0, 0, 0, loc))
+		Expect(next).To(BeTemporally("==", time.Date(2024, 1, 1, 2, 30, 0, 0, loc)))
+		Expect(next.Location()).To(Equal(loc))
+
+		By("skipping times that do not exist due to daylight saving time")
+		next = s.Next(time.Date(2024, 3, 10, 0, 0, 0, 0, loc))
+		Expect(next).To(BeTemporally("==", time.Date(2024, 3, 11, 2, 30, 0, 0, loc)))
+	})
+	DescribeTable("rejecting invalid expressions",
+		func(expr string) {
+			_, err := cron.Parse(expr)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("empty", ""),
+		Entry("too few fields", "* * * *"),
+		Entry("too many fields", "* * * * * *"),
+		Entry("unknown macro", "@sometimes"),
+		Entry("minute out of range", "60 * * * *"),
+		Entry("hour out of range", "* 24 * * *"),
+		Entry("day of month out of range", "* * 0 * *"),
+		Entry("month out of range", "* * * 13 *"),
+		Entry("day of week out of range", "* * * * 8"),
+		Entry("invalid value", "a * * * *"),
+		Entry("invalid range", "5-1 * * * *"),
+		Entry("invalid step", "*/0 * * * *"),
+		Entry("empty list item", "1,,2 * * * *"),
+	)
+})
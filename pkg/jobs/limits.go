@@ -0,0 +1,83 @@
+package jobs
+
+import (
+	jobv1 "github.com/kralicky/jobserver/pkg/apis/job/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// MergeLimits returns a copy of the given limits, with every limit that is set
+// in the update replaced by its new value. IO limits are merged per device.
+// Neither argument is modified.
+func MergeLimits(limits, update *jobv1.ResourceLimits) *jobv1.ResourceLimits {
+	merged := &jobv1.ResourceLimits{}
+	if limits != nil {
+		merged = proto.Clone(limits).(*jobv1.ResourceLimits)
+	}
+	if update.Cpu != nil {
+		merged.Cpu = proto.Int64(update.GetCpu())
+	}
+	if update.CpuWeight != nil {
+		merged.CpuWeight = proto.Uint32(update.GetCpuWeight())
+	}
+	if update.CpuPeriod != nil {
+		merged.CpuPeriod = proto.Clone(update.GetCpuPeriod()).(*durationpb.Duration)
+	}
+	if cpuset := update.GetCpuset(); cpuset != nil {
+		if merged.Cpuset == nil {
+			merged.Cpuset = &jobv1.CpusetLimits{}
+		}
+		if cpuset.GetCpus() != "" {
+			merged.Cpuset.Cpus = cpuset.GetCpus()
+		}
+		if cpuset.GetMems() != "" {
+			merged.Cpuset.Mems = cpuset.GetMems()
+		}
+	}
+	if update.Pids != nil {
+		merged.Pids = proto.Int64(update.GetPids())
+	}
+	if mem := update.GetMemory(); mem != nil {
+		if merged.Memory == nil {
+			merged.Memory = &jobv1.MemoryLimits{}
+		}
+		if mem.SoftLimit != nil {
+			merged.Memory.SoftLimit = proto.Int64(mem.GetSoftLimit())
+		}
+		if mem.Limit != nil {
+			merged.Memory.Limit = proto.Int64(mem.GetLimit())
+		}
+		if mem.SwapLimit != nil {
+			merged.Memory.SwapLimit = proto.Int64(mem.GetSwapLimit())
+		}
+		if mem.Low != nil {
+			merged.Memory.Low = proto.Int64(mem.GetLow())
+		}
+		if mem.Min != nil {
+			merged.Memory.Min = proto.Int64(mem.GetMin())
+		}
+		if mem.OomGroup != nil {
+			merged.Memory.OomGroup = proto.Bool(mem.GetOomGroup())
+		}
+	}
+	for _, dev := range update.GetIo() {
+		var existing *jobv1.IODeviceLimits
+		for _, d := range merged.Io {
+			if d.GetDevice() == dev.GetDevice() {
+				existing = d
+				break
+			}
+		}
+		if existing == nil {
+			merged.Io = append(merged.Io, proto.Clone(dev).(*jobv1.IODeviceLimits))
+			continue
+		}
+		if existing.Limits == nil {
+			existing.Limits = &jobv1.IOLimits{}
+		}
+		if dev.GetLimits() != nil {
+			proto.Merge(existing.Limits, dev.GetLimits())
+		}
+	}
+	return merged
+}
@@ -0,0 +1,101 @@
+package jobs_test
+
+import (
+	"time"
+
+	jobv1 "github.com/kralicky/jobserver/pkg/apis/job/v1"
+	"github.com/kralicky/jobserver/pkg/jobs"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+var _ = Describe("MergeLimits", func() {
+	DescribeTable("should replace the limits that are set in the update",
+		func(limits, update, expected *jobv1.ResourceLimits) {
+			var original, originalUpdate *jobv1.ResourceLimits
+			if limits != nil {
+				original = proto.Clone(limits).(*jobv1.ResourceLimits)
+			}
+			originalUpdate = proto.Clone(update).(*jobv1.ResourceLimits)
+
+			Expect(jobs.MergeLimits(limits, update)).To(BeComparableTo(expected, protocmp.Transform()))
+			// neither argument is modified
+			Expect(limits).To(BeComparableTo(original, protocmp.Transform()))
+			Expect(update).To(BeComparableTo(originalUpdate, protocmp.Transform()))
+		},
+		Entry("no existing limits",
+			nil,
+			&jobv1.ResourceLimits{Cpu: proto.Int64(500)},
+			&jobv1.ResourceLimits{Cpu: proto.Int64(500)},
+		),
+		Entry("empty update",
+			&jobv1.ResourceLimits{Cpu: proto.Int64(500), Pids: proto.Int64(10)},
+			&jobv1.ResourceLimits{},
+			&jobv1.ResourceLimits{Cpu: proto.Int64(500), Pids: proto.Int64(10)},
+		),
+		Entry("scalar limits",
+			&jobv1.ResourceLimits{Cpu: proto.Int64(500), CpuWeight: proto.Uint32(100), Pids: proto.Int64(10)},
+			&jobv1.ResourceLimits{
+				Cpu:       proto.Int64(1000),
+				CpuPeriod: durationpb.New(50 * time.Millisecond),
+				Pids:      proto.Int64(20),
+			},
+			&jobv1.ResourceLimits{
+				Cpu:       proto.Int64(1000),
+				CpuWeight: proto.Uint32(100),
+				CpuPeriod: durationpb.New(50 * time.Millisecond),
+				Pids:      proto.Int64(20),
+			},
+		),
+		Entry("limits set to zero",
+			&jobv1.ResourceLimits{Pids: proto.Int64(10)},
+			&jobv1.ResourceLimits{Pids: proto.Int64(0)},
+			&jobv1.ResourceLimits{Pids: proto.Int64(0)},
+		),
+		Entry("cpuset",
+			&jobv1.ResourceLimits{Cpuset: &jobv1.CpusetLimits{Cpus: "0-3", Mems: "0"}},
+			&jobv1.ResourceLimits{Cpuset: &jobv1.CpusetLimits{Cpus: "4-7"}},
+			&jobv1.ResourceLimits{Cpuset: &jobv1.CpusetLimits{Cpus: "4-7", Mems: "0"}},
+		),
+		Entry("memory",
+			&jobv1.ResourceLimits{Memory: &jobv1.MemoryLimits{Limit: proto.Int64(1 << 30), SoftLimit: proto.Int64(1 << 29)}},
+			&jobv1.ResourceLimits{Memory: &jobv1.MemoryLimits{Limit: proto.Int64(1 << 31), OomGroup: proto.Bool(true)}},
+			&jobv1.ResourceLimits{Memory: &jobv1.MemoryLimits{
+				Limit:     proto.Int64(1 << 31),
+				SoftLimit: proto.Int64(1 << 29),
+				OomGroup:  proto.Bool(true),
+			}},
+		),
+		Entry("io limits are merged per device",
+			&jobv1.ResourceLimits{Io: []*jobv1.IODeviceLimits{
+				{Device: "8:0", Limits: &jobv1.IOLimits{ReadBps: proto.Int64(1000), WriteBps: proto.Int64(2000)}},
+				{Device: "8:16", Limits: &jobv1.IOLimits{ReadIops: proto.Int64(10)}},
+			}},
+			&jobv1.ResourceLimits{Io: []*jobv1.IODeviceLimits{
+				{Device: "8:0", Limits: &jobv1.IOLimits{WriteBps: proto.Int64(3000), WriteIops: proto.Int64(20)}},
+				{Device: "8:32", Limits: &jobv1.IOLimits{ReadBps: proto.Int64(500)}},
+			}},
+			&jobv1.ResourceLimits{Io: []*jobv1.IODeviceLimits{
+				{Device: "8:0", Limits: &jobv1.IOLimits{
+					ReadBps:   proto.Int64(1000),
+					WriteBps:  proto.Int64(3000),
+					WriteIops: proto.Int64(20),
+				}},
+				{Device: "8:16", Limits: &jobv1.IOLimits{ReadIops: proto.Int64(10)}},
+				{Device: "8:32", Limits: &jobv1.IOLimits{ReadBps: proto.Int64(500)}},
+			}},
+		),
+		Entry("io limits for a device without limits",
+			&jobv1.ResourceLimits{Io: []*jobv1.IODeviceLimits{{Device: "8:0"}}},
+			&jobv1.ResourceLimits{Io: []*jobv1.IODeviceLimits{
+				{Device: "8:0", Limits: &jobv1.IOLimits{ReadBps: proto.Int64(1000)}},
+			}},
+			&jobv1.ResourceLimits{Io: []*jobv1.IODeviceLimits{
+				{Device: "8:0", Limits: &jobv1.IOLimits{ReadBps: proto.Int64(1000)}},
+			}},
+		),
+	)
+})
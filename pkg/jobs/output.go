@@ -0,0 +1,149 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	jobv1 "github.com/kralicky/jobserver/pkg/apis/job/v1"
+	"github.com/kralicky/jobserver/pkg/util"
+)
+
+// OutputBuffer stores the output of a job's process. It is written to by the
+// process, and can be streamed by any number of concurrent readers. See
+// [util.StreamBuffer] for a description of the expected semantics.
+type OutputBuffer interface {
+	io.WriteCloser
+	// Writes p to the buffer, tagged with the given source. The source of
+	// output written by a process is the jobv1.Stream it was written to.
+	WriteFrom(source util.Source, p []byte) (int, error)
+	// Streams the contents of the buffer, starting at the given offset.
+	NewChunkStreamFrom(ctx context.Context, offset int64) <-chan util.StreamChunk
+	// Reads the contents of the buffer before the given offset in reverse,
+	// newest first, until fn returns false.
+	ReadChunksBackward(end int64, fn func(util.StreamChunk) bool) error
+	// Returns the total number of bytes written to the buffer.
+	Size() int64
+}
+
+// StreamWriter returns a writer that writes to the given buffer, tagging all
+// data with the given output stream.
+func StreamWriter(buf OutputBuffer, stream jobv1.Stream) io.Writer {
+	return streamWriter{buf: buf, source: util.Source(stream)}
+}
+
+type streamWriter struct {
+	buf    OutputBuffer
+	source util.Source
+}
+
+func (w streamWriter) Write(p []byte) (int, error) {
+	return w.buf.WriteFrom(w.source, p)
+}
+
+// OutputBackend creates and manages the output buffers of jobs.
+type OutputBackend interface {
+	// Creates a new, empty output buffer for the job with the given id,
+	// configured according to the job's output spec (which may be nil).
+	NewBuffer(id string, spec *jobv1.OutputSpec) (OutputBuffer, error)
+	// Opens the output of a job created by a previous instance of the backend,
+	// for example before a server restart. The returned buffer is closed.
+	// If the backend does not retain output, or no output exists for the job,
+	// an error matching os.ErrNotExist is returned.
+	Open(id string) (OutputBuffer, error)
+	// Removes any stored output for the job with the given id. Buffers
+	// previously returned for the job must not be used after calling Remove.
+	Remove(id string) error
+}
+
+type memoryOutputBackend struct {
+	retainBytes int64
+}
+
+// NewMemoryOutputBackend returns an OutputBackend that keeps all output in
+// memory. Output is lost when the server exits.
+//
+// If retainBytes is greater than 0, only that many bytes of the most recent
+// output of each job are kept. Jobs can lower (but not raise) this limit
+// using the 'retain_bytes' field of their output spec.
+func NewMemoryOutputBackend(retainBytes int64) OutputBackend {
+	return memoryOutputBackend{retainBytes: retainBytes}
+}
+
+// NewBuffer implements OutputBackend.
+func (b memoryOutputBackend) NewBuffer(_ string, spec *jobv1.OutputSpec) (OutputBuffer, error) {
+	limit := b.retainBytes
+	if spec != nil && spec.RetainBytes != nil {
+		if limit > 0 {
+			limit = min(limit, spec.GetRetainBytes())
+		} else {
+			limit = spec.GetRetainBytes()
+		}
+	}
+	return util.NewBoundedStreamBuffer(limit), nil
+}
+
+// Open implements OutputBackend.
+func (memoryOutputBackend) Open(id string) (OutputBuffer, error) {
+	return nil, fmt.Errorf("output for job %s: %w", id, os.ErrNotExist)
+}
+
+// Remove implements OutputBackend.
+func (memoryOutputBackend) Remove(string) error {
+	return nil
+}
+
+type fileOutputBackend struct {
+	dir string
+}
+
+// NewFileOutputBackend returns an OutputBackend that writes the output of
+// each job to its own file in the given directory. The directory is created
+// if it does not exist.
+func NewFileOutputBackend(dir string) (OutputBackend, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create output directory: %w", err)
+	}
+	return &fileOutputBackend{dir: dir}, nil
+}
+
+// NewBuffer implements OutputBackend.
+func (b *fileOutputBackend) NewBuffer(id string, _ *jobv1.OutputSpec) (OutputBuffer, error) {
+	path, err := b.outputPath(id)
+	if err != nil {
+		return nil, err
+	}
+	return util.NewFileStreamBuffer(path)
+}
+
+// Open implements OutputBackend.
+func (b *fileOutputBackend) Open(id string) (OutputBuffer, error) {
+	path, err := b.outputPath(id)
+	if err != nil {
+		return nil, err
+	}
+	return util.OpenFileStreamBuffer(path)
+}
+
+// Remove implements OutputBackend.
+func (b *fileOutputBackend) Remove(id string) error {
+	path, err := b.outputPath(id)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (b *fileOutputBackend) outputPath(id string) (string, error) {
+	if id == "" || strings.ContainsAny(id, `/\.`) {
+		return "", fmt.Errorf("invalid job id %q", id)
+	}
+	return filepath.Join(b.dir, id+".log"), nil
+}
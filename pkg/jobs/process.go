@@ -2,30 +2,95 @@ package jobs
 
 import (
 	"context"
+	"errors"
+	"io"
 
 	jobv1 "github.com/kralicky/jobserver/pkg/apis/job/v1"
+	"github.com/kralicky/jobserver/pkg/util"
 )
 
-// Process represents a read-only view of the underlying process of a job that
-// was started by a Runtime, and can be used to query the status of the process,
-// as well as to stream its output.
+// ErrNoTerminal is returned by Process.ResizeTerminal if the process was not
+// started with a terminal.
+var ErrNoTerminal = errors.New("job does not have a terminal")
+
+// ErrNotRunning is returned by Process methods that require the process to
+// be running.
+var ErrNotRunning = errors.New("job is not running")
+
+// ErrUsageUnavailable is returned by Process.Usage if the resource usage of the
+// process cannot be measured.
+var ErrUsageUnavailable = errors.New("resource usage is not available")
+
+// Process represents a view of the underlying process of a job that was
+// started by a Runtime, and can be used to query the status of the process,
+// to stream its output, and (if enabled) to write to its stdin.
 type Process interface {
 	// Returns the unique ID of the process.
 	ID() string
-	// Streams the combined stdout and stderr of the process to the returned
-	// channel. Data will be written to the channel in real time until either the
-	// job terminates or the provided context is canceled, after which the channel
-	// will be closed.
+	// Streams the stdout and stderr of the process to the returned channel.
+	// Each chunk contains data written to a single stream, with the stream
+	// (a jobv1.Stream) as its source. Data will be written to the channel in
+	// real time until either the job terminates or the provided context is
+	// canceled, after which the channel will be closed.
 	// If the job is already terminated when this method is called, the full
 	// output will be written to the channel, and the channel will be closed
 	// immediately.
 	// Each call to this method will return a new independent channel that will
 	// receive a copy of the output. Safe to call concurrently from multiple
 	// goroutines.
-	Output(ctx context.Context) <-chan []byte
+	// If the job's output buffer has discarded any output according to its
+	// retention limits, the amount of discarded output is reported in the
+	// 'Dropped' field of the chunk that follows it.
+	// The output starts at the given offset (the number of bytes of output
+	// written before it, across all streams), and each chunk reports its own
+	// offset. If the offset is past the end of the output written so far, the
+	// output starts at the end.
+	Output(ctx context.Context, offset int64) <-chan util.StreamChunk
+	// Calls fn with the output written by the process before the given
+	// offset, newest first, until fn returns false or the start of the
+	// retained output is reached. Like the chunks received from Output, each
+	// chunk contains data written to a single stream. Output written after
+	// this method is called is not read.
+	ReadOutputBackward(end int64, fn func(util.StreamChunk) bool) error
+	// Returns the total number of bytes of output written by the process so
+	// far, across all streams.
+	OutputSize() int64
 	// Returns the current status of the job. This method is safe to call
 	// concurrently from multiple goroutines.
 	Status() *jobv1.JobStatus
+	// Returns a writer connected to the stdin of the process, or nil if stdin
+	// was not enabled in the job's command spec. Closing the writer closes the
+	// process's stdin; subsequent writes will fail. If the process was started
+	// with a terminal, writes are sent to the terminal, and closing the writer
+	// sends an end-of-file character instead. The writer is safe to use
+	// concurrently from multiple goroutines, and each call to Write is written
+	// to the process's stdin without being interleaved with other writes.
+	Stdin() io.WriteCloser
+	// Resizes the terminal of the process. Returns ErrNoTerminal if the process
+	// was not started with a terminal.
+	ResizeTerminal(size *jobv1.TerminalSize) error
+	// Sends the signal described by the event to the process (or, if the
+	// event's 'all_processes' field is set, to every process in the job). If
+	// the signal is delivered, the event is recorded in the job's status, with
+	// its time set to the time of delivery. Returns ErrNotRunning if the
+	// process is not running.
+	Signal(event *jobv1.SignalEvent) error
+	// Starts stopping the process according to the given options, and marks
+	// the job as stopped by the user. This does not wait for the process to
+	// exit. It can be called again while the process is stopping, for example
+	// to force it to exit immediately. Returns ErrNotRunning if the process is
+	// not running.
+	Stop(opts StopOptions) error
+	// Returns the resources used by the process, as measured by the runtime.
+	// Once the process has exited, returns the last usage measured before the
+	// runtime released the process's resources. Returns ErrUsageUnavailable if
+	// the usage cannot be measured. Safe to call concurrently from multiple
+	// goroutines.
+	Usage() (*jobv1.ResourceUsage, error)
+	// Applies the limits that are set in the given limits to the running
+	// process, leaving its other limits unchanged (see MergeLimits). Returns
+	// ErrNotRunning if the process is not running.
+	UpdateLimits(limits *jobv1.ResourceLimits) error
 	// Returns a channel that will be closed when the job terminates.
 	// Successive calls to Done() will return the same channel.
 	Done() <-chan struct{}
@@ -0,0 +1,56 @@
+package jobs
+
+import (
+	"time"
+
+	jobv1 "github.com/kralicky/jobserver/pkg/apis/job/v1"
+)
+
+const (
+	DefaultRestartInitialBackoff = 1 * time.Second
+	DefaultRestartMaxBackoff     = 5 * time.Minute
+)
+
+// ShouldRestart returns whether a job's process should be restarted
+// according to the job's restart policy, given the final status of the
+// attempt that just completed, and the number of times the process has
+// already been restarted.
+func ShouldRestart(policy *jobv1.RestartPolicy, status *jobv1.JobStatus, restarts uint32) bool {
+	if policy.GetMode() == jobv1.RestartMode_NEVER {
+		return false
+	}
+	if policy != nil && policy.MaxRetries != nil && restarts >= policy.GetMaxRetries() {
+		return false
+	}
+	term := status.GetTerminated()
+	if term.GetStopped() || term.GetDeadlineExceeded() {
+		return false
+	}
+	switch policy.GetMode() {
+	case jobv1.RestartMode_ALWAYS:
+		return true
+	case jobv1.RestartMode_ON_FAILURE:
+		return status.GetState() == jobv1.State_FAILED ||
+			term.GetExitCode() != 0 || term.GetSignal() != 0
+	default:
+		return false
+	}
+}
+
+// RestartBackoff returns how long to wait before restarting a job's process
+// according to the job's restart policy, given the number of times the
+// process has already been restarted.
+func RestartBackoff(policy *jobv1.RestartPolicy, restarts uint32) time.Duration {
+	backoff := DefaultRestartInitialBackoff
+	if policy.GetInitialBackoff() != nil {
+		backoff = policy.GetInitialBackoff().AsDuration()
+	}
+	maxBackoff := max(DefaultRestartMaxBackoff, backoff)
+	if policy.GetMaxBackoff() != nil {
+		maxBackoff = policy.GetMaxBackoff().AsDuration()
+	}
+	for i := uint32(0); i < restarts && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, maxBackoff)
+}
@@ -0,0 +1,85 @@
+package jobs_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	jobv1 "github.com/kralicky/jobserver/pkg/apis/job/v1"
+	"github.com/kralicky/jobserver/pkg/jobs"
+)
+
+func exited(code int32) *jobv1.JobStatus {
+	return &jobv1.JobStatus{
+		State:      jobv1.State_TERMINATED,
+		Terminated: &jobv1.TerminationStatus{ExitCode: code},
+	}
+}
+
+var _ = Describe("Restart", func() {
+	never := &jobv1.RestartPolicy{Mode: jobv1.RestartMode_NEVER}
+	onFailure := &jobv1.RestartPolicy{Mode: jobv1.RestartMode_ON_FAILURE}
+	always := &jobv1.RestartPolicy{Mode: jobv1.RestartMode_ALWAYS}
+	limited := &jobv1.RestartPolicy{Mode: jobv1.RestartMode_ALWAYS, MaxRetries: proto.Uint32(2)}
+
+	DescribeTable("deciding whether to restart",
+		func(policy *jobv1.RestartPolicy, status *jobv1.JobStatus, restarts uint32, expected bool) {
+			Expect(jobs.ShouldRestart(policy, status, restarts)).To(Equal(expected))
+		},
+		Entry("no policy, success", nil, exited(0), uint32(0), false),
+		Entry("no policy, failure", nil, exited(1), uint32(0), false),
+		Entry("never, failure", never, exited(1), uint32(0), false),
+		Entry("on-failure, success", onFailure, exited(0), uint32(0), false),
+		Entry("on-failure, nonzero exit code", onFailure, exited(1), uint32(0), true),
+		Entry("on-failure, killed by a signal", onFailure, &jobv1.JobStatus{
+			State:      jobv1.State_TERMINATED,
+			Terminated: &jobv1.TerminationStatus{Signal: 9},
+		}, uint32(0), true),
+		Entry("on-failure, failed to start", onFailure, &jobv1.JobStatus{State: jobv1.State_FAILED}, uint32(0), true),
+		Entry("always, success", always, exited(0), uint32(0), true),
+		Entry("always, failure", always, exited(1), uint32(5), true),
+		Entry("always, stopped", always, &jobv1.JobStatus{
+			State:      jobv1.State_TERMINATED,
+			Terminated: &jobv1.TerminationStatus{Signal: 15, Stopped: true},
+		}, uint32(0), false),
+		Entry("always, deadline exceeded", always, &jobv1.JobStatus{
+			State:      jobv1.State_TERMINATED,
+			Terminated: &jobv1.TerminationStatus{Signal: 15, DeadlineExceeded: true},
+		}, uint32(0), false),
+		Entry("max retries, below the limit", limited, exited(1), uint32(1), true),
+		Entry("max retries, at the limit", limited, exited(1), uint32(2), false),
+		Entry("max retries of 0", &jobv1.RestartPolicy{
+			Mode:       jobv1.RestartMode_ON_FAILURE,
+			MaxRetries: proto.Uint32(0),
+		}, exited(1), uint32(0), false),
+	)
+
+	DescribeTable("computing the restart backoff",
+		func(policy *jobv1.RestartPolicy, restarts uint32, expected time.Duration) {
+			Expect(jobs.RestartBackoff(policy, restarts)).To(Equal(expected))
+		},
+		Entry("no policy", nil, uint32(0), jobs.DefaultRestartInitialBackoff),
+		Entry("default backoff, first restart", always, uint32(0), 1*time.Second),
+		Entry("default backoff, doubles", always, uint32(3), 8*time.Second),
+		Entry("default backoff, capped", always, uint32(20), jobs.DefaultRestartMaxBackoff),
+		Entry("initial backoff", &jobv1.RestartPolicy{
+			Mode:           jobv1.RestartMode_ALWAYS,
+			InitialBackoff: durationpb.New(100 * time.Millisecond),
+		}, uint32(2), 400*time.Millisecond),
+		Entry("max backoff", &jobv1.RestartPolicy{
+			Mode:       jobv1.RestartMode_ALWAYS,
+			MaxBackoff: durationpb.New(5 * time.Second),
+		}, uint32(10), 5*time.Second),
+		Entry("initial backoff above the default max backoff", &jobv1.RestartPolicy{
+			Mode:           jobv1.RestartMode_ALWAYS,
+			InitialBackoff: durationpb.New(10 * time.Minute),
+		}, uint32(3), 10*time.Minute),
+		Entry("many restarts don't overflow", &jobv1.RestartPolicy{
+			Mode:       jobv1.RestartMode_ALWAYS,
+			MaxBackoff: durationpb.New(time.Hour),
+		}, uint32(1<<31), time.Hour),
+	)
+})
@@ -2,8 +2,10 @@ package jobs
 
 import (
 	"context"
+	"encoding/hex"
 	"errors"
 
+	"github.com/google/uuid"
 	jobv1 "github.com/kralicky/jobserver/pkg/apis/job/v1"
 )
 
@@ -12,11 +14,17 @@ import (
 type Runtime interface {
 	// Creates a new job from the given spec and context, starts it, and returns
 	// a Process interface that can be used to control the underlying process
-	// described by the command in the job spec.
+	// described by the command in the job spec. The id becomes the ID of the
+	// returned Process, and must be unique among all processes created by the
+	// runtime and its output backend (see NewID).
 	//
 	// The context controls the lifetime of the job; if the context is canceled
-	// before the job completes, it will be terminated. Canceling the context
-	// after the job completes has no effect.
+	// before the job completes, it will be stopped according to the stop
+	// policy in its spec (see StopOptionsForSpec). Canceling the context
+	// after the job completes has no effect. Likewise, if the spec has a
+	// timeout or deadline (see DeadlineForSpec), the job is stopped once it is
+	// reached, and the 'deadline_exceeded' field of its termination status is
+	// set to true.
 	//
 	// If the context is canceled with its cause matching ErrStoppedByUser, the
 	// `stopped` field of the returned JobStatus will be set to true. This can
@@ -25,7 +33,17 @@ type Runtime interface {
 	// by the system or by external means.
 	//
 	// This method will return an error if the spec is invalid.
-	Execute(ctx context.Context, spec *jobv1.JobSpec) (Process, error)
+	Execute(ctx context.Context, id string, spec *jobv1.JobSpec) (Process, error)
+}
+
+// NewID generates a new unique job id.
+func NewID() string {
+	// generate a uuid for the job, but encode it in the raw hex format.
+	// this makes it slightly easier to use from the command line, since many
+	// terminals treat '-' as a word separator, making it difficult to copy
+	// the whole string using a double-click.
+	u := uuid.New()
+	return hex.EncodeToString(u[:])
 }
 
 // RuntimeID is an opaque string id that can also be used as a key into LookupRuntime.
@@ -35,13 +53,20 @@ type RuntimeID string
 // was terminated by a signal initiated by the user.
 //
 // Correct usage of this error is such that context.Cause(ctx) matches this
-// error according to [errors.Is], where 'ctx' was the same context passed to
-// [exec.CommandContext] for the process.
+// error according to [errors.Is], where 'ctx' is the job's context that was
+// passed to [Runtime.Execute].
 var ErrStoppedByUser = errors.New("job stopped by user")
 
 var allRuntimes = make(map[RuntimeID]RuntimeBuilder)
 
-type RuntimeBuilder func() (Runtime, error)
+// RuntimeOptions contains configuration that is common to all runtimes.
+type RuntimeOptions struct {
+	// The backend used to store the output of new jobs. If nil, output will
+	// be kept in memory.
+	Output OutputBackend
+}
+
+type RuntimeBuilder func(opts RuntimeOptions) (Runtime, error)
 
 // RegisterRuntime registers a new runtime with the given id. This must only
 // be called from an init() function.
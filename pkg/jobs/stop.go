@@ -0,0 +1,58 @@
+package jobs
+
+import (
+	"syscall"
+	"time"
+
+	jobv1 "github.com/kralicky/jobserver/pkg/apis/job/v1"
+)
+
+const (
+	DefaultStopSignal      = syscall.SIGTERM
+	DefaultStopGracePeriod = 10 * time.Second
+)
+
+// StopOptions control how a process is stopped.
+type StopOptions struct {
+	// The signal sent to the process to ask it to exit.
+	Signal syscall.Signal
+	// How long to wait for the process to exit after sending the signal,
+	// before killing every process in the job.
+	GracePeriod time.Duration
+	// If true, every process in the job is killed immediately, without
+	// sending the signal first.
+	Force bool
+}
+
+// StopOptionsForSpec returns the options used to stop a job with the given
+// spec, according to its stop policy and the defaults.
+func StopOptionsForSpec(spec *jobv1.JobSpec) StopOptions {
+	opts := StopOptions{
+		Signal:      DefaultStopSignal,
+		GracePeriod: DefaultStopGracePeriod,
+	}
+	policy := spec.GetStop()
+	if policy.GetSignal() != 0 {
+		opts.Signal = syscall.Signal(policy.GetSignal())
+	}
+	if policy.GetGracePeriod() != nil {
+		opts.GracePeriod = policy.GetGracePeriod().AsDuration()
+	}
+	return opts
+}
+
+// DeadlineForSpec returns the time at which a job with the given spec, started
+// at the given time, should be stopped according to its timeout or deadline.
+// If the spec has neither, ok is false.
+//
+// The start time is that of the job's current attempt, so each attempt of a
+// restarted job gets the full timeout. A deadline applies to every attempt.
+func DeadlineForSpec(spec *jobv1.JobSpec, startTime time.Time) (deadline time.Time, ok bool) {
+	switch limit := spec.GetMaxRuntime().(type) {
+	case *jobv1.JobSpec_Timeout:
+		return startTime.Add(limit.Timeout.AsDuration()), true
+	case *jobv1.JobSpec_Deadline:
+		return limit.Deadline.AsTime(), true
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,39 @@
+package jobs_test
+
+import (
+	"time"
+
+	jobv1 "github.com/kralicky/jobserver/pkg/apis/job/v1"
+	"github.com/kralicky/jobserver/pkg/jobs"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var _ = Describe("DeadlineForSpec", func() {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	deadline := time.Date(2024, 1, 1, 13, 0, 0, 0, time.UTC)
+
+	DescribeTable("should return when the job should be stopped",
+		func(spec *jobv1.JobSpec, startTime time.Time, expected time.Time, expectedOk bool) {
+			actual, ok := jobs.DeadlineForSpec(spec, startTime)
+			Expect(ok).To(Equal(expectedOk))
+			Expect(actual).To(BeTemporally("==", expected))
+		},
+		Entry("nil spec", nil, start, time.Time{}, false),
+		Entry("no timeout or deadline", &jobv1.JobSpec{}, start, time.Time{}, false),
+		Entry("timeout",
+			&jobv1.JobSpec{MaxRuntime: &jobv1.JobSpec_Timeout{Timeout: durationpb.New(10 * time.Minute)}},
+			start, start.Add(10*time.Minute), true),
+		Entry("timeout of a later attempt",
+			&jobv1.JobSpec{MaxRuntime: &jobv1.JobSpec_Timeout{Timeout: durationpb.New(10 * time.Minute)}},
+			start.Add(time.Hour), start.Add(time.Hour+10*time.Minute), true),
+		Entry("deadline",
+			&jobv1.JobSpec{MaxRuntime: &jobv1.JobSpec_Deadline{Deadline: timestamppb.New(deadline)}},
+			start, deadline, true),
+		Entry("deadline of a later attempt",
+			&jobv1.JobSpec{MaxRuntime: &jobv1.JobSpec_Deadline{Deadline: timestamppb.New(deadline)}},
+			start.Add(30*time.Minute), deadline, true),
+	)
+})
@@ -0,0 +1,160 @@
+// Package labels validates the labels attached to jobs, and implements label
+// selectors, which match jobs by their labels.
+package labels
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// The maximum number of labels a job can have.
+const MaxLabels = 64
+
+var (
+	keyRegex   = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9_./-]{0,61}[A-Za-z0-9])?$`)
+	valueRegex = regexp.MustCompile(`^([A-Za-z0-9]([A-Za-z0-9_.-]{0,61}[A-Za-z0-9])?)?$`)
+)
+
+// ValidateKey checks that the given string is a valid label key. Keys may
+// contain up to 63 letters, digits, '-', '_', '.', or '/', and must start and
+// end with a letter or digit.
+func ValidateKey(key string) error {
+	if !keyRegex.MatchString(key) {
+		return fmt.Errorf("invalid label key %q", key)
+	}
+	return nil
+}
+
+// ValidateValue checks that the given string is a valid label value. Values
+// may be empty, or contain up to 63 letters, digits, '-', '_', or '.', and
+// must start and end with a letter or digit.
+func ValidateValue(value string) error {
+	if !valueRegex.MatchString(value) {
+		return fmt.Errorf("invalid label value %q", value)
+	}
+	return nil
+}
+
+// Validate checks that all of the given labels have valid keys and values,
+// and that there are no more than MaxLabels of them.
+func Validate(labels map[string]string) error {
+	if len(labels) > MaxLabels {
+		return fmt.Errorf("too many labels (max %d)", MaxLabels)
+	}
+	for k, v := range labels {
+		if err := ValidateKey(k); err != nil {
+			return err
+		}
+		if err := ValidateValue(v); err != nil {
+			return fmt.Errorf("label %q: %w", k, err)
+		}
+	}
+	return nil
+}
+
+type operator int
+
+const (
+	opEquals operator = iota
+	opNotEquals
+	opExists
+	opNotExists
+)
+
+type requirement struct {
+	key   string
+	op    operator
+	value string
+}
+
+func (r requirement) matches(labels map[string]string) bool {
+	value, ok := labels[r.key]
+	switch r.op {
+	case opEquals:
+		return ok && value == r.value
+	case opNotEquals:
+		return !ok || value != r.value
+	case opExists:
+		return ok
+	case opNotExists:
+		return !ok
+	default:
+		return false
+	}
+}
+
+// Selector matches a set of labels against a list of requirements. A set of
+// labels matches the selector if it matches all of its requirements.
+type Selector []requirement
+
+// ParseSelector parses a label selector, which is a comma-separated list of
+// requirements. Each requirement has one of the following forms:
+//
+//	key=value   the label is set to the given value ('==' is also accepted)
+//	key!=value  the label is not set, or is set to a different value
+//	key         the label is set, to any value
+//	!key        the label is not set
+//
+// An empty selector matches all labels.
+func ParseSelector(selector string) (Selector, error) {
+	var s Selector
+	if strings.TrimSpace(selector) == "" {
+		return s, nil
+	}
+	for _, part := range strings.Split(selector, ",") {
+		r, err := parseRequirement(strings.TrimSpace(part))
+		if err != nil {
+			return nil, fmt.Errorf("invalid label selector %q: %w", selector, err)
+		}
+		s = append(s, r)
+	}
+	return s, nil
+}
+
+func parseRequirement(part string) (requirement, error) {
+	var r requirement
+	switch {
+	case strings.Contains(part, "!="):
+		r.op = opNotEquals
+		r.key, r.value, _ = strings.Cut(part, "!=")
+	case strings.Contains(part, "=="):
+		r.op = opEquals
+		r.key, r.value, _ = strings.Cut(part, "==")
+	case strings.Contains(part, "="):
+		r.op = opEquals
+		r.key, r.value, _ = strings.Cut(part, "=")
+	case strings.HasPrefix(part, "!"):
+		r.op = opNotExists
+		r.key = part[1:]
+	default:
+		r.op = opExists
+		r.key = part
+	}
+	r.key = strings.TrimSpace(r.key)
+	r.value = strings.TrimSpace(r.value)
+	if err := ValidateKey(r.key); err != nil {
+		return r, err
+	}
+	if err := ValidateValue(r.value); err != nil {
+		return r, err
+	}
+	return r, nil
+}
+
+// Matches returns whether the given labels match all of the selector's
+// requirements.
+func (s Selector) Matches(labels map[string]string) bool {
+	for _, r := range s {
+		if !r.matches(labels) {
+			return false
+		}
+	}
+	return true
+}
+
+// Empty returns whether the selector has no requirements, and therefore
+// matches all labels.
+func (s Selector) Empty() bool {
+	return len(s) == 0
+}
@@ -0,0 +1,82 @@
+package labels_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/kralicky/jobserver/pkg/labels"
+)
+
+var _ = Describe("Labels", func() {
+	DescribeTable("validating labels",
+		func(lbls map[string]string, valid bool) {
+			err := labels.Validate(lbls)
+			if valid {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(HaveOccurred())
+			}
+		},
+		Entry("no labels", nil, true),
+		Entry("simple labels", map[string]string{"team": "infra", "env": "prod"}, true),
+		Entry("empty value", map[string]string{"team": ""}, true),
+		Entry("prefixed key", map[string]string{"example.com/team": "infra"}, true),
+		Entry("punctuation", map[string]string{"a_b-c.d": "e_f-g.h"}, true),
+		Entry("empty key", map[string]string{"": "infra"}, false),
+		Entry("key starting with punctuation", map[string]string{"-team": "infra"}, false),
+		Entry("key with spaces", map[string]string{"my team": "infra"}, false),
+		Entry("key too long", map[string]string{fmt.Sprintf("%064d", 0): "infra"}, false),
+		Entry("value with a slash", map[string]string{"team": "a/b"}, false),
+		Entry("value ending with punctuation", map[string]string{"team": "infra-"}, false),
+		Entry("value too long", map[string]string{"team": fmt.Sprintf("%064d", 0)}, false),
+	)
+	It("should limit the number of labels", func() {
+		lbls := map[string]string{}
+		for i := 0; i < labels.MaxLabels+1; i++ {
+			lbls[fmt.Sprintf("key%d", i)] = "value"
+		}
+		Expect(labels.Validate(lbls)).NotTo(Succeed())
+	})
+})
+
+var _ = Describe("Selector", func() {
+	lbls := map[string]string{
+		"team": "infra",
+		"env":  "staging",
+	}
+	DescribeTable("matching labels",
+		func(selector string, matches bool) {
+			s, err := labels.ParseSelector(selector)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(s.Matches(lbls)).To(Equal(matches))
+		},
+		Entry("empty selector", "", true),
+		Entry("equals", "team=infra", true),
+		Entry("double equals", "team==infra", true),
+		Entry("equals, different value", "team=web", false),
+		Entry("equals, missing label", "owner=alice", false),
+		Entry("not equals", "env!=prod", true),
+		Entry("not equals, same value", "env!=staging", false),
+		Entry("not equals, missing label", "owner!=alice", true),
+		Entry("exists", "team", true),
+		Entry("exists, missing label", "owner", false),
+		Entry("not exists", "!owner", true),
+		Entry("not exists, existing label", "!team", false),
+		Entry("multiple requirements", "team=infra,env!=prod", true),
+		Entry("multiple requirements with spaces", " team = infra , env != prod ", true),
+		Entry("multiple requirements, one not matching", "team=infra,env=prod", false),
+	)
+	DescribeTable("rejecting invalid selectors",
+		func(selector string) {
+			_, err := labels.ParseSelector(selector)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("empty requirement", "team=infra,"),
+		Entry("missing key", "=infra"),
+		Entry("invalid key", "te am=infra"),
+		Entry("invalid value", "team=in/fra"),
+		Entry("missing key after '!'", "!"),
+	)
+})
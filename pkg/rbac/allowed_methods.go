@@ -42,31 +42,58 @@ func (h *middleware) Eval(ctx context.Context) (context.Context, error) {
 	if !ok {
 		panic("bug: grpc method not found in context")
 	}
+	return authorize(ctx, h.config, user, fullMethodName)
+}
+
+// NewContextForUser returns a context for a call to the given method by the
+// given user, with the permissions the config grants the user, in the same
+// way as the middleware. It can be used to check the permissions of a user
+// outside of a request. Returns a PermissionDenied error if the user is not
+// allowed to call the method.
+func NewContextForUser(ctx context.Context, config *rbacv1.Config, user auth.AuthenticatedUser, fullMethodName string) (context.Context, error) {
+	return authorize(auth.NewContextWithUser(ctx, user), config, user, fullMethodName)
+}
 
+func authorize(ctx context.Context, config *rbacv1.Config, user auth.AuthenticatedUser, fullMethodName string) (context.Context, error) {
 	serviceName, methodName, ok := util.SplitFullyQualifiedMethodName(fullMethodName)
 	if !ok {
 		panic("bug: method name is not fully qualified")
 	}
 	// find roles that are bound to the subject
 	roleIds := make(map[string]struct{})
-	for _, rb := range h.config.GetRoleBindings() {
+	for _, rb := range config.GetRoleBindings() {
 		if slices.Contains(rb.GetUsers(), string(user)) {
 			roleIds[rb.GetRoleId()] = struct{}{}
 		}
 	}
-	// for each matching role, check if it contains the method
-	for _, role := range h.config.GetRoles() {
+	// for each matching role, check if it contains the method, and collect
+	// the identities the user is allowed to use
+	var allowedMethod *rbacv1.AllowedMethod
+	var allowedIdentities []*rbacv1.Identity
+	for _, role := range config.GetRoles() {
 		if _, ok := roleIds[role.GetId()]; !ok {
 			continue
 		}
 		if role.GetService() != serviceName {
 			continue
 		}
+		for _, id := range role.GetAllowedIdentities() {
+			allowedIdentities = append(allowedIdentities, proto.Clone(id).(*rbacv1.Identity))
+		}
+		if allowedMethod != nil {
+			continue
+		}
 		for _, m := range role.GetAllowedMethods() {
 			if m.GetName() == methodName {
-				return context.WithValue(ctx, allowedMethodKey, proto.Clone(m).(*rbacv1.AllowedMethod)), nil
+				allowedMethod = proto.Clone(m).(*rbacv1.AllowedMethod)
+				break
 			}
 		}
 	}
-	return ctx, status.Errorf(codes.PermissionDenied, "user %q is not authorized for method %q", user, fullMethodName)
+	if allowedMethod == nil {
+		return ctx, status.Errorf(codes.PermissionDenied, "user %q is not authorized for method %q", user, fullMethodName)
+	}
+	ctx = context.WithValue(ctx, allowedMethodKey, allowedMethod)
+	ctx = context.WithValue(ctx, allowedIdentitiesKey, allowedIdentities)
+	return ctx, nil
 }
@@ -147,3 +147,39 @@ var _ = Describe("AllowedMethods Middleware", func() {
 		})
 	})
 })
+
+var _ = Describe("NewContextForUser", func() {
+	const clientUser = "client-user"
+	rbacConfig := &rbacv1.Config{
+		Roles: []*rbacv1.Role{
+			{
+				Id:             "test-role",
+				Service:        "foo.bar.Example",
+				AllowedMethods: []*rbacv1.AllowedMethod{{Name: "Test"}},
+				AllowedIdentities: []*rbacv1.Identity{
+					{Uids: []uint32{1000}, Gids: []uint32{1000}},
+				},
+			},
+		},
+		RoleBindings: []*rbacv1.RoleBinding{
+			{
+				Id:     "test-role-binding",
+				RoleId: "test-role",
+				Users:  []string{clientUser},
+			},
+		},
+	}
+	It("should return a context with the user's permissions", func() {
+		ctx, err := rbac.NewContextForUser(context.Background(), rbacConfig, clientUser, "/foo.bar.Example/Test")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(auth.AuthenticatedUserFromContext(ctx)).To(BeEquivalentTo(clientUser))
+		Expect(rbac.AllowedMethodFromContext(ctx)).To(BeEquivalentTo(rbacConfig.Roles[0].AllowedMethods[0]))
+		Expect(rbac.VerifyIdentityForUser(ctx, 1000, 1000)).To(Succeed())
+	})
+	It("should return a PermissionDenied error if the user is not allowed the method", func() {
+		_, err := rbac.NewContextForUser(context.Background(), rbacConfig, clientUser, "/foo.bar.Example/Other")
+		Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+		_, err = rbac.NewContextForUser(context.Background(), rbacConfig, "other-user", "/foo.bar.Example/Test")
+		Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+	})
+})
@@ -0,0 +1,46 @@
+package rbac
+
+import (
+	"context"
+	"slices"
+
+	rbacv1 "github.com/kralicky/jobserver/pkg/apis/rbac/v1"
+	"github.com/kralicky/jobserver/pkg/auth"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type allowedIdentitiesKeyType struct{}
+
+var allowedIdentitiesKey = allowedIdentitiesKeyType{}
+
+// AllowedIdentitiesFromContext returns the identities allowed by all of the
+// authenticated user's roles for the service being called.
+func AllowedIdentitiesFromContext(ctx context.Context) []*rbacv1.Identity {
+	v, ok := ctx.Value(allowedIdentitiesKey).([]*rbacv1.Identity)
+	if !ok {
+		panic("bug: allowed identities not found in context (required middleware not configured)")
+	}
+	return v
+}
+
+// VerifyIdentityForUser verifies that the authenticated user in the context
+// is allowed to run jobs as the given uid, with the given primary and
+// supplementary group ids. The identity is allowed if a single identity
+// allowed by one of the user's roles contains the uid and every gid.
+func VerifyIdentityForUser(ctx context.Context, uid uint32, gids ...uint32) error {
+	user := auth.AuthenticatedUserFromContext(ctx)
+outer:
+	for _, id := range AllowedIdentitiesFromContext(ctx) {
+		if !slices.Contains(id.GetUids(), uid) {
+			continue
+		}
+		for _, gid := range gids {
+			if !slices.Contains(id.GetGids(), gid) {
+				continue outer
+			}
+		}
+		return nil
+	}
+	return status.Errorf(codes.PermissionDenied, "user %q is not allowed to run jobs as uid %d with gids %v", user, uid, gids)
+}
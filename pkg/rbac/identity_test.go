@@ -0,0 +1,121 @@
+package rbac_test
+
+import (
+	"context"
+
+	rbacv1 "github.com/kralicky/jobserver/pkg/apis/rbac/v1"
+	"github.com/kralicky/jobserver/pkg/auth"
+	"github.com/kralicky/jobserver/pkg/rbac"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ = Describe("Identity", func() {
+	var ctx context.Context
+	var rbacConfig *rbacv1.Config
+	const clientUser = "client-user"
+	BeforeEach(func() {
+		rbacConfig = &rbacv1.Config{
+			Roles: []*rbacv1.Role{
+				{
+					Id:             "test-role",
+					Service:        "foo.bar.Example",
+					AllowedMethods: []*rbacv1.AllowedMethod{{Name: "Test"}},
+					AllowedIdentities: []*rbacv1.Identity{
+						{Uids: []uint32{1000}, Gids: []uint32{1000, 100}},
+					},
+				},
+				{
+					Id:      "other-role",
+					Service: "foo.bar.Example",
+					AllowedIdentities: []*rbacv1.Identity{
+						{Uids: []uint32{1001, 1002}, Gids: []uint32{1001}},
+					},
+				},
+				{
+					Id:      "other-service-role",
+					Service: "foo.bar.OtherService",
+					AllowedIdentities: []*rbacv1.Identity{
+						{Uids: []uint32{0}, Gids: []uint32{0}},
+					},
+				},
+			},
+			RoleBindings: []*rbacv1.RoleBinding{
+				{
+					Id:     "test-role-binding",
+					RoleId: "test-role",
+					Users:  []string{clientUser},
+				},
+				{
+					Id:     "other-role-binding",
+					RoleId: "other-role",
+					Users:  []string{clientUser},
+				},
+				{
+					Id:     "other-service-role-binding",
+					RoleId: "other-service-role",
+					Users:  []string{clientUser},
+				},
+			},
+		}
+	})
+	JustBeforeEach(func() {
+		streamCtx := grpc.NewContextWithServerTransportStream(
+			context.Background(),
+			&testServerTransportStream{
+				method: "/foo.bar.Example/Test",
+			},
+		)
+		authCtx, err := auth.NewMiddleware(&testAuthenticator{
+			user: clientUser,
+		}).Eval(streamCtx)
+		Expect(err).NotTo(HaveOccurred())
+		ctx, err = rbac.NewAllowedMethodsMiddleware(rbacConfig).Eval(authCtx)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should collect identities from all of the user's roles for the service", func() {
+		Expect(rbac.AllowedIdentitiesFromContext(ctx)).To(HaveLen(2))
+	})
+
+	When("the identity is allowed by one of the user's roles", func() {
+		It("should allow the identity", func() {
+			Expect(rbac.VerifyIdentityForUser(ctx, 1000, 1000)).To(Succeed())
+			Expect(rbac.VerifyIdentityForUser(ctx, 1000, 1000, 100)).To(Succeed())
+			Expect(rbac.VerifyIdentityForUser(ctx, 1002, 1001)).To(Succeed())
+		})
+	})
+	When("the identity is not allowed by any of the user's roles", func() {
+		It("should return a PermissionDenied error", func() {
+			err := rbac.VerifyIdentityForUser(ctx, 1003, 1001)
+			Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+		})
+	})
+	When("the identity is only allowed by a role for a different service", func() {
+		It("should return a PermissionDenied error", func() {
+			err := rbac.VerifyIdentityForUser(ctx, 0, 0)
+			Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+		})
+	})
+	When("the groups are allowed by different identities than the uid", func() {
+		It("should return a PermissionDenied error", func() {
+			err := rbac.VerifyIdentityForUser(ctx, 1000, 1000, 1001)
+			Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+		})
+	})
+	When("the user's roles do not allow any identities", func() {
+		BeforeEach(func() {
+			for _, role := range rbacConfig.Roles {
+				role.AllowedIdentities = nil
+			}
+		})
+		It("should return a PermissionDenied error", func() {
+			Expect(rbac.AllowedIdentitiesFromContext(ctx)).To(BeEmpty())
+			err := rbac.VerifyIdentityForUser(ctx, 1000, 1000)
+			Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+		})
+	})
+})
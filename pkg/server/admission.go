@@ -0,0 +1,118 @@
+package server
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/kralicky/jobserver/pkg/auth"
+)
+
+// AdmissionPolicy limits the number of jobs that can run at the same time.
+// Jobs that are started while a limit is reached wait in the Pending state,
+// and are started in the order in which they were submitted once enough
+// running jobs have completed. A zero value for any of the fields disables
+// the corresponding limit.
+//
+// A job counts as running from the time it is started until it completes,
+// including any time spent waiting to be restarted.
+type AdmissionPolicy struct {
+	// The maximum number of jobs that can run at the same time.
+	MaxRunning int
+	// The maximum number of jobs that each user can run at the same time.
+	MaxRunningPerUser int
+}
+
+// admissionQueue tracks the number of running jobs, and the jobs waiting to
+// be started. It never locks a job's mutex, so it can be called by a job
+// while holding its own mutex.
+type admissionQueue struct {
+	policy AdmissionPolicy
+
+	mu             sync.Mutex
+	running        int
+	runningPerUser map[auth.AuthenticatedUser]int
+	pending        []*jobInfo // oldest first
+}
+
+func newAdmissionQueue(policy AdmissionPolicy) *admissionQueue {
+	return &admissionQueue{
+		policy:         policy,
+		runningPerUser: make(map[auth.AuthenticatedUser]int),
+	}
+}
+
+// fits returns whether a job owned by the given user can be started without
+// exceeding the limits. The caller must hold q.mu.
+func (q *admissionQueue) fits(owner auth.AuthenticatedUser) bool {
+	if q.policy.MaxRunning > 0 && q.running >= q.policy.MaxRunning {
+		return false
+	}
+	if q.policy.MaxRunningPerUser > 0 && q.runningPerUser[owner] >= q.policy.MaxRunningPerUser {
+		return false
+	}
+	return true
+}
+
+// admitLocked counts the job as running, and signals that it can be started.
+// The caller must hold q.mu.
+func (q *admissionQueue) admitLocked(job *jobInfo) {
+	q.running++
+	q.runningPerUser[job.owner]++
+	close(job.admitted)
+}
+
+// submit admits the job if it fits within the limits, or adds it to the end
+// of the queue otherwise. Returns true if the job was admitted.
+//
+// Every job already in the queue is waiting for a limit that this job would
+// also exceed, or for a per-user limit of a different user, so a job that
+// fits can be admitted ahead of them.
+func (q *admissionQueue) submit(job *jobInfo) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.fits(job.owner) {
+		q.admitLocked(job)
+		return true
+	}
+	q.pending = append(q.pending, job)
+	return false
+}
+
+// release is called once a job that was admitted has completed. Queued jobs
+// that now fit within the limits are admitted, in order.
+func (q *admissionQueue) release(owner auth.AuthenticatedUser) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.running--
+	if q.runningPerUser[owner]--; q.runningPerUser[owner] <= 0 {
+		delete(q.runningPerUser, owner)
+	}
+	q.pending = slices.DeleteFunc(q.pending, func(job *jobInfo) bool {
+		if !q.fits(job.owner) {
+			return false
+		}
+		q.admitLocked(job)
+		return true
+	})
+}
+
+// cancel removes a job from the queue. Returns false if the job is not in
+// the queue (for example, because it was already admitted).
+func (q *admissionQueue) cancel(job *jobInfo) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	i := slices.Index(q.pending, job)
+	if i < 0 {
+		return false
+	}
+	q.pending = slices.Delete(q.pending, i, i+1)
+	return true
+}
+
+// position returns the position of the job in the queue, starting at 1, or 0
+// if the job is not in the queue.
+func (q *admissionQueue) position(job *jobInfo) int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return slices.Index(q.pending, job) + 1
+}
@@ -0,0 +1,121 @@
+package server
+
+import (
+	"github.com/kralicky/jobserver/pkg/auth"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("admissionQueue", func() {
+	newJob := func(owner auth.AuthenticatedUser) *jobInfo {
+		return &jobInfo{owner: owner, admitted: make(chan struct{})}
+	}
+	admitted := func(job *jobInfo) bool {
+		select {
+		case <-job.admitted:
+			return true
+		default:
+			return false
+		}
+	}
+
+	It("should admit queued jobs in the order they were submitted", func() {
+		q := newAdmissionQueue(AdmissionPolicy{MaxRunning: 1})
+		a, b, c := newJob(testUser), newJob(otherUser), newJob(testUser)
+		Expect(q.submit(a)).To(BeTrue())
+		Expect(q.submit(b)).To(BeFalse())
+		Expect(q.submit(c)).To(BeFalse())
+		Expect(admitted(a)).To(BeTrue())
+		Expect(admitted(b)).To(BeFalse())
+
+		q.release(a.owner)
+		Expect(admitted(b)).To(BeTrue())
+		Expect(admitted(c)).To(BeFalse())
+
+		q.release(b.owner)
+		Expect(admitted(c)).To(BeTrue())
+		Expect(q.pending).To(BeEmpty())
+	})
+
+	It("should skip queued jobs whose owner has reached their limit", func() {
+		q := newAdmissionQueue(AdmissionPolicy{MaxRunning: 2, MaxRunningPerUser: 1})
+		a, b, c, d := newJob(testUser), newJob(testUser), newJob(otherUser), newJob(otherUser)
+		Expect(q.submit(a)).To(BeTrue())
+		Expect(q.submit(b)).To(BeFalse())
+		Expect(q.submit(c)).To(BeTrue())
+		Expect(q.submit(d)).To(BeFalse())
+
+		// b is first in the queue, but its owner is still running a
+		q.release(c.owner)
+		Expect(admitted(b)).To(BeFalse())
+		Expect(admitted(d)).To(BeTrue())
+		Expect(q.position(b)).To(Equal(1))
+
+		q.release(a.owner)
+		Expect(admitted(b)).To(BeTrue())
+	})
+
+	It("should admit new jobs ahead of queued jobs that are waiting for another user's limit", func() {
+		q := newAdmissionQueue(AdmissionPolicy{MaxRunningPerUser: 1})
+		a, b := newJob(testUser), newJob(testUser)
+		Expect(q.submit(a)).To(BeTrue())
+		Expect(q.submit(b)).To(BeFalse())
+
+		c := newJob(otherUser)
+		Expect(q.submit(c)).To(BeTrue())
+		Expect(q.position(b)).To(Equal(1))
+	})
+
+	It("should not admit new jobs ahead of queued jobs that are waiting for the global limit", func() {
+		q := newAdmissionQueue(AdmissionPolicy{MaxRunning: 1, MaxRunningPerUser: 1})
+		a, b := newJob(testUser), newJob(otherUser)
+		Expect(q.submit(a)).To(BeTrue())
+		Expect(q.submit(b)).To(BeFalse())
+
+		c := newJob(testAdmin)
+		Expect(q.submit(c)).To(BeFalse())
+		Expect(q.position(b)).To(Equal(1))
+		Expect(q.position(c)).To(Equal(2))
+	})
+
+	It("should remove canceled jobs from the queue", func() {
+		q := newAdmissionQueue(AdmissionPolicy{MaxRunning: 1})
+		a, b, c := newJob(testUser), newJob(testUser), newJob(testUser)
+		Expect(q.submit(a)).To(BeTrue())
+		Expect(q.submit(b)).To(BeFalse())
+		Expect(q.submit(c)).To(BeFalse())
+
+		Expect(q.cancel(b)).To(BeTrue())
+		Expect(q.cancel(b)).To(BeFalse())
+		Expect(q.cancel(a)).To(BeFalse(), "a was already admitted")
+		Expect(q.position(b)).To(BeZero())
+		Expect(q.position(c)).To(Equal(1))
+
+		q.release(a.owner)
+		Expect(admitted(b)).To(BeFalse())
+		Expect(admitted(c)).To(BeTrue())
+	})
+
+	It("should report the position of queued jobs", func() {
+		q := newAdmissionQueue(AdmissionPolicy{MaxRunning: 1})
+		jobs := []*jobInfo{newJob(testUser), newJob(testUser), newJob(otherUser), newJob(testUser)}
+		for _, job := range jobs {
+			q.submit(job)
+		}
+		Expect(q.position(jobs[0])).To(BeZero(), "running jobs are not in the queue")
+		for i, job := range jobs[1:] {
+			Expect(q.position(job)).To(Equal(i + 1))
+		}
+		q.release(jobs[0].owner)
+		Expect(q.position(jobs[1])).To(BeZero())
+		Expect(q.position(jobs[2])).To(Equal(1))
+		Expect(q.position(jobs[3])).To(Equal(2))
+	})
+
+	It("should not limit anything with a zero policy", func() {
+		q := newAdmissionQueue(AdmissionPolicy{})
+		for i := 0; i < 100; i++ {
+			Expect(q.submit(newJob(testUser))).To(BeTrue())
+		}
+	})
+})
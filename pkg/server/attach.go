@@ -0,0 +1,110 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+
+	jobv1 "github.com/kralicky/jobserver/pkg/apis/job/v1"
+	"github.com/kralicky/jobserver/pkg/jobs"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Attach implements v1.JobServer.
+func (s *Server) Attach(stream jobv1.Job_AttachServer) error {
+	first, err := stream.Recv()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return status.Error(codes.InvalidArgument, "expected a job id")
+		}
+		return err
+	}
+	id := first.GetId()
+	if id == nil {
+		return status.Error(codes.InvalidArgument, "the first message must contain a job id")
+	}
+	job, err := s.lookupScoped(stream.Context(), id)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancelCause(stream.Context())
+	defer cancel(nil)
+	go func() {
+		if err := forwardInput(job, stream); err != nil {
+			cancel(err)
+		}
+	}()
+
+	if err := sendOutput(ctx, job, outputOptions{}, stream.Send); err != nil {
+		return err
+	}
+	if err := context.Cause(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+// forwardInput writes input received from the client to the job's stdin,
+// until the client closes the request stream. Input received after the job
+// has terminated is discarded.
+func forwardInput(job *jobInfo, stream jobv1.Job_AttachServer) error {
+	// wait for the job to leave the admission queue, so that input is not
+	// rejected before the job has a stdin
+	if err := job.waitStarted(stream.Context()); err != nil {
+		return nil
+	}
+	for {
+		req, err := stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		stdin := job.Stdin()
+		switch req := req.GetRequest().(type) {
+		case *jobv1.AttachRequest_Input:
+			if stdin == nil {
+				return status.Errorf(codes.FailedPrecondition, "job %s does not accept input", job.ID())
+			}
+			if _, err := stdin.Write(req.Input); err != nil && !isDone(job) {
+				return status.Errorf(codes.FailedPrecondition, "failed to write to stdin of job %s: %v", job.ID(), err)
+			}
+		case *jobv1.AttachRequest_CloseStdin:
+			if !req.CloseStdin {
+				continue
+			}
+			if stdin == nil {
+				return status.Errorf(codes.FailedPrecondition, "job %s does not accept input", job.ID())
+			}
+			stdin.Close()
+		case *jobv1.AttachRequest_Resize:
+			rows, cols := req.Resize.GetRows(), req.Resize.GetCols()
+			if rows == 0 || cols == 0 || rows > math.MaxUint16 || cols > math.MaxUint16 {
+				return status.Errorf(codes.InvalidArgument, "invalid terminal size %dx%d", cols, rows)
+			}
+			if err := job.ResizeTerminal(req.Resize); err != nil {
+				if errors.Is(err, jobs.ErrNoTerminal) {
+					return status.Errorf(codes.FailedPrecondition, "job %s does not have a terminal", job.ID())
+				}
+				if !isDone(job) && !errors.Is(err, jobs.ErrNotRunning) {
+					return status.Errorf(codes.Internal, "failed to resize terminal of job %s: %v", job.ID(), err)
+				}
+			}
+		case *jobv1.AttachRequest_Id:
+			return status.Error(codes.InvalidArgument, "job id can only be sent in the first message")
+		}
+	}
+}
+
+func isDone(job *jobInfo) bool {
+	select {
+	case <-job.Done():
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,529 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+	"time"
+
+	jobv1 "github.com/kralicky/jobserver/pkg/apis/job/v1"
+	"github.com/kralicky/jobserver/pkg/auth"
+	"github.com/kralicky/jobserver/pkg/jobs"
+	"github.com/kralicky/jobserver/pkg/util"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// The number of previous attempts kept for each job. Once a job has been
+// restarted more times than this, the output of its oldest attempts is
+// removed.
+const maxPreviousAttempts = 10
+
+// attemptID returns the id of the process for the given attempt of a job.
+// The first attempt uses the id of the job itself, so that the output of jobs
+// that are never restarted is stored under the job's id.
+func attemptID(jobID string, attempt uint32) string {
+	if attempt == 0 {
+		return jobID
+	}
+	return fmt.Sprintf("%s-%d", jobID, attempt)
+}
+
+type previousAttempt struct {
+	status *jobv1.AttemptStatus
+	proc   jobs.Process
+}
+
+// jobInfo tracks a job and each of its attempts. Every attempt is a separate
+// process created by the runtime. jobInfo implements jobs.Process by
+// delegating to the job's current attempt, except that it is only done once
+// the job will not be restarted again.
+type jobInfo struct {
+	id     string
+	owner  auth.AuthenticatedUser
+	spec   *jobv1.JobSpec
+	cancel context.CancelCauseFunc // cancels the context of the job's processes once it is done
+	queue  *admissionQueue         // nil for restored jobs
+	// the id of the schedule that started the job, if any
+	scheduleID string
+
+	mu            sync.Mutex
+	current       jobs.Process // nil while pending, or while waiting to restart
+	attempt       uint32
+	previous      []previousAttempt     // oldest first
+	restartAt     time.Time             // zero unless waiting to restart
+	limits        *jobv1.ResourceLimits // the effective limits; see UpdateLimits
+	stopRequested bool
+	canceled      bool          // true if stopped while in the admission queue
+	stopC         chan struct{} // closed once stopRequested is set
+	admitted      chan struct{} // closed once the job leaves the admission queue
+	started       chan struct{} // closed once the first attempt's process is set
+	done          chan struct{}
+
+	finishedMu sync.Mutex
+	finished   time.Time // zero until the job is done
+
+	// serializes writes of the job's record to the store, so that an older
+	// status never overwrites a newer one, and a deleted job is never
+	// written back to the store
+	persistMu sync.Mutex
+	deleted   bool
+}
+
+func newJobInfo(id string, owner auth.AuthenticatedUser, spec *jobv1.JobSpec, cancel context.CancelCauseFunc) *jobInfo {
+	return &jobInfo{
+		id:       id,
+		owner:    owner,
+		spec:     spec,
+		limits:   spec.GetLimits(),
+		cancel:   cancel,
+		stopC:    make(chan struct{}),
+		admitted: make(chan struct{}),
+		started:  make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// Returns the time at which the job was observed to be done, and whether it
+// is done.
+func (j *jobInfo) finishedAt() (time.Time, bool) {
+	j.finishedMu.Lock()
+	defer j.finishedMu.Unlock()
+	return j.finished, !j.finished.IsZero()
+}
+
+func (j *jobInfo) setFinished(t time.Time) {
+	j.finishedMu.Lock()
+	defer j.finishedMu.Unlock()
+	j.finished = t
+}
+
+// ID implements jobs.Process.
+func (j *jobInfo) ID() string {
+	return j.id
+}
+
+// pendingLocked returns whether the job's first attempt has not been started
+// yet. The caller must hold j.mu.
+func (j *jobInfo) pendingLocked() bool {
+	return j.current == nil && len(j.previous) == 0
+}
+
+// latest returns the process of the job's latest attempt that was started.
+// The caller must hold j.mu, and the job must not be pending.
+func (j *jobInfo) latest() jobs.Process {
+	if j.current != nil {
+		return j.current
+	}
+	return j.previous[len(j.previous)-1].proc
+}
+
+// waitStarted waits until the job's first attempt has been started (or the
+// job was canceled before it could be started).
+func (j *jobInfo) waitStarted(ctx context.Context) error {
+	select {
+	case <-j.started:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// attemptProcess returns the process of the given attempt, if it is the
+// current attempt or one of the previous attempts that are still kept.
+func (j *jobInfo) attemptProcess(attempt uint32) (jobs.Process, bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if attempt == j.attempt && j.current != nil {
+		return j.current, true
+	}
+	for _, a := range j.previous {
+		if a.status.GetAttempt() == attempt {
+			return a.proc, true
+		}
+	}
+	return nil, false
+}
+
+// Output implements jobs.Process. The output of the job's latest attempt is
+// streamed. If the job is pending, the output is streamed once it starts.
+func (j *jobInfo) Output(ctx context.Context, offset int64) <-chan util.StreamChunk {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if !j.pendingLocked() {
+		return j.latest().Output(ctx, offset)
+	}
+	c := make(chan util.StreamChunk)
+	go func() {
+		defer close(c)
+		if err := j.waitStarted(ctx); err != nil {
+			return
+		}
+		for chunk := range j.Output(ctx, offset) {
+			select {
+			case c <- chunk:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return c
+}
+
+// ReadOutputBackward implements jobs.Process. The output of the job's latest
+// attempt is read. If the job is pending, there is no output to read.
+func (j *jobInfo) ReadOutputBackward(end int64, fn func(util.StreamChunk) bool) error {
+	j.mu.Lock()
+	if j.pendingLocked() {
+		j.mu.Unlock()
+		return nil
+	}
+	latest := j.latest()
+	j.mu.Unlock()
+	return latest.ReadOutputBackward(end, fn)
+}
+
+// OutputSize implements jobs.Process.
+func (j *jobInfo) OutputSize() int64 {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.pendingLocked() {
+		return 0
+	}
+	return j.latest().OutputSize()
+}
+
+// Status implements jobs.Process.
+func (j *jobInfo) Status() *jobv1.JobStatus {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	var status *jobv1.JobStatus
+	switch {
+	case j.current != nil:
+		status = j.current.Status()
+	case j.pendingLocked():
+		status = &jobv1.JobStatus{
+			State:   jobv1.State_PENDING,
+			Spec:    proto.Clone(j.spec).(*jobv1.JobSpec),
+			Message: jobv1.State_PENDING.String(),
+		}
+		if pos := j.queue.position(j); pos > 0 {
+			status.Message = fmt.Sprintf("waiting for other jobs to complete (position %d in queue)", pos)
+			status.QueuePosition = uint32(pos)
+		}
+	default:
+		status = &jobv1.JobStatus{
+			State:           jobv1.State_RESTARTING,
+			Spec:            proto.Clone(j.spec).(*jobv1.JobSpec),
+			Message:         fmt.Sprintf("waiting to restart (attempt %d)", j.attempt),
+			NextRestartTime: timestamppb.New(j.restartAt),
+		}
+	}
+	status.Attempt = j.attempt
+	status.ScheduleId = j.scheduleID
+	if j.limits != nil {
+		status.Limits = proto.Clone(j.limits).(*jobv1.ResourceLimits)
+	}
+	status.PreviousAttempts = nil
+	for _, a := range j.previous {
+		status.PreviousAttempts = append(status.PreviousAttempts, proto.Clone(a.status).(*jobv1.AttemptStatus))
+	}
+	return status
+}
+
+// Stdin implements jobs.Process.
+func (j *jobInfo) Stdin() io.WriteCloser {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.current == nil {
+		return nil
+	}
+	return j.current.Stdin()
+}
+
+// ResizeTerminal implements jobs.Process.
+func (j *jobInfo) ResizeTerminal(size *jobv1.TerminalSize) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.current == nil {
+		return jobs.ErrNotRunning
+	}
+	return j.current.ResizeTerminal(size)
+}
+
+// Signal implements jobs.Process.
+func (j *jobInfo) Signal(event *jobv1.SignalEvent) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.current == nil {
+		return jobs.ErrNotRunning
+	}
+	return j.current.Signal(event)
+}
+
+// Stop implements jobs.Process. Once a job has been stopped, it will not be
+// restarted again. If the job is waiting to be restarted, the restart is
+// canceled. If the job is waiting in the admission queue, it is removed from
+// the queue without being started.
+func (j *jobInfo) Stop(opts jobs.StopOptions) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if isDone(j) {
+		return jobs.ErrNotRunning
+	}
+	if !j.stopRequested {
+		j.stopRequested = true
+		close(j.stopC)
+	}
+	if j.pendingLocked() && j.queue.cancel(j) {
+		j.canceled = true
+		close(j.admitted)
+		return nil
+	}
+	if j.current == nil {
+		// the job is waiting to restart, or is about to be started; in the
+		// latter case, its process is stopped by setCurrent
+		return nil
+	}
+	if err := j.current.Stop(opts); err != nil && !errors.Is(err, jobs.ErrNotRunning) {
+		return err
+	}
+	// if the current attempt is no longer running, the job is about to
+	// complete, since it will not be restarted
+	return nil
+}
+
+// UpdateLimits implements jobs.Process. The limits are applied to the job's
+// current attempt if it is running, and are used to start any later attempts.
+func (j *jobInfo) UpdateLimits(limits *jobv1.ResourceLimits) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if isDone(j) {
+		return jobs.ErrNotRunning
+	}
+	if j.current != nil {
+		if err := j.current.UpdateLimits(limits); err != nil && !errors.Is(err, jobs.ErrNotRunning) {
+			return err
+		}
+	}
+	j.limits = jobs.MergeLimits(j.limits, limits)
+	return nil
+}
+
+// attemptSpec returns the spec used to start a new attempt of the job, with
+// the job's effective limits.
+func (j *jobInfo) attemptSpec() *jobv1.JobSpec {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if proto.Equal(j.limits, j.spec.GetLimits()) {
+		return j.spec
+	}
+	spec := proto.Clone(j.spec).(*jobv1.JobSpec)
+	spec.Limits = proto.Clone(j.limits).(*jobv1.ResourceLimits)
+	return spec
+}
+
+// Usage implements jobs.Process. The usage of the job's latest attempt that
+// was started is returned.
+func (j *jobInfo) Usage() (*jobv1.ResourceUsage, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.pendingLocked() {
+		return nil, jobs.ErrUsageUnavailable
+	}
+	return j.latest().Usage()
+}
+
+// Done implements jobs.Process. The returned channel is closed once the job's
+// last attempt is done.
+func (j *jobInfo) Done() <-chan struct{} {
+	return j.done
+}
+
+var _ jobs.Process = (*jobInfo)(nil)
+
+// processIDs returns the ids of the processes of all attempts of the job that
+// are still kept.
+func (j *jobInfo) processIDs() []string {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	var ids []string
+	for _, a := range j.previous {
+		ids = append(ids, a.proc.ID())
+	}
+	if j.current != nil {
+		ids = append(ids, j.current.ID())
+	}
+	return ids
+}
+
+// scheduleRestart is called once the job's current attempt is done. If the
+// attempt should be restarted according to the job's restart policy, it is
+// moved to the job's previous attempts, and the delay before the job should
+// be restarted is returned, along with the ids of the processes of any
+// attempts that are no longer kept.
+func (j *jobInfo) scheduleRestart() (delay time.Duration, expired []string, ok bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	status := j.current.Status()
+	if j.stopRequested || !jobs.ShouldRestart(j.spec.GetRestart(), status, j.attempt) {
+		return 0, nil, false
+	}
+	delay = jobs.RestartBackoff(j.spec.GetRestart(), j.attempt)
+	j.previous = append(j.previous, previousAttempt{
+		status: &jobv1.AttemptStatus{
+			Attempt:       j.attempt,
+			State:         status.GetState(),
+			Message:       status.GetMessage(),
+			Pid:           status.GetPid(),
+			StartTime:     status.GetStartTime(),
+			Terminated:    status.GetTerminated(),
+			PidsLimitHits: status.GetPidsLimitHits(),
+		},
+		proc: j.current,
+	})
+	for len(j.previous) > maxPreviousAttempts {
+		expired = append(expired, j.previous[0].proc.ID())
+		j.previous = j.previous[1:]
+	}
+	j.current = nil
+	j.attempt++
+	j.restartAt = time.Now().Add(delay)
+	return delay, expired, true
+}
+
+// cancelRestart makes the job's last attempt its current attempt again,
+// after the job was stopped while waiting to restart.
+func (j *jobInfo) cancelRestart() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	last := j.previous[len(j.previous)-1]
+	j.previous = j.previous[:len(j.previous)-1]
+	j.current = last.proc
+	j.attempt = last.status.GetAttempt()
+	j.restartAt = time.Time{}
+}
+
+// setCurrent sets the process of the job's new current attempt. If the job
+// was stopped while the process was being started, the process is stopped.
+func (j *jobInfo) setCurrent(proc jobs.Process) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.current = proc
+	j.restartAt = time.Time{}
+	if j.stopRequested {
+		proc.Stop(jobs.StopOptionsForSpec(j.spec))
+	}
+	select {
+	case <-j.started:
+	default:
+		close(j.started)
+	}
+}
+
+func (j *jobInfo) currentProcess() jobs.Process {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.current
+}
+
+func (j *jobInfo) currentAttempt() uint32 {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.attempt
+}
+
+func (j *jobInfo) isCanceled() bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.canceled
+}
+
+// startAttempt starts the job's current attempt using the runtime. If the
+// runtime fails to start it, the attempt is recorded as failed.
+func (s *Server) startAttempt(ctx context.Context, job *jobInfo) {
+	id := attemptID(job.ID(), job.currentAttempt())
+	spec := job.attemptSpec()
+	proc, err := s.runtime.Execute(ctx, id, spec)
+	if err != nil {
+		slog.With("id", job.ID(), "error", err).Error("failed to start job")
+		proc = newRestoredProcess(id, &jobv1.JobStatus{
+			State:   jobv1.State_FAILED,
+			Spec:    spec,
+			Message: err.Error(),
+		}, nil)
+	}
+	job.setCurrent(proc)
+	s.persist(context.Background(), job)
+	s.notifyStatus(job)
+}
+
+// runJob waits for the job to be admitted if it is pending, then waits for
+// each attempt of the job to complete, and restarts the job according to its
+// restart policy, until its last attempt is done.
+func (s *Server) runJob(ctx context.Context, job *jobInfo) {
+	<-job.admitted
+	if !job.isCanceled() {
+		// runs last, so that the next job is only admitted once this job's
+		// final status is visible
+		defer s.admission.release(job.owner)
+	}
+	defer func() {
+		// release anything the runtime attached to the job's context
+		job.cancel(nil)
+		close(job.done)
+		// persist before marking the job as finished, so that the job can't be
+		// deleted before its final status is written to the store
+		s.persist(context.Background(), job)
+		job.setFinished(time.Now())
+		s.notifyStatus(job)
+		if job.scheduleID != "" {
+			s.enforceHistoryLimit(context.Background(), job.scheduleID)
+		}
+	}()
+	switch {
+	case job.isCanceled():
+		job.setCurrent(newRestoredProcess(job.ID(), &jobv1.JobStatus{
+			State:   jobv1.State_TERMINATED,
+			Spec:    job.spec,
+			Message: "stopped before it was started",
+			Terminated: &jobv1.TerminationStatus{
+				Stopped: true,
+				Time:    timestamppb.Now(),
+			},
+		}, nil))
+		return
+	case job.currentProcess() == nil:
+		s.startAttempt(ctx, job)
+	}
+	for {
+		<-job.currentProcess().Done()
+		delay, expired, ok := job.scheduleRestart()
+		if !ok {
+			return
+		}
+		lg := slog.With("id", job.ID())
+		lg.With("delay", delay).Info("restarting job")
+		for _, id := range expired {
+			if err := s.OutputBackend.Remove(id); err != nil {
+				lg.With("error", err).Error("failed to remove output of previous attempt")
+			}
+		}
+		s.persist(context.Background(), job)
+		s.notifyStatus(job)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-job.stopC:
+			timer.Stop()
+			job.cancelRestart()
+			return
+		}
+
+		s.startAttempt(ctx, job)
+	}
+}
@@ -0,0 +1,31 @@
+package server
+
+import (
+	"context"
+	"errors"
+
+	jobv1 "github.com/kralicky/jobserver/pkg/apis/job/v1"
+	"github.com/kralicky/jobserver/pkg/jobs"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UpdateLimits implements v1.JobServer.
+func (s *Server) UpdateLimits(ctx context.Context, in *jobv1.UpdateLimitsRequest) (*jobv1.ResourceLimits, error) {
+	if err := in.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	job, err := s.lookupScoped(ctx, in.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if err := job.UpdateLimits(in.GetLimits()); err != nil {
+		if errors.Is(err, jobs.ErrNotRunning) {
+			return nil, status.Errorf(codes.FailedPrecondition, "job %s is not running", job.ID())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to update limits of job %s: %v", job.ID(), err)
+	}
+	s.persist(ctx, job)
+	s.notifyStatus(job)
+	return job.Status().GetLimits(), nil
+}
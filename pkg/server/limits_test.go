@@ -0,0 +1,100 @@
+package server
+
+import (
+	jobv1 "github.com/kralicky/jobserver/pkg/apis/job/v1"
+	rbacv1 "github.com/kralicky/jobserver/pkg/apis/rbac/v1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+var _ = Describe("Default limits", func() {
+	var srv *Server
+	var rt *fakeRuntime
+	var config *rbacv1.Config
+	BeforeEach(func() {
+		rt = newFakeRuntime()
+		srv = NewServer(rt, Options{DefaultPidsLimit: 4096})
+		config = newTestRbacConfig()
+	})
+	// starts a job with the given spec, and returns the limits it was started
+	// with by the runtime
+	start := func(spec *jobv1.JobSpec) *jobv1.ResourceLimits {
+		id, err := srv.Start(contextForMethod(config, testUser, "Start"), spec)
+		Expect(err).NotTo(HaveOccurred())
+		return rt.process(id.GetId()).Status().GetSpec().GetLimits()
+	}
+
+	It("should apply the default pids limit to jobs without limits", func() {
+		spec := newTestSpec()
+		Expect(start(spec).Pids).To(Equal(proto.Int64(4096)))
+		Expect(spec.Limits).To(BeNil(), "the given spec is not modified")
+	})
+	It("should apply the default pids limit to jobs with other limits", func() {
+		spec := newTestSpec()
+		spec.Limits = &jobv1.ResourceLimits{CpuWeight: proto.Uint32(200)}
+		limits := start(spec)
+		Expect(limits.Pids).To(Equal(proto.Int64(4096)))
+		Expect(limits.CpuWeight).To(Equal(proto.Uint32(200)))
+		Expect(spec.Limits.Pids).To(BeNil(), "the given spec is not modified")
+	})
+	It("should not override a pids limit set by the job", func() {
+		spec := newTestSpec()
+		spec.Limits = &jobv1.ResourceLimits{Pids: proto.Int64(10)}
+		Expect(start(spec).Pids).To(Equal(proto.Int64(10)))
+	})
+	It("should not limit jobs if the default is 0", func() {
+		srv.DefaultPidsLimit = 0
+		Expect(start(newTestSpec())).To(BeNil())
+	})
+	It("should apply the default pids limit to workflow jobs", func() {
+		id, err := srv.StartWorkflow(contextForMethod(config, testUser, "StartWorkflow"), &jobv1.WorkflowSpec{
+			Jobs: []*jobv1.WorkflowJob{{Name: "a", Spec: newTestSpec()}},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		value, ok := srv.workflows.Load(id.GetId())
+		Expect(ok).To(BeTrue())
+		wf := value.(*workflowInfo)
+		Eventually(wf.jobIDs).Should(HaveLen(1))
+		proc := rt.process(wf.jobIDs()[0])
+		Expect(proc.Status().GetSpec().GetLimits().Pids).To(Equal(proto.Int64(4096)))
+	})
+})
+
+var _ = Describe("UpdateLimits", func() {
+	var srv *Server
+	var rt *fakeRuntime
+	var config *rbacv1.Config
+	var id string
+	BeforeEach(func() {
+		rt = newFakeRuntime()
+		srv = NewServer(rt, Options{})
+		config = newTestRbacConfig()
+		spec := newTestSpec()
+		spec.Limits = &jobv1.ResourceLimits{Pids: proto.Int64(10)}
+		resp, err := srv.Start(contextForMethod(config, testUser, "Start"), spec)
+		Expect(err).NotTo(HaveOccurred())
+		id = resp.GetId()
+	})
+	update := func(limits *jobv1.ResourceLimits) (*jobv1.ResourceLimits, error) {
+		return srv.UpdateLimits(contextForMethod(config, testUser, "UpdateLimits"), &jobv1.UpdateLimitsRequest{
+			Id:     &jobv1.JobId{Id: id},
+			Limits: limits,
+		})
+	}
+
+	It("should merge the given limits into the job's limits", func() {
+		limits, err := update(&jobv1.ResourceLimits{CpuWeight: proto.Uint32(200)})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(limits.Pids).To(Equal(proto.Int64(10)))
+		Expect(limits.CpuWeight).To(Equal(proto.Uint32(200)))
+	})
+	It("should fail if the job is not running", func() {
+		rt.process(id).exit(0)
+		Eventually(func() bool { return srv.finished(id) }).Should(BeTrue())
+		_, err := update(&jobv1.ResourceLimits{CpuWeight: proto.Uint32(200)})
+		Expect(status.Code(err)).To(Equal(codes.FailedPrecondition))
+	})
+})
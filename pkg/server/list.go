@@ -0,0 +1,131 @@
+package server
+
+import (
+	"context"
+	"encoding/base64"
+	"slices"
+	"strings"
+
+	jobv1 "github.com/kralicky/jobserver/pkg/apis/job/v1"
+	"github.com/kralicky/jobserver/pkg/auth"
+	"github.com/kralicky/jobserver/pkg/labels"
+	"github.com/kralicky/jobserver/pkg/rbac"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultPageSize = 100
+	maxPageSize     = 1000
+)
+
+type userJobInfo struct {
+	*jobv1.JobInfo
+	user auth.AuthenticatedUser
+}
+
+func (i userJobInfo) AssignedUser() auth.AuthenticatedUser {
+	return i.user
+}
+
+// List implements v1.JobServer.
+func (s *Server) List(ctx context.Context, req *jobv1.ListRequest) (*jobv1.JobList, error) {
+	if req.GetPageSize() < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "page size must not be negative")
+	}
+	pageSize := int(req.GetPageSize())
+	if pageSize == 0 {
+		pageSize = defaultPageSize
+	}
+	pageSize = min(pageSize, maxPageSize)
+
+	var after string
+	if req.GetPageToken() != "" {
+		token, err := base64.RawURLEncoding.DecodeString(req.GetPageToken())
+		if err != nil || len(token) == 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid page token")
+		}
+		after = string(token)
+	}
+	selector, err := labels.ParseSelector(req.GetLabelSelector())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	var jobs []userJobInfo
+	s.jobs.Range(func(k, v any) bool {
+		id := k.(string)
+		if after != "" && id <= after {
+			return true
+		}
+		job := v.(*jobInfo)
+		jobs = append(jobs, userJobInfo{
+			JobInfo: &jobv1.JobInfo{
+				Id:     &jobv1.JobId{Id: id},
+				Owner:  string(job.owner),
+				Status: job.Status(),
+			},
+			user: job.owner,
+		})
+		return true
+	})
+
+	jobs, err = rbac.FilterByScope(ctx, jobs)
+	if err != nil {
+		return nil, err
+	}
+	jobs = slices.DeleteFunc(jobs, func(job userJobInfo) bool {
+		return !matchesListRequest(job.JobInfo, req, selector)
+	})
+	slices.SortFunc(jobs, func(a, b userJobInfo) int {
+		return strings.Compare(a.GetId().GetId(), b.GetId().GetId())
+	})
+
+	resp := &jobv1.JobList{}
+	if len(jobs) > pageSize {
+		jobs = jobs[:pageSize]
+		last := jobs[len(jobs)-1].GetId().GetId()
+		resp.NextPageToken = base64.RawURLEncoding.EncodeToString([]byte(last))
+	}
+	resp.Items = make([]*jobv1.JobInfo, 0, len(jobs))
+	for _, job := range jobs {
+		resp.Items = append(resp.Items, job.JobInfo)
+	}
+	return resp, nil
+}
+
+func matchesListRequest(job *jobv1.JobInfo, req *jobv1.ListRequest, selector labels.Selector) bool {
+	stat := job.GetStatus()
+	if len(req.GetStates()) > 0 && !slices.Contains(req.GetStates(), stat.GetState()) {
+		return false
+	}
+	if !selector.Matches(stat.GetSpec().GetLabels()) {
+		return false
+	}
+	if req.Owner != nil && job.GetOwner() != req.GetOwner() {
+		return false
+	}
+	if req.ScheduleId != nil && stat.GetScheduleId() != req.GetScheduleId() {
+		return false
+	}
+	if req.Command != nil {
+		cmdSpec := stat.GetSpec().GetCommand()
+		cmdLine := strings.Join(append([]string{cmdSpec.GetCommand()}, cmdSpec.GetArgs()...), " ")
+		if !strings.Contains(cmdLine, req.GetCommand()) {
+			return false
+		}
+	}
+	if req.StartedAfter != nil || req.StartedBefore != nil {
+		if stat.StartTime == nil {
+			return false
+		}
+		startTime := stat.GetStartTime().AsTime()
+		if req.StartedAfter != nil && startTime.Before(req.GetStartedAfter().AsTime()) {
+			return false
+		}
+		if req.StartedBefore != nil && !startTime.Before(req.GetStartedBefore().AsTime()) {
+			return false
+		}
+	}
+	return true
+}
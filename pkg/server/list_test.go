@@ -0,0 +1,131 @@
+package server
+
+import (
+	"slices"
+
+	jobv1 "github.com/kralicky/jobserver/pkg/apis/job/v1"
+	rbacv1 "github.com/kralicky/jobserver/pkg/apis/rbac/v1"
+	"github.com/kralicky/jobserver/pkg/auth"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+var _ = Describe("List", func() {
+	var srv *Server
+	var rt *fakeRuntime
+	var config *rbacv1.Config
+	BeforeEach(func() {
+		rt = newFakeRuntime()
+		srv = NewServer(rt, Options{})
+		config = newTestRbacConfig()
+	})
+
+	start := func(user auth.AuthenticatedUser, args ...string) string {
+		id, err := srv.Start(contextForMethod(config, user, "Start"), newTestSpec(args...))
+		Expect(err).NotTo(HaveOccurred())
+		return id.GetId()
+	}
+	list := func(user auth.AuthenticatedUser, req *jobv1.ListRequest) (*jobv1.JobList, error) {
+		return srv.List(contextForMethod(config, user, "List"), req)
+	}
+	ids := func(resp *jobv1.JobList) []string {
+		var ids []string
+		for _, item := range resp.GetItems() {
+			ids = append(ids, item.GetId().GetId())
+		}
+		return ids
+	}
+
+	It("should return every page of jobs in order of their ids", func() {
+		var expected []string
+		for i := 0; i < 5; i++ {
+			expected = append(expected, start(testUser))
+		}
+		slices.Sort(expected)
+
+		var pages [][]string
+		req := &jobv1.ListRequest{PageSize: 2}
+		for {
+			resp, err := list(testUser, req)
+			Expect(err).NotTo(HaveOccurred())
+			pages = append(pages, ids(resp))
+			if resp.GetNextPageToken() == "" {
+				break
+			}
+			req.PageToken = resp.GetNextPageToken()
+		}
+		Expect(pages).To(Equal([][]string{expected[:2], expected[2:4], expected[4:]}))
+	})
+
+	It("should not return a page token if the last page is full", func() {
+		start(testUser)
+		start(testUser)
+		resp, err := list(testUser, &jobv1.ListRequest{PageSize: 2})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.GetItems()).To(HaveLen(2))
+		Expect(resp.GetNextPageToken()).To(BeEmpty())
+	})
+
+	It("should apply filters before paging", func() {
+		var expected []string
+		for i := 0; i < 3; i++ {
+			start(otherUser)
+			expected = append(expected, start(testUser, "match"))
+			start(testUser, "other")
+		}
+		slices.Sort(expected)
+
+		req := &jobv1.ListRequest{PageSize: 2, Command: proto.String("match")}
+		resp, err := list(testAdmin, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ids(resp)).To(Equal(expected[:2]))
+		req.PageToken = resp.GetNextPageToken()
+		resp, err = list(testAdmin, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ids(resp)).To(Equal(expected[2:]))
+		Expect(resp.GetNextPageToken()).To(BeEmpty())
+	})
+
+	It("should only return jobs in the caller's scope", func() {
+		own := start(testUser)
+		other := start(otherUser)
+
+		resp, err := list(testUser, &jobv1.ListRequest{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ids(resp)).To(ConsistOf(own))
+		Expect(resp.GetItems()[0].GetOwner()).To(Equal(testUser))
+
+		resp, err = list(testAdmin, &jobv1.ListRequest{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ids(resp)).To(ConsistOf(own, other))
+	})
+
+	It("should filter by state and owner", func() {
+		running := start(testUser)
+		terminated := start(testUser)
+		rt.process(terminated).exit(0)
+		Eventually(func() bool { return srv.finished(terminated) }).Should(BeTrue())
+		other := start(otherUser)
+
+		resp, err := list(testAdmin, &jobv1.ListRequest{States: []jobv1.State{jobv1.State_RUNNING}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ids(resp)).To(ConsistOf(running, other))
+
+		resp, err = list(testAdmin, &jobv1.ListRequest{Owner: proto.String(testUser)})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ids(resp)).To(ConsistOf(running, terminated))
+	})
+
+	DescribeTable("should reject invalid requests",
+		func(req *jobv1.ListRequest) {
+			_, err := list(testUser, req)
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+		},
+		Entry("negative page size", &jobv1.ListRequest{PageSize: -1}),
+		Entry("invalid page token", &jobv1.ListRequest{PageToken: "!"}),
+		Entry("invalid label selector", &jobv1.ListRequest{LabelSelector: "a in (b"}),
+	)
+})
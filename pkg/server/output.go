@@ -0,0 +1,151 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"time"
+
+	jobv1 "github.com/kralicky/jobserver/pkg/apis/job/v1"
+	"github.com/kralicky/jobserver/pkg/jobs"
+	"github.com/kralicky/jobserver/pkg/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const maxChunkSize = 512 * 1024 // 512 KiB
+
+// Output implements v1.JobServer.
+func (s *Server) Output(in *jobv1.OutputRequest, stream jobv1.Job_OutputServer) error {
+	if in.GetOffset() < 0 {
+		return status.Error(codes.InvalidArgument, "offset must not be negative")
+	}
+	if in.GetTailLines() < 0 {
+		return status.Error(codes.InvalidArgument, "tail_lines must not be negative")
+	}
+	job, err := s.lookupScoped(stream.Context(), in.GetId())
+	if err != nil {
+		return err
+	}
+	var proc jobs.Process = job
+	if in.Attempt != nil {
+		var ok bool
+		proc, ok = job.attemptProcess(in.GetAttempt())
+		if !ok {
+			return status.Errorf(codes.NotFound, "attempt %d of job %s not found", in.GetAttempt(), job.ID())
+		}
+	}
+	size := proc.OutputSize()
+	if in.GetOffset() > size {
+		return status.Errorf(codes.OutOfRange, "offset %d is past the end of the output of job %s (%d bytes)",
+			in.GetOffset(), job.ID(), size)
+	}
+	offset := in.GetOffset()
+	if in.TailLines != nil {
+		tail, err := tailOffset(proc, in.GetStreams(), in.GetTailLines(), size)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to read the output of job %s: %v", job.ID(), err)
+		}
+		offset = max(offset, tail)
+	}
+	opts := outputOptions{
+		offset:  offset,
+		streams: in.GetStreams(),
+	}
+	if in.Since != nil {
+		opts.since = in.GetSince().AsTime()
+	}
+	return sendOutput(stream.Context(), proc, opts, stream.Send)
+}
+
+type outputOptions struct {
+	// The offset to start at.
+	offset int64
+	// If not empty, only output written to one of the given streams is sent.
+	streams []jobv1.Stream
+	// If not zero, only output written at or after this time is sent.
+	since time.Time
+}
+
+// sendOutput streams the output of the process using the given send function,
+// until the process's output is closed or the context is canceled.
+func sendOutput(ctx context.Context, proc jobs.Process, opts outputOptions, send func(*jobv1.ProcessOutput) error) error {
+	for chunk := range proc.Output(ctx, opts.offset) {
+		if chunk.Dropped > 0 {
+			if err := send(&jobv1.ProcessOutput{
+				DroppedBytes: chunk.Dropped,
+				Offset:       chunk.Offset,
+			}); err != nil {
+				return err
+			}
+		}
+		stream := jobv1.Stream(chunk.Source)
+		if len(opts.streams) > 0 && !slices.Contains(opts.streams, stream) {
+			continue
+		}
+		if chunk.Time.Before(opts.since) {
+			continue
+		}
+		buf := chunk.Data
+		offset := chunk.Offset
+		for len(buf) > 0 {
+			data := buf
+			if len(data) > maxChunkSize {
+				data = data[:maxChunkSize]
+			}
+			buf = buf[len(data):]
+			if err := send(&jobv1.ProcessOutput{
+				Output: data,
+				Stream: stream,
+				Time:   timestamppb.New(chunk.Time),
+				Offset: offset,
+			}); err != nil {
+				return err
+			}
+			offset += int64(len(data))
+		}
+	}
+	return nil
+}
+
+// tailOffset returns the offset of the start of the last n lines of output
+// written to the given streams (or all streams, if empty) before the given
+// end offset. A final line that does not end with a newline is counted as a
+// line. The output is read backwards from the end offset, so only the last n
+// lines are read.
+func tailOffset(proc jobs.Process, streams []jobv1.Stream, n int64, end int64) (int64, error) {
+	if n == 0 || end == 0 {
+		return end, nil
+	}
+	offset := int64(0)
+	lines := int64(0)
+	last := true // whether the data being read is the end of the output
+	err := proc.ReadOutputBackward(end, func(chunk util.StreamChunk) bool {
+		data := chunk.Data
+		if len(data) == 0 || (len(streams) > 0 && !slices.Contains(streams, jobv1.Stream(chunk.Source))) {
+			return true
+		}
+		if last {
+			// if the output ends with a complete line, the newline at the end
+			// of it doesn't start a new line
+			if data[len(data)-1] == '\n' {
+				data = data[:len(data)-1]
+			}
+			last = false
+		}
+		for {
+			i := bytes.LastIndexByte(data, '\n')
+			if i < 0 {
+				return true
+			}
+			lines++
+			if lines == n {
+				offset = chunk.Offset + int64(i) + 1
+				return false
+			}
+			data = data[:i]
+		}
+	})
+	return offset, err
+}
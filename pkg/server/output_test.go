@@ -0,0 +1,68 @@
+package server
+
+import (
+	jobv1 "github.com/kralicky/jobserver/pkg/apis/job/v1"
+	"github.com/kralicky/jobserver/pkg/util"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("tailOffset", func() {
+	type write struct {
+		stream jobv1.Stream
+		data   string
+	}
+	stdout := func(data string) write { return write{jobv1.Stream_STDOUT, data} }
+	stderr := func(data string) write { return write{jobv1.Stream_STDERR, data} }
+
+	DescribeTable("should find the start of the last n lines",
+		func(writes []write, streams []jobv1.Stream, n int64, expected string) {
+			buf := util.NewStreamBuffer()
+			var all []byte
+			for _, w := range writes {
+				Expect(buf.WriteFrom(util.Source(w.stream), []byte(w.data))).To(Equal(len(w.data)))
+				all = append(all, w.data...)
+			}
+			Expect(buf.Close()).To(Succeed())
+			proc := newRestoredProcess("test", &jobv1.JobStatus{}, buf)
+
+			offset, err := tailOffset(proc, streams, n, buf.Size())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(all[offset:])).To(Equal(expected))
+		},
+		Entry("no lines", []write{stdout("a\nb\n")}, nil, int64(0), ""),
+		Entry("trailing newline", []write{stdout("a\nb\nc\n")}, nil, int64(2), "b\nc\n"),
+		Entry("no trailing newline", []write{stdout("a\nb\nc")}, nil, int64(2), "b\nc"),
+		Entry("fewer lines than requested", []write{stdout("a\nb\n")}, nil, int64(5), "a\nb\n"),
+		Entry("lines split across writes", []write{stdout("a\nb"), stdout("b\ncc"), stdout("c\n")}, nil, int64(2), "bb\nccc\n"),
+		Entry("newline in its own write", []write{stdout("a\nb"), stdout("\n")}, nil, int64(1), "b\n"),
+		Entry("filtered streams",
+			[]write{stdout("a\n"), stderr("x\n"), stdout("b\n"), stderr("y\n")},
+			[]jobv1.Stream{jobv1.Stream_STDOUT}, int64(1), "x\nb\ny\n"),
+		Entry("trailing newline of a filtered stream",
+			[]write{stdout("a\nb\n"), stderr("x")},
+			[]jobv1.Stream{jobv1.Stream_STDOUT}, int64(1), "b\nx"),
+	)
+
+	It("should only count output before the end offset", func() {
+		buf := util.NewStreamBuffer()
+		buf.Write([]byte("a\nb\nc\nd\n"))
+		proc := newRestoredProcess("test", &jobv1.JobStatus{}, buf)
+		offset, err := tailOffset(proc, nil, 2, 6)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(offset).To(BeEquivalentTo(2))
+	})
+
+	It("should read a file-backed buffer", func() {
+		buf, err := util.NewFileStreamBuffer(GinkgoT().TempDir() + "/output.log")
+		Expect(err).NotTo(HaveOccurred())
+		for i := 0; i < 3000; i++ {
+			buf.Write([]byte("line\n"))
+		}
+		Expect(buf.Close()).To(Succeed())
+		proc := newRestoredProcess("test", &jobv1.JobStatus{}, buf)
+		offset, err := tailOffset(proc, nil, 1500, buf.Size())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(offset).To(BeEquivalentTo(1500 * 5))
+	})
+})
@@ -0,0 +1,222 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"time"
+
+	jobv1 "github.com/kralicky/jobserver/pkg/apis/job/v1"
+	storagev1 "github.com/kralicky/jobserver/pkg/apis/storage/v1"
+	"github.com/kralicky/jobserver/pkg/auth"
+	"github.com/kralicky/jobserver/pkg/jobs"
+	"github.com/kralicky/jobserver/pkg/util"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// restoredProcess is a jobs.Process for a job that was loaded from the job
+// store after a server restart. Its process is no longer managed by the
+// server, so it only reports the last known status of the job, and any output
+// that the output backend retained. It is also used for attempts of a job
+// that could not be started by the runtime.
+type restoredProcess struct {
+	id     string
+	status *jobv1.JobStatus
+	output jobs.OutputBuffer // may be nil
+	done   chan struct{}
+}
+
+func newRestoredProcess(id string, status *jobv1.JobStatus, output jobs.OutputBuffer) *restoredProcess {
+	done := make(chan struct{})
+	close(done)
+	return &restoredProcess{
+		id:     id,
+		status: status,
+		output: output,
+		done:   done,
+	}
+}
+
+// ID implements jobs.Process.
+func (p *restoredProcess) ID() string {
+	return p.id
+}
+
+// Output implements jobs.Process.
+func (p *restoredProcess) Output(ctx context.Context, offset int64) <-chan util.StreamChunk {
+	if p.output != nil {
+		return p.output.NewChunkStreamFrom(ctx, offset)
+	}
+	c := make(chan util.StreamChunk)
+	close(c)
+	return c
+}
+
+// ReadOutputBackward implements jobs.Process.
+func (p *restoredProcess) ReadOutputBackward(end int64, fn func(util.StreamChunk) bool) error {
+	if p.output != nil {
+		return p.output.ReadChunksBackward(end, fn)
+	}
+	return nil
+}
+
+// Stdin implements jobs.Process.
+func (p *restoredProcess) Stdin() io.WriteCloser {
+	return nil
+}
+
+// ResizeTerminal implements jobs.Process.
+func (p *restoredProcess) ResizeTerminal(*jobv1.TerminalSize) error {
+	return jobs.ErrNoTerminal
+}
+
+// OutputSize implements jobs.Process.
+func (p *restoredProcess) OutputSize() int64 {
+	if p.output != nil {
+		return p.output.Size()
+	}
+	return 0
+}
+
+// Signal implements jobs.Process.
+func (p *restoredProcess) Signal(*jobv1.SignalEvent) error {
+	return jobs.ErrNotRunning
+}
+
+// Stop implements jobs.Process.
+func (p *restoredProcess) Stop(jobs.StopOptions) error {
+	return jobs.ErrNotRunning
+}
+
+// UpdateLimits implements jobs.Process.
+func (p *restoredProcess) UpdateLimits(*jobv1.ResourceLimits) error {
+	return jobs.ErrNotRunning
+}
+
+// Usage implements jobs.Process.
+func (p *restoredProcess) Usage() (*jobv1.ResourceUsage, error) {
+	return nil, jobs.ErrUsageUnavailable
+}
+
+// Status implements jobs.Process.
+func (p *restoredProcess) Status() *jobv1.JobStatus {
+	return proto.Clone(p.status).(*jobv1.JobStatus)
+}
+
+// Done implements jobs.Process.
+func (p *restoredProcess) Done() <-chan struct{} {
+	return p.done
+}
+
+var _ jobs.Process = (*restoredProcess)(nil)
+
+// restoreJobs loads all jobs from the job store. Jobs that had not yet
+// terminated when the server exited can no longer be tracked, so they are
+// marked as failed or terminated and written back to the store.
+func (s *Server) restoreJobs(ctx context.Context) error {
+	records, err := s.Store.List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list stored jobs: %w", err)
+	}
+	for _, record := range records {
+		status := record.GetStatus()
+		if status == nil {
+			status = &jobv1.JobStatus{}
+		}
+		interrupted := true
+		switch status.GetState() {
+		case jobv1.State_FAILED, jobv1.State_TERMINATED:
+			interrupted = false
+		case jobv1.State_RUNNING:
+			status.State = jobv1.State_TERMINATED
+			status.Message = "job server restarted while the job was running"
+			status.Terminated = &jobv1.TerminationStatus{
+				Time: timestamppb.Now(),
+			}
+		case jobv1.State_RESTARTING:
+			status.State = jobv1.State_FAILED
+			status.Message = "job server restarted before the job was restarted"
+			status.NextRestartTime = nil
+		default:
+			status.State = jobv1.State_FAILED
+			status.Message = "job server restarted before the job was started"
+		}
+		id := record.GetId()
+		currentID := attemptID(id, status.GetAttempt())
+		job := newJobInfo(id, auth.AuthenticatedUser(record.GetOwner()), status.GetSpec(), func(error) {})
+		job.setCurrent(newRestoredProcess(currentID, status, s.openOutput(currentID)))
+		job.attempt = status.GetAttempt()
+		job.scheduleID = status.GetScheduleId()
+		if status.GetLimits() != nil {
+			job.limits = status.GetLimits()
+		}
+		for _, a := range status.GetPreviousAttempts() {
+			procID := attemptID(id, a.GetAttempt())
+			job.previous = append(job.previous, previousAttempt{
+				status: a,
+				proc: newRestoredProcess(procID, &jobv1.JobStatus{
+					State:      a.GetState(),
+					Spec:       status.GetSpec(),
+					Message:    a.GetMessage(),
+					Pid:        a.GetPid(),
+					StartTime:  a.GetStartTime(),
+					Terminated: a.GetTerminated(),
+				}, s.openOutput(procID)),
+			})
+		}
+		close(job.done)
+		if t := status.GetTerminated().GetTime(); t != nil {
+			job.setFinished(t.AsTime())
+		} else {
+			job.setFinished(time.Now())
+		}
+		s.jobs.Store(record.GetId(), job)
+		if interrupted {
+			s.persist(ctx, job)
+		}
+	}
+	if len(records) > 0 {
+		slog.Info("restored jobs from store", "count", len(records))
+	}
+	return nil
+}
+
+// openOutput opens the output of a process created before the server was
+// restarted, or returns nil if its output was not retained.
+func (s *Server) openOutput(id string) jobs.OutputBuffer {
+	output, err := s.OutputBackend.Open(id)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			slog.With(
+				"id", id,
+				"error", err,
+			).Warn("failed to restore job output")
+		}
+		return nil
+	}
+	return output
+}
+
+// persist writes the current status of the job to the job store.
+func (s *Server) persist(ctx context.Context, job *jobInfo) {
+	job.persistMu.Lock()
+	defer job.persistMu.Unlock()
+	if job.deleted {
+		return
+	}
+	record := &storagev1.JobRecord{
+		Id:     job.ID(),
+		Owner:  string(job.owner),
+		Status: job.Status(),
+	}
+	if err := s.Store.Put(ctx, record); err != nil {
+		slog.With(
+			"id", job.ID(),
+			"error", err,
+		).Error("failed to persist job")
+	}
+}
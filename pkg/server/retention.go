@@ -0,0 +1,204 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"time"
+
+	jobv1 "github.com/kralicky/jobserver/pkg/apis/job/v1"
+	"github.com/kralicky/jobserver/pkg/auth"
+	"github.com/kralicky/jobserver/pkg/storage"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// RetentionPolicy controls when completed jobs are automatically deleted by
+// the server. Jobs that are still running are never deleted. A zero value
+// for any of the fields disables the corresponding limit.
+//
+// Completed workflows are deleted once all of the jobs they started have been
+// deleted, and (if TTL is set) the TTL has passed since they completed.
+type RetentionPolicy struct {
+	// How long to keep a job after it has completed.
+	TTL time.Duration
+	// The maximum number of completed jobs to keep for each user. Once the
+	// limit is exceeded, the user's oldest completed jobs are deleted first.
+	MaxCompletedPerUser int
+	// The maximum number of jobs to keep in total. Once the limit is exceeded,
+	// the oldest completed jobs are deleted first.
+	MaxJobs int
+}
+
+func (p RetentionPolicy) enabled() bool {
+	return p.TTL > 0 || p.MaxCompletedPerUser > 0 || p.MaxJobs > 0
+}
+
+const retentionInterval = 15 * time.Second
+
+// Delete implements v1.JobServer.
+func (s *Server) Delete(ctx context.Context, id *jobv1.JobId) (*emptypb.Empty, error) {
+	job, err := s.lookupScoped(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if _, finished := job.finishedAt(); !finished {
+		return nil, status.Errorf(codes.FailedPrecondition, "job %s is still running", job.ID())
+	}
+	if err := s.deleteJob(ctx, job.ID()); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// deleteJob removes a completed job from the server, the job store, and the
+// output backend. Any output streams that are still open for the job will
+// continue to be served until they complete.
+func (s *Server) deleteJob(ctx context.Context, id string) error {
+	value, ok := s.jobs.LoadAndDelete(id)
+	if !ok {
+		return status.Errorf(codes.NotFound, "job %s not found", id)
+	}
+	job := value.(*jobInfo)
+	var errs []error
+	job.persistMu.Lock()
+	job.deleted = true
+	if err := s.Store.Delete(ctx, id); err != nil && !errors.Is(err, storage.ErrNotFound) {
+		errs = append(errs, err)
+	}
+	job.persistMu.Unlock()
+	for _, procID := range job.processIDs() {
+		if err := s.OutputBackend.Remove(procID); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		slog.With(
+			"id", id,
+			"error", err,
+		).Error("failed to delete job data")
+		return status.Errorf(codes.Internal, "failed to delete job %s: %s", id, err.Error())
+	}
+	slog.With("id", id).Info("job deleted")
+	return nil
+}
+
+// runRetention periodically deletes completed jobs according to the
+// retention policy, until the context is canceled.
+func (s *Server) runRetention(ctx context.Context) {
+	if !s.Retention.enabled() {
+		return
+	}
+	ticker := time.NewTicker(retentionInterval)
+	defer ticker.Stop()
+	for {
+		s.enforceRetention(ctx, time.Now())
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+type completedJob struct {
+	id       string
+	owner    auth.AuthenticatedUser
+	finished time.Time
+}
+
+func (s *Server) enforceRetention(ctx context.Context, now time.Time) {
+	var total int
+	var completed []completedJob
+	s.jobs.Range(func(k, v any) bool {
+		total++
+		job := v.(*jobInfo)
+		if finished, ok := job.finishedAt(); ok {
+			completed = append(completed, completedJob{
+				id:       k.(string),
+				owner:    job.owner,
+				finished: finished,
+			})
+		}
+		return true
+	})
+	// oldest first
+	slices.SortFunc(completed, func(a, b completedJob) int {
+		return a.finished.Compare(b.finished)
+	})
+
+	expired := make(map[string]struct{})
+	if ttl := s.Retention.TTL; ttl > 0 {
+		for _, job := range completed {
+			if now.Sub(job.finished) >= ttl {
+				expired[job.id] = struct{}{}
+			}
+		}
+	}
+	if limit := s.Retention.MaxCompletedPerUser; limit > 0 {
+		perUser := make(map[auth.AuthenticatedUser]int)
+		// iterate newest first, so that the oldest jobs exceed the limit
+		for i := len(completed) - 1; i >= 0; i-- {
+			job := completed[i]
+			if _, ok := expired[job.id]; ok {
+				continue
+			}
+			perUser[job.owner]++
+			if perUser[job.owner] > limit {
+				expired[job.id] = struct{}{}
+			}
+		}
+	}
+	if limit := s.Retention.MaxJobs; limit > 0 {
+		remaining := total - len(expired)
+		for _, job := range completed {
+			if remaining <= limit {
+				break
+			}
+			if _, ok := expired[job.id]; ok {
+				continue
+			}
+			expired[job.id] = struct{}{}
+			remaining--
+		}
+	}
+
+	var deleted int
+	for id := range expired {
+		// errors are logged by deleteJob
+		if err := s.deleteJob(ctx, id); err == nil {
+			deleted++
+		}
+	}
+	if deleted > 0 {
+		slog.With("count", deleted).Info("deleted completed jobs according to retention policy")
+	}
+	s.expireWorkflows(now)
+}
+
+// expireWorkflows deletes completed workflows whose jobs have all been
+// deleted, according to the retention policy.
+func (s *Server) expireWorkflows(now time.Time) {
+	var deleted int
+	s.workflows.Range(func(k, v any) bool {
+		wf := v.(*workflowInfo)
+		finished, ok := wf.finishedAt()
+		if !ok || (s.Retention.TTL > 0 && now.Sub(finished) < s.Retention.TTL) {
+			return true
+		}
+		for _, id := range wf.jobIDs() {
+			if _, ok := s.jobs.Load(id); ok {
+				return true
+			}
+		}
+		s.workflows.Delete(k)
+		slog.With("id", k).Info("workflow deleted")
+		deleted++
+		return true
+	})
+	if deleted > 0 {
+		slog.With("count", deleted).Info("deleted completed workflows according to retention policy")
+	}
+}
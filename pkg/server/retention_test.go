@@ -0,0 +1,189 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	jobv1 "github.com/kralicky/jobserver/pkg/apis/job/v1"
+	rbacv1 "github.com/kralicky/jobserver/pkg/apis/rbac/v1"
+	"github.com/kralicky/jobserver/pkg/auth"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ = Describe("Delete", func() {
+	var srv *Server
+	var rt *fakeRuntime
+	var config *rbacv1.Config
+	BeforeEach(func() {
+		rt = newFakeRuntime()
+		srv = NewServer(rt, Options{})
+		config = newTestRbacConfig()
+	})
+
+	start := func(spec *jobv1.JobSpec) string {
+		id, err := srv.Start(contextForMethod(config, testUser, "Start"), spec)
+		Expect(err).NotTo(HaveOccurred())
+		return id.GetId()
+	}
+
+	It("should delete a completed job", func() {
+		id := start(newTestSpec())
+		rt.process(id).exit(0)
+		Eventually(func() bool { return srv.finished(id) }).Should(BeTrue())
+
+		_, err := srv.Delete(contextForMethod(config, testUser, "Delete"), &jobv1.JobId{Id: id})
+		Expect(err).NotTo(HaveOccurred())
+		_, ok := srv.jobs.Load(id)
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should delete a completed job by name", func() {
+		spec := newTestSpec()
+		spec.Name = "build"
+		id := start(spec)
+		rt.process(id).exit(0)
+		Eventually(func() bool { return srv.finished(id) }).Should(BeTrue())
+
+		_, err := srv.Delete(contextForMethod(config, testUser, "Delete"), &jobv1.JobId{Id: "build"})
+		Expect(err).NotTo(HaveOccurred())
+		_, ok := srv.jobs.Load(id)
+		Expect(ok).To(BeFalse())
+		records, err := srv.Store.List(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(records).To(BeEmpty())
+	})
+
+	It("should not delete a running job", func() {
+		spec := newTestSpec()
+		spec.Name = "server"
+		id := start(spec)
+
+		_, err := srv.Delete(contextForMethod(config, testUser, "Delete"), &jobv1.JobId{Id: "server"})
+		Expect(status.Code(err)).To(Equal(codes.FailedPrecondition))
+		Expect(err.Error()).To(ContainSubstring(id))
+	})
+
+	It("should not resolve the names of other users' jobs", func() {
+		spec := newTestSpec()
+		spec.Name = "build"
+		id := start(spec)
+		rt.process(id).exit(0)
+		Eventually(func() bool { return srv.finished(id) }).Should(BeTrue())
+
+		_, err := srv.Delete(contextForMethod(config, otherUser, "Delete"), &jobv1.JobId{Id: "build"})
+		Expect(err).To(HaveOccurred())
+		_, ok := srv.jobs.Load(id)
+		Expect(ok).To(BeTrue())
+	})
+})
+
+var _ = Describe("Retention", func() {
+	var srv *Server
+	var rt *fakeRuntime
+	var config *rbacv1.Config
+	now := time.Now()
+	BeforeEach(func() {
+		rt = newFakeRuntime()
+		srv = NewServer(rt, Options{})
+		config = newTestRbacConfig()
+	})
+
+	startRunning := func(user auth.AuthenticatedUser) string {
+		id, err := srv.Start(contextForMethod(config, user, "Start"), newTestSpec())
+		Expect(err).NotTo(HaveOccurred())
+		return id.GetId()
+	}
+	// starts a job that completed at the given time
+	startCompleted := func(user auth.AuthenticatedUser, finished time.Time) string {
+		id := startRunning(user)
+		rt.process(id).exit(0)
+		Eventually(func() bool { return srv.finished(id) }).Should(BeTrue())
+		v, _ := srv.jobs.Load(id)
+		v.(*jobInfo).setFinished(finished)
+		return id
+	}
+	remaining := func() []string {
+		var ids []string
+		srv.jobs.Range(func(k, _ any) bool {
+			ids = append(ids, k.(string))
+			return true
+		})
+		return ids
+	}
+
+	It("should delete jobs that completed longer than the TTL ago", func() {
+		srv.Retention = RetentionPolicy{TTL: time.Hour}
+		expired := startCompleted(testUser, now.Add(-2*time.Hour))
+		atTTL := startCompleted(otherUser, now.Add(-time.Hour))
+		recent := startCompleted(testUser, now.Add(-30*time.Minute))
+		running := startRunning(testUser)
+
+		srv.enforceRetention(context.Background(), now)
+		Expect(remaining()).To(ConsistOf(recent, running))
+		Expect(remaining()).NotTo(ContainElements(expired, atTTL))
+	})
+
+	It("should keep at most the given number of completed jobs for each user, oldest first", func() {
+		srv.Retention = RetentionPolicy{MaxCompletedPerUser: 2}
+		oldest := startCompleted(testUser, now.Add(-3*time.Hour))
+		newest := startCompleted(testUser, now.Add(-1*time.Hour))
+		middle := startCompleted(testUser, now.Add(-2*time.Hour))
+		other := startCompleted(otherUser, now.Add(-4*time.Hour))
+		running := startRunning(testUser)
+
+		srv.enforceRetention(context.Background(), now)
+		Expect(remaining()).To(ConsistOf(middle, newest, other, running))
+		Expect(remaining()).NotTo(ContainElement(oldest))
+	})
+
+	It("should keep at most the given number of jobs in total, deleting the oldest completed jobs first", func() {
+		srv.Retention = RetentionPolicy{MaxJobs: 3}
+		running1 := startRunning(testUser)
+		oldest := startCompleted(otherUser, now.Add(-3*time.Hour))
+		newest := startCompleted(testUser, now.Add(-1*time.Hour))
+		middle := startCompleted(testUser, now.Add(-2*time.Hour))
+		running2 := startRunning(otherUser)
+
+		srv.enforceRetention(context.Background(), now)
+		Expect(remaining()).To(ConsistOf(running1, running2, newest))
+		Expect(remaining()).NotTo(ContainElements(oldest, middle))
+	})
+
+	It("should never delete running jobs to stay within the total limit", func() {
+		srv.Retention = RetentionPolicy{MaxJobs: 1}
+		running1 := startRunning(testUser)
+		running2 := startRunning(otherUser)
+		completed := startCompleted(testUser, now.Add(-time.Hour))
+
+		srv.enforceRetention(context.Background(), now)
+		Expect(remaining()).To(ConsistOf(running1, running2))
+		Expect(remaining()).NotTo(ContainElement(completed))
+	})
+
+	It("should count jobs deleted by other limits towards the total limit", func() {
+		srv.Retention = RetentionPolicy{TTL: time.Hour, MaxCompletedPerUser: 1, MaxJobs: 2}
+		expired := startCompleted(testUser, now.Add(-2*time.Hour))
+		overUserLimit := startCompleted(otherUser, now.Add(-50*time.Minute))
+		kept1 := startCompleted(otherUser, now.Add(-10*time.Minute))
+		kept2 := startCompleted(testUser, now.Add(-20*time.Minute))
+
+		srv.enforceRetention(context.Background(), now)
+		Expect(remaining()).To(ConsistOf(kept1, kept2))
+		Expect(remaining()).NotTo(ContainElements(expired, overUserLimit))
+	})
+
+	It("should delete the stored records of deleted jobs", func() {
+		srv.Retention = RetentionPolicy{TTL: time.Hour}
+		startCompleted(testUser, now.Add(-2*time.Hour))
+		recent := startCompleted(testUser, now)
+
+		srv.enforceRetention(context.Background(), now)
+		records, err := srv.Store.List(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(records).To(HaveLen(1))
+		Expect(records[0].GetId()).To(Equal(recent))
+	})
+})
@@ -0,0 +1,417 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	jobv1 "github.com/kralicky/jobserver/pkg/apis/job/v1"
+	storagev1 "github.com/kralicky/jobserver/pkg/apis/storage/v1"
+	"github.com/kralicky/jobserver/pkg/auth"
+	"github.com/kralicky/jobserver/pkg/cron"
+	"github.com/kralicky/jobserver/pkg/jobs"
+	"github.com/kralicky/jobserver/pkg/rbac"
+	"github.com/kralicky/jobserver/pkg/storage"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// scheduleInfo tracks a schedule, which starts a new job from its template
+// each time its cron expression fires.
+type scheduleInfo struct {
+	id     string
+	owner  auth.AuthenticatedUser
+	spec   *jobv1.ScheduleSpec
+	cron   *cron.Schedule
+	cancel context.CancelFunc
+
+	mu       sync.Mutex
+	lastTime time.Time // zero if the schedule has not fired yet
+	nextTime time.Time // zero if the schedule will not fire again
+	message  string
+	disabled bool // true if the schedule's owner may no longer run its jobs
+
+	// serializes writes of the schedule's record to the store, in the same
+	// way as jobInfo.persistMu
+	persistMu sync.Mutex
+	deleted   bool
+}
+
+func (sc *scheduleInfo) setNext(t time.Time) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	sc.nextTime = t
+}
+
+func (sc *scheduleInfo) setFired(t time.Time, message string) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	sc.lastTime = t
+	sc.message = message
+}
+
+func (sc *scheduleInfo) disable(message string) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	sc.disabled = true
+	sc.nextTime = time.Time{}
+	sc.message = message
+}
+
+func (sc *scheduleInfo) isDisabled() bool {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	return sc.disabled
+}
+
+func (sc *scheduleInfo) info(active []*jobInfo) *jobv1.ScheduleInfo {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	info := &jobv1.ScheduleInfo{
+		Id:      &jobv1.ScheduleId{Id: sc.id},
+		Owner:   string(sc.owner),
+		Spec:    proto.Clone(sc.spec).(*jobv1.ScheduleSpec),
+		Message: sc.message,
+	}
+	if !sc.lastTime.IsZero() {
+		info.LastScheduleTime = timestamppb.New(sc.lastTime)
+	}
+	if !sc.nextTime.IsZero() {
+		info.NextScheduleTime = timestamppb.New(sc.nextTime)
+	}
+	for _, job := range active {
+		info.ActiveJobs = append(info.ActiveJobs, &jobv1.JobId{Id: job.ID()})
+	}
+	return info
+}
+
+func (s *Server) lookupScheduleScoped(ctx context.Context, id *jobv1.ScheduleId) (*scheduleInfo, error) {
+	var user auth.AuthenticatedUser
+	// if the schedule doesn't exist, don't short circuit
+	sched, ok := s.schedules.Load(id.GetId())
+	if ok {
+		user = sched.(*scheduleInfo).owner
+	}
+	if err := rbac.VerifyScopeForUser(ctx, user); err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "schedule %s not found", id.GetId())
+	}
+	return sched.(*scheduleInfo), nil
+}
+
+// CreateSchedule implements v1.JobServer.
+func (s *Server) CreateSchedule(ctx context.Context, in *jobv1.ScheduleSpec) (*jobv1.ScheduleId, error) {
+	user := auth.AuthenticatedUserFromContext(ctx)
+	if err := in.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := verifyCredential(ctx, in.GetJobTemplate()); err != nil {
+		return nil, err
+	}
+	schedule, _ := cron.Parse(in.GetCron()) // already validated
+	sched := &scheduleInfo{
+		id:    jobs.NewID(),
+		owner: user,
+		spec:  in,
+		cron:  schedule,
+	}
+	if err := s.ScheduleStore.Put(ctx, scheduleRecord(sched)); err != nil {
+		slog.With("error", err).Error("failed to persist schedule")
+		return nil, status.Errorf(codes.Internal, "failed to create schedule: %v", err)
+	}
+	s.addSchedule(sched)
+	slog.With("id", sched.id, "cron", in.GetCron()).Info("schedule created")
+	return &jobv1.ScheduleId{Id: sched.id}, nil
+}
+
+type userScheduleInfo struct {
+	*jobv1.ScheduleInfo
+	user auth.AuthenticatedUser
+}
+
+func (i userScheduleInfo) AssignedUser() auth.AuthenticatedUser {
+	return i.user
+}
+
+// ListSchedules implements v1.JobServer.
+func (s *Server) ListSchedules(ctx context.Context, req *jobv1.ListSchedulesRequest) (*jobv1.ScheduleList, error) {
+	var schedules []userScheduleInfo
+	s.schedules.Range(func(_, v any) bool {
+		sched := v.(*scheduleInfo)
+		if req.Owner != nil && string(sched.owner) != req.GetOwner() {
+			return true
+		}
+		schedules = append(schedules, userScheduleInfo{
+			ScheduleInfo: sched.info(s.activeScheduleJobs(sched.id)),
+			user:         sched.owner,
+		})
+		return true
+	})
+	schedules, err := rbac.FilterByScope(ctx, schedules)
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(schedules, func(a, b userScheduleInfo) int {
+		return strings.Compare(a.GetId().GetId(), b.GetId().GetId())
+	})
+	resp := &jobv1.ScheduleList{
+		Items: make([]*jobv1.ScheduleInfo, 0, len(schedules)),
+	}
+	for _, sched := range schedules {
+		resp.Items = append(resp.Items, sched.ScheduleInfo)
+	}
+	return resp, nil
+}
+
+// DeleteSchedule implements v1.JobServer.
+func (s *Server) DeleteSchedule(ctx context.Context, id *jobv1.ScheduleId) (*emptypb.Empty, error) {
+	sched, err := s.lookupScheduleScoped(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := s.schedules.LoadAndDelete(sched.id); !ok {
+		return nil, status.Errorf(codes.NotFound, "schedule %s not found", id.GetId())
+	}
+	sched.cancel()
+	sched.persistMu.Lock()
+	defer sched.persistMu.Unlock()
+	sched.deleted = true
+	if err := s.ScheduleStore.Delete(ctx, sched.id); err != nil && !errors.Is(err, storage.ErrNotFound) {
+		slog.With(
+			"id", sched.id,
+			"error", err,
+		).Error("failed to delete schedule data")
+		return nil, status.Errorf(codes.Internal, "failed to delete schedule %s: %s", sched.id, err.Error())
+	}
+	slog.With("id", sched.id).Info("schedule deleted")
+	return &emptypb.Empty{}, nil
+}
+
+// addSchedule adds the schedule to the server, and starts running it until
+// it is deleted.
+func (s *Server) addSchedule(sched *scheduleInfo) {
+	ctx, cancel := context.WithCancel(context.Background())
+	sched.cancel = cancel
+	s.schedules.Store(sched.id, sched)
+	go s.runSchedule(ctx, sched)
+}
+
+// runSchedule fires the schedule at each time given by its cron expression,
+// until the context is canceled or the schedule is disabled.
+func (s *Server) runSchedule(ctx context.Context, sched *scheduleInfo) {
+	for !sched.isDisabled() {
+		next := sched.cron.Next(time.Now())
+		sched.setNext(next)
+		if next.IsZero() {
+			slog.With("id", sched.id).Warn("schedule will not fire again")
+			return
+		}
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		s.fireSchedule(ctx, sched, next)
+	}
+}
+
+// fireSchedule starts a new job from the schedule's template, according to
+// its concurrency policy.
+func (s *Server) fireSchedule(ctx context.Context, sched *scheduleInfo, t time.Time) {
+	lg := slog.With("schedule", sched.id)
+	if err := s.verifyScheduleOwner(sched); err != nil {
+		s.disableSchedule(sched, err)
+		return
+	}
+	if active := s.activeScheduleJobs(sched.id); len(active) > 0 {
+		switch sched.spec.GetConcurrencyPolicy() {
+		case jobv1.ConcurrencyPolicy_FORBID:
+			lg.Info("skipping scheduled job; previous job is still running")
+			sched.setFired(t, "skipped because a previous job is still running")
+			s.persistSchedule(ctx, sched)
+			return
+		case jobv1.ConcurrencyPolicy_REPLACE:
+			for _, job := range active {
+				lg.With("id", job.ID()).Info("stopping previous scheduled job")
+				if err := job.Stop(jobs.StopOptionsForSpec(job.spec)); err != nil && !errors.Is(err, jobs.ErrNotRunning) {
+					lg.With("id", job.ID(), "error", err).Error("failed to stop previous scheduled job")
+				}
+			}
+			for _, job := range active {
+				select {
+				case <-job.Done():
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}
+	spec := proto.Clone(sched.spec.GetJobTemplate()).(*jobv1.JobSpec)
+	job, err := s.startJob(ctx, sched.owner, spec, sched.id)
+	if err != nil {
+		sched.setFired(t, fmt.Sprintf("failed to start job: %v", err))
+	} else {
+		lg.With("id", job.ID()).Info("started scheduled job")
+		sched.setFired(t, fmt.Sprintf("started job %s", job.ID()))
+	}
+	s.persistSchedule(ctx, sched)
+}
+
+// verifyScheduleOwner checks that the owner of the schedule is still allowed
+// to create it, and to run its jobs with the credential in its template,
+// according to the server's current RBAC configuration.
+func (s *Server) verifyScheduleOwner(sched *scheduleInfo) error {
+	if s.RbacConfig == nil {
+		return nil
+	}
+	ctx, err := rbac.NewContextForUser(context.Background(), s.RbacConfig, sched.owner, jobv1.Job_CreateSchedule_FullMethodName)
+	if err != nil {
+		return err
+	}
+	return verifyCredential(ctx, sched.spec.GetJobTemplate())
+}
+
+// disableSchedule stops the schedule from starting any more jobs, because its
+// owner is no longer allowed to run them. The schedule is kept, so that its
+// owner can see why it was disabled and delete it.
+func (s *Server) disableSchedule(sched *scheduleInfo, err error) {
+	slog.With(
+		"id", sched.id,
+		"owner", sched.owner,
+		"error", err,
+	).Warn("disabling schedule; its owner is no longer allowed to run its jobs")
+	sched.disable(fmt.Sprintf("disabled because its owner is no longer allowed to run its jobs: %s", status.Convert(err).Message()))
+}
+
+// activeScheduleJobs returns the jobs started by the schedule with the given
+// id that are not yet done.
+func (s *Server) activeScheduleJobs(scheduleID string) []*jobInfo {
+	var active []*jobInfo
+	s.jobs.Range(func(_, v any) bool {
+		job := v.(*jobInfo)
+		if job.scheduleID == scheduleID && !isDone(job) {
+			active = append(active, job)
+		}
+		return true
+	})
+	slices.SortFunc(active, func(a, b *jobInfo) int {
+		return strings.Compare(a.ID(), b.ID())
+	})
+	return active
+}
+
+// enforceHistoryLimit deletes the oldest completed jobs started by the
+// schedule with the given id, once there are more than its history limit.
+func (s *Server) enforceHistoryLimit(ctx context.Context, scheduleID string) {
+	value, ok := s.schedules.Load(scheduleID)
+	if !ok {
+		return
+	}
+	limit := int(value.(*scheduleInfo).spec.GetHistoryLimit())
+	if limit == 0 {
+		return
+	}
+	var completed []completedJob
+	s.jobs.Range(func(k, v any) bool {
+		job := v.(*jobInfo)
+		if job.scheduleID != scheduleID {
+			return true
+		}
+		if finished, ok := job.finishedAt(); ok {
+			completed = append(completed, completedJob{
+				id:       k.(string),
+				owner:    job.owner,
+				finished: finished,
+			})
+		}
+		return true
+	})
+	if len(completed) <= limit {
+		return
+	}
+	// oldest first
+	slices.SortFunc(completed, func(a, b completedJob) int {
+		return a.finished.Compare(b.finished)
+	})
+	for _, job := range completed[:len(completed)-limit] {
+		// errors are logged by deleteJob
+		s.deleteJob(ctx, job.id)
+	}
+}
+
+func scheduleRecord(sched *scheduleInfo) *storagev1.ScheduleRecord {
+	sched.mu.Lock()
+	defer sched.mu.Unlock()
+	record := &storagev1.ScheduleRecord{
+		Id:    sched.id,
+		Owner: string(sched.owner),
+		Spec:  sched.spec,
+	}
+	if !sched.lastTime.IsZero() {
+		record.LastScheduleTime = timestamppb.New(sched.lastTime)
+	}
+	return record
+}
+
+// persistSchedule writes the schedule to the schedule store.
+func (s *Server) persistSchedule(ctx context.Context, sched *scheduleInfo) {
+	sched.persistMu.Lock()
+	defer sched.persistMu.Unlock()
+	if sched.deleted {
+		return
+	}
+	if err := s.ScheduleStore.Put(ctx, scheduleRecord(sched)); err != nil {
+		slog.With(
+			"id", sched.id,
+			"error", err,
+		).Error("failed to persist schedule")
+	}
+}
+
+// restoreSchedules loads all schedules from the schedule store, and starts
+// running them.
+func (s *Server) restoreSchedules(ctx context.Context) error {
+	records, err := s.ScheduleStore.List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list stored schedules: %w", err)
+	}
+	for _, record := range records {
+		schedule, err := cron.Parse(record.GetSpec().GetCron())
+		if err != nil {
+			slog.With(
+				"id", record.GetId(),
+				"error", err,
+			).Error("failed to restore schedule")
+			continue
+		}
+		sched := &scheduleInfo{
+			id:    record.GetId(),
+			owner: auth.AuthenticatedUser(record.GetOwner()),
+			spec:  record.GetSpec(),
+			cron:  schedule,
+		}
+		if t := record.GetLastScheduleTime(); t != nil {
+			sched.lastTime = t.AsTime()
+		}
+		if err := s.verifyScheduleOwner(sched); err != nil {
+			s.disableSchedule(sched, err)
+		}
+		s.addSchedule(sched)
+	}
+	if len(records) > 0 {
+		slog.Info("restored schedules from store", "count", len(records))
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	jobv1 "github.com/kralicky/jobserver/pkg/apis/job/v1"
+	rbacv1 "github.com/kralicky/jobserver/pkg/apis/rbac/v1"
+	storagev1 "github.com/kralicky/jobserver/pkg/apis/storage/v1"
+	"github.com/kralicky/jobserver/pkg/cron"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Schedules", func() {
+	var srv *Server
+	var rt *fakeRuntime
+	var config *rbacv1.Config
+	BeforeEach(func() {
+		rt = newFakeRuntime()
+		config = newTestRbacConfig()
+		srv = NewServer(rt, Options{RbacConfig: config})
+		DeferCleanup(func() {
+			srv.schedules.Range(func(_, v any) bool {
+				v.(*scheduleInfo).cancel()
+				return true
+			})
+		})
+	})
+	revokeUser := func() {
+		for _, rb := range config.RoleBindings {
+			if rb.GetRoleId() == "user" {
+				rb.Users = []string{otherUser}
+			}
+		}
+	}
+	revokeIdentity := func() {
+		for _, role := range config.Roles {
+			if role.GetId() == "user" {
+				role.AllowedIdentities = []*rbacv1.Identity{{Uids: []uint32{1001}, Gids: []uint32{1001}}}
+			}
+		}
+	}
+	newSchedule := func() *scheduleInfo {
+		spec := &jobv1.ScheduleSpec{
+			Cron:        "* * * * *",
+			JobTemplate: newTestSpec(),
+		}
+		schedule, err := cron.Parse(spec.GetCron())
+		Expect(err).NotTo(HaveOccurred())
+		return &scheduleInfo{
+			id:     "test-schedule",
+			owner:  testUser,
+			spec:   spec,
+			cron:   schedule,
+			cancel: func() {},
+		}
+	}
+	scheduleJobs := func(id string) int {
+		var count int
+		srv.jobs.Range(func(_, v any) bool {
+			if v.(*jobInfo).scheduleID == id {
+				count++
+			}
+			return true
+		})
+		return count
+	}
+
+	Context("restoring schedules", func() {
+		restore := func() *jobv1.ScheduleInfo {
+			sched := newSchedule()
+			Expect(srv.ScheduleStore.Put(context.Background(), &storagev1.ScheduleRecord{
+				Id:    sched.id,
+				Owner: string(sched.owner),
+				Spec:  sched.spec,
+			})).To(Succeed())
+			Expect(srv.restoreSchedules(context.Background())).To(Succeed())
+			v, ok := srv.schedules.Load(sched.id)
+			Expect(ok).To(BeTrue())
+			var info *jobv1.ScheduleInfo
+			Eventually(func() *jobv1.ScheduleInfo {
+				info = v.(*scheduleInfo).info(nil)
+				return info
+			}).Should(Or(
+				HaveField("NextScheduleTime", Not(BeNil())),
+				HaveField("Message", Not(BeEmpty())),
+			))
+			return info
+		}
+		It("should run schedules whose owner is still allowed to run their jobs", func() {
+			info := restore()
+			Expect(info.GetNextScheduleTime()).NotTo(BeNil())
+			Expect(info.GetMessage()).To(BeEmpty())
+		})
+		It("should disable schedules whose owner no longer has the required role", func() {
+			revokeUser()
+			info := restore()
+			Expect(info.GetNextScheduleTime()).To(BeNil())
+			Expect(info.GetMessage()).To(HavePrefix("disabled because its owner is no longer allowed to run its jobs"))
+		})
+		It("should disable schedules whose owner is no longer allowed the template's identity", func() {
+			revokeIdentity()
+			info := restore()
+			Expect(info.GetNextScheduleTime()).To(BeNil())
+			Expect(info.GetMessage()).To(ContainSubstring("not allowed to run jobs as uid 1000"))
+		})
+	})
+
+	Context("firing schedules", func() {
+		It("should start a job if the owner is still allowed to run it", func() {
+			sched := newSchedule()
+			srv.fireSchedule(context.Background(), sched, time.Now())
+			Expect(scheduleJobs(sched.id)).To(Equal(1))
+			Expect(sched.isDisabled()).To(BeFalse())
+		})
+		It("should disable the schedule if the owner is no longer allowed to run its jobs", func() {
+			sched := newSchedule()
+			revokeIdentity()
+			srv.fireSchedule(context.Background(), sched, time.Now())
+			Expect(scheduleJobs(sched.id)).To(BeZero())
+			Expect(sched.isDisabled()).To(BeTrue())
+			Expect(sched.info(nil).GetMessage()).To(HavePrefix("disabled"))
+		})
+		It("should not check the owner if the server has no rbac config", func() {
+			srv.RbacConfig = nil
+			sched := newSchedule()
+			revokeUser()
+			srv.fireSchedule(context.Background(), sched, time.Now())
+			Expect(scheduleJobs(sched.id)).To(Equal(1))
+		})
+	})
+})
@@ -4,24 +4,31 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net"
 	"os"
 	"runtime"
+	"slices"
+	"strings"
 	"sync"
+	"syscall"
 	"time"
 
 	jobv1 "github.com/kralicky/jobserver/pkg/apis/job/v1"
+	rbacv1 "github.com/kralicky/jobserver/pkg/apis/rbac/v1"
 	"github.com/kralicky/jobserver/pkg/auth"
 	"github.com/kralicky/jobserver/pkg/jobs"
+	"github.com/kralicky/jobserver/pkg/labels"
 	"github.com/kralicky/jobserver/pkg/rbac"
+	"github.com/kralicky/jobserver/pkg/storage"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/status"
-	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/proto"
 )
 
 type Options struct {
@@ -30,77 +37,109 @@ type Options struct {
 	CertFile        string
 	KeyFile         string
 	AuthMiddlewares []auth.Middleware
-}
-
-type jobInfo struct {
-	jobs.Process
-	owner  auth.AuthenticatedUser
-	cancel context.CancelCauseFunc
+	// Store is used to persist jobs across server restarts. If nil, jobs are
+	// only kept in memory.
+	Store storage.JobStore
+	// OutputBackend is the backend used to store job output. It is used to
+	// restore the output of jobs loaded from the store. If nil, output is
+	// kept in memory.
+	OutputBackend jobs.OutputBackend
+	// Retention controls when completed jobs are automatically deleted.
+	Retention RetentionPolicy
+	// Admission limits the number of jobs that can run at the same time.
+	Admission AdmissionPolicy
+	// DefaultPidsLimit is the maximum number of processes in jobs that do not
+	// set a process limit in their spec. If 0, such jobs are not limited.
+	DefaultPidsLimit int64
+	// ScheduleStore is used to persist schedules across server restarts. If
+	// nil, schedules are only kept in memory.
+	ScheduleStore storage.ScheduleStore
+	// RbacConfig is used to check that the owners of schedules are still
+	// allowed to run their jobs, when schedules are restored and each time
+	// they start a job, and that users starting workflows are allowed to
+	// start jobs. If nil, schedules and workflows are not checked.
+	RbacConfig *rbacv1.Config
 }
 
 type Server struct {
 	Options
 	jobv1.UnsafeJobServer
-	jobs    sync.Map // map[string]jobInfo
-	runtime jobs.Runtime
+	jobs      sync.Map // map[string]*jobInfo
+	schedules sync.Map // map[string]*scheduleInfo
+	workflows sync.Map // map[string]*workflowInfo
+	runtime   jobs.Runtime
+	watchers  watchers
+	admission *admissionQueue
+	// startMu is held while starting jobs with a name or idempotency key, so
+	// that two jobs with the same name or key can't be started concurrently.
+	startMu sync.Mutex
 }
 
 func NewServer(runtime jobs.Runtime, options Options) *Server {
-	return &Server{
-		Options: options,
-		runtime: runtime,
+	if options.Store == nil {
+		options.Store = storage.NewMemoryStore()
 	}
-}
-
-type userJobId struct {
-	*jobv1.JobId
-	user auth.AuthenticatedUser
-}
-
-func (i userJobId) AssignedUser() auth.AuthenticatedUser {
-	return i.user
-}
-
-// ListJobs implements v1.JobServer.
-func (s *Server) List(ctx context.Context, _ *emptypb.Empty) (*jobv1.JobIdList, error) {
-	var jobIds []userJobId
-	s.jobs.Range(func(k, v any) bool {
-		jobIds = append(jobIds, userJobId{
-			JobId: &jobv1.JobId{Id: k.(string)},
-			user:  v.(jobInfo).owner,
-		})
-		return true
-	})
-
-	var err error
-	jobIds, err = rbac.FilterByScope(ctx, jobIds)
-	if err != nil {
-		return nil, err
+	if options.ScheduleStore == nil {
+		options.ScheduleStore = storage.NewMemoryScheduleStore()
 	}
-
-	var items []*jobv1.JobId
-	for _, id := range jobIds {
-		items = append(items, id.JobId)
+	if options.OutputBackend == nil {
+		options.OutputBackend = jobs.NewMemoryOutputBackend(0)
+	}
+	return &Server{
+		Options:   options,
+		runtime:   runtime,
+		admission: newAdmissionQueue(options.Admission),
 	}
-	return &jobv1.JobIdList{
-		Items: items,
-	}, nil
 }
 
-func (s *Server) lookupScoped(ctx context.Context, id *jobv1.JobId) (jobInfo, error) {
+func (s *Server) lookupScoped(ctx context.Context, id *jobv1.JobId) (*jobInfo, error) {
 	var user auth.AuthenticatedUser
 	// if the job doesn't exist, don't short circuit
-	job, ok := s.jobs.Load(id.Id)
+	job, ok := s.jobs.Load(s.resolveJobID(ctx, id.GetId()))
 	if ok {
-		user = job.(jobInfo).owner
+		user = job.(*jobInfo).owner
 	}
 	if err := rbac.VerifyScopeForUser(ctx, user); err != nil {
-		return jobInfo{}, err
+		return nil, err
 	}
 	if !ok {
-		return jobInfo{}, status.Errorf(codes.NotFound, "job %s not found", id.Id)
+		return nil, status.Errorf(codes.NotFound, "job %s not found", id.GetId())
+	}
+	return job.(*jobInfo), nil
+}
+
+// resolveJobID returns the id of the job referred to by ref, which is either a
+// job id, or the name of one of the caller's own jobs. If ref does not refer
+// to any job, it is returned unchanged.
+func (s *Server) resolveJobID(ctx context.Context, ref string) string {
+	if ref == "" {
+		return ref
+	}
+	if _, ok := s.jobs.Load(ref); ok {
+		return ref
+	}
+	job := s.findJob(auth.AuthenticatedUserFromContext(ctx), func(spec *jobv1.JobSpec) bool {
+		return spec.GetName() == ref
+	})
+	if job == nil {
+		return ref
 	}
-	return job.(jobInfo), nil
+	return job.ID()
+}
+
+// findJob returns a job owned by the given user whose spec matches, or nil if
+// there is no such job.
+func (s *Server) findJob(owner auth.AuthenticatedUser, match func(*jobv1.JobSpec) bool) *jobInfo {
+	var found *jobInfo
+	s.jobs.Range(func(_, v any) bool {
+		job := v.(*jobInfo)
+		if job.owner == owner && match(job.spec) {
+			found = job
+			return false
+		}
+		return true
+	})
+	return found
 }
 
 // GetStatus implements v1.JobServer.
@@ -112,79 +151,231 @@ func (s *Server) Status(ctx context.Context, id *jobv1.JobId) (*jobv1.JobStatus,
 	return job.Status(), nil
 }
 
+// Usage implements v1.JobServer.
+func (s *Server) Usage(ctx context.Context, id *jobv1.JobId) (*jobv1.ResourceUsage, error) {
+	job, err := s.lookupScoped(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	usage, err := job.Usage()
+	if err != nil {
+		if errors.Is(err, jobs.ErrUsageUnavailable) {
+			return nil, status.Errorf(codes.FailedPrecondition, "resource usage of job %s is not available", job.ID())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to read resource usage of job %s: %v", job.ID(), err)
+	}
+	return usage, nil
+}
+
 // Start implements v1.JobServer.
 func (s *Server) Start(ctx context.Context, in *jobv1.JobSpec) (*jobv1.JobId, error) {
 	user := auth.AuthenticatedUserFromContext(ctx)
-	jobCtx, cancel := context.WithCancelCause(context.Background())
-	proc, err := s.runtime.Execute(jobCtx, in)
+	if err := in.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := verifyCredential(ctx, in); err != nil {
+		return nil, err
+	}
+	job, err := s.startJob(ctx, user, in, "")
 	if err != nil {
-		cancel(err)
-		slog.With("error", err).Error("failed to start job")
 		return nil, err
 	}
+	return &jobv1.JobId{Id: job.ID()}, nil
+}
 
-	id := proc.ID()
+// verifyCredential checks that the user is allowed to run jobs with the
+// credential in the spec. Jobs without a credential run as the job server's
+// own uid and gid, which must be allowed instead, unless the user's roles
+// don't restrict identities at all.
+func verifyCredential(ctx context.Context, spec *jobv1.JobSpec) error {
+	cred := spec.GetCommand().GetCredential()
+	if cred == nil {
+		if len(rbac.AllowedIdentitiesFromContext(ctx)) == 0 {
+			return nil
+		}
+		return rbac.VerifyIdentityForUser(ctx, uint32(os.Getuid()), uint32(os.Getgid()))
+	}
+	gids := append([]uint32{cred.GetGid()}, cred.GetGroups()...)
+	return rbac.VerifyIdentityForUser(ctx, cred.GetUid(), gids...)
+}
 
-	s.jobs.Store(id, jobInfo{
-		Process: proc,
-		owner:   user,
-		cancel:  cancel,
-	})
+// startJob creates a new job owned by the given user, and submits it to the
+// admission queue. If the job is admitted immediately, it is started before
+// returning, so that errors can be returned to the caller. The spec must
+// already be validated.
+//
+// If the user already has a job with the same idempotency key as the spec,
+// that job is returned instead. If the user already has a job with the same
+// name, an AlreadyExists error is returned.
+func (s *Server) startJob(ctx context.Context, owner auth.AuthenticatedUser, spec *jobv1.JobSpec, scheduleID string) (*jobInfo, error) {
+	if key := spec.GetIdempotencyKey(); key != "" || spec.GetName() != "" {
+		s.startMu.Lock()
+		defer s.startMu.Unlock()
+		if key != "" {
+			existing := s.findJob(owner, func(other *jobv1.JobSpec) bool {
+				return other.GetIdempotencyKey() == key
+			})
+			if existing != nil {
+				slog.With("id", existing.ID()).Info("job already started with the same idempotency key")
+				return existing, nil
+			}
+		}
+		if name := spec.GetName(); name != "" {
+			existing := s.findJob(owner, func(other *jobv1.JobSpec) bool {
+				return other.GetName() == name
+			})
+			if existing != nil {
+				return nil, status.Errorf(codes.AlreadyExists, "job named %q already exists (%s)", name, existing.ID())
+			}
+		}
+	}
+	spec = s.withDefaultLimits(spec)
+	id := jobs.NewID()
+	jobCtx, cancel := context.WithCancelCause(context.Background())
+	job := newJobInfo(id, owner, spec, cancel)
+	job.queue = s.admission
+	job.scheduleID = scheduleID
+	if s.admission.submit(job) {
+		// start the job immediately, so that errors are returned to the client
+		proc, err := s.runtime.Execute(jobCtx, attemptID(id, 0), spec)
+		if err != nil {
+			s.admission.release(owner)
+			cancel(err)
+			slog.With("error", err).Error("failed to start job")
+			return nil, err
+		}
+		job.setCurrent(proc)
+	} else {
+		slog.With("id", id).Info("job queued")
+	}
+	s.jobs.Store(id, job)
+	s.persist(ctx, job)
+	s.notifyStatus(job)
+	go s.runJob(jobCtx, job)
+	return job, nil
+}
 
-	return &jobv1.JobId{Id: id}, nil
+// withDefaultLimits returns the spec with the server's default limits applied
+// to any limits it does not set. The given spec is not modified.
+func (s *Server) withDefaultLimits(spec *jobv1.JobSpec) *jobv1.JobSpec {
+	if s.DefaultPidsLimit <= 0 || (spec.GetLimits() != nil && spec.GetLimits().Pids != nil) {
+		return spec
+	}
+	spec = proto.Clone(spec).(*jobv1.JobSpec)
+	if spec.Limits == nil {
+		spec.Limits = &jobv1.ResourceLimits{}
+	}
+	spec.Limits.Pids = proto.Int64(s.DefaultPidsLimit)
+	return spec
 }
 
 // Stop implements v1.JobServer.
-func (s *Server) Stop(ctx context.Context, id *jobv1.JobId) (*emptypb.Empty, error) {
-	jobValue, ok := s.jobs.Load(id.Id)
-	if !ok {
-		return nil, status.Errorf(codes.NotFound, "job %s not found", id.Id)
+func (s *Server) Stop(ctx context.Context, in *jobv1.StopRequest) (*jobv1.StopResponse, error) {
+	if err := in.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
-	job := jobValue.(jobInfo)
-	switch job.Status().GetState() {
-	case jobv1.State_RUNNING:
-	default:
-		return nil, status.Errorf(codes.FailedPrecondition, "job %s is not running", id.Id)
+	if in.GetLabelSelector() != "" {
+		return s.stopSelected(ctx, in)
+	}
+	job, err := s.lookupScoped(ctx, in.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if err := job.Stop(stopOptionsForRequest(job, in)); err != nil {
+		if errors.Is(err, jobs.ErrNotRunning) {
+			return nil, status.Errorf(codes.FailedPrecondition, "job %s is not running", job.ID())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to stop job %s: %v", job.ID(), err)
 	}
-
-	job.cancel(jobs.ErrStoppedByUser)
 	select {
 	case <-job.Done():
-		return &emptypb.Empty{}, nil
+		return &jobv1.StopResponse{Stopped: []*jobv1.JobId{{Id: job.ID()}}}, nil
 	case <-ctx.Done():
 		return nil, status.FromContextError(ctx.Err()).Err()
 	}
 }
 
-const maxChunkSize = 512 * 1024 // 512 KiB
-
-// Output implements v1.JobServer.
-func (s *Server) Output(id *jobv1.JobId, stream jobv1.Job_OutputServer) error {
-	jobValue, ok := s.jobs.Load(id.Id)
-	if !ok {
-		return status.Errorf(codes.NotFound, "job %s not found", id.Id)
+// stopSelected stops every job visible to the caller that matches the
+// request's label selector and has not completed, then waits for all of them
+// to complete.
+func (s *Server) stopSelected(ctx context.Context, in *jobv1.StopRequest) (*jobv1.StopResponse, error) {
+	selector, err := labels.ParseSelector(in.GetLabelSelector())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
-	job := jobValue.(jobInfo)
+	var candidates []userJob
+	s.jobs.Range(func(_, v any) bool {
+		job := v.(*jobInfo)
+		if selector.Matches(job.spec.GetLabels()) {
+			candidates = append(candidates, userJob{job})
+		}
+		return true
+	})
+	candidates, err = rbac.FilterByScope(ctx, candidates)
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(candidates, func(a, b userJob) int {
+		return strings.Compare(a.ID(), b.ID())
+	})
 
-	for buf := range job.Output(stream.Context()) {
-		for len(buf) > 0 {
-			chunk := buf
-			if len(chunk) > maxChunkSize {
-				chunk = chunk[:maxChunkSize]
-			}
-			buf = buf[len(chunk):]
-			if err := stream.Send(&jobv1.ProcessOutput{Output: chunk}); err != nil {
-				return err
+	resp := &jobv1.StopResponse{}
+	var stopped []*jobInfo
+	for _, job := range candidates {
+		if err := job.Stop(stopOptionsForRequest(job.jobInfo, in)); err != nil {
+			if errors.Is(err, jobs.ErrNotRunning) {
+				continue
 			}
+			return nil, status.Errorf(codes.Internal, "failed to stop job %s: %v", job.ID(), err)
 		}
+		stopped = append(stopped, job.jobInfo)
+		resp.Stopped = append(resp.Stopped, &jobv1.JobId{Id: job.ID()})
 	}
+	for _, job := range stopped {
+		select {
+		case <-job.Done():
+		case <-ctx.Done():
+			return nil, status.FromContextError(ctx.Err()).Err()
+		}
+	}
+	return resp, nil
+}
 
-	return nil
+type userJob struct {
+	*jobInfo
+}
+
+func (j userJob) AssignedUser() auth.AuthenticatedUser {
+	return j.owner
+}
+
+// stopOptionsForRequest returns the options used to stop a job, using the
+// job's stop policy unless overridden by the request.
+func stopOptionsForRequest(job *jobInfo, in *jobv1.StopRequest) jobs.StopOptions {
+	opts := jobs.StopOptionsForSpec(job.Status().GetSpec())
+	if in.GetSignal() != 0 {
+		opts.Signal = syscall.Signal(in.GetSignal())
+	}
+	if in.GetGracePeriod() != nil {
+		opts.GracePeriod = in.GetGracePeriod().AsDuration()
+	}
+	opts.Force = in.GetForce()
+	return opts
 }
 
 var _ jobv1.JobServer = (*Server)(nil)
 
 func (s *Server) ListenAndServe(ctx context.Context) error {
+	if err := s.restoreJobs(ctx); err != nil {
+		return err
+	}
+	if err := s.restoreSchedules(ctx); err != nil {
+		return err
+	}
+	retentionCtx, cancelRetention := context.WithCancel(ctx)
+	defer cancelRetention()
+	go s.runRetention(retentionCtx)
+
 	cacertData, err := os.ReadFile(s.CaCertFile)
 	if err != nil {
 		return fmt.Errorf("failed to read CA certificate: %w", err)
@@ -0,0 +1,243 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"syscall"
+	"testing"
+
+	jobv1 "github.com/kralicky/jobserver/pkg/apis/job/v1"
+	rbacv1 "github.com/kralicky/jobserver/pkg/apis/rbac/v1"
+	"github.com/kralicky/jobserver/pkg/auth"
+	"github.com/kralicky/jobserver/pkg/jobs"
+	"github.com/kralicky/jobserver/pkg/rbac"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestServer(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Server Suite")
+}
+
+type testAuthenticator struct {
+	user auth.AuthenticatedUser
+}
+
+func (a *testAuthenticator) Authenticate(context.Context) (auth.AuthenticatedUser, error) {
+	return a.user, nil
+}
+
+type testServerTransportStream struct {
+	grpc.ServerTransportStream
+
+	method string
+}
+
+func (t *testServerTransportStream) Method() string {
+	return t.method
+}
+
+const (
+	testUser  = "test-user"
+	otherUser = "other-user"
+	testAdmin = "test-admin"
+)
+
+// newTestRbacConfig returns a config that allows testUser and otherUser to
+// call every method for their own jobs as uid/gid 1000, and testAdmin to call
+// every method for all users' jobs as any identity listed for the users, or
+// as uid/gid 0.
+func newTestRbacConfig() *rbacv1.Config {
+	userRole := &rbacv1.Role{
+		Id:      "user",
+		Service: jobv1.Job_ServiceDesc.ServiceName,
+		AllowedIdentities: []*rbacv1.Identity{
+			{Uids: []uint32{1000}, Gids: []uint32{1000}},
+		},
+	}
+	adminRole := &rbacv1.Role{
+		Id:      "admin",
+		Service: jobv1.Job_ServiceDesc.ServiceName,
+		AllowedIdentities: []*rbacv1.Identity{
+			{Uids: []uint32{0, 1000}, Gids: []uint32{0, 1000}},
+		},
+	}
+	var methods []string
+	for _, m := range jobv1.Job_ServiceDesc.Methods {
+		methods = append(methods, m.MethodName)
+	}
+	for _, s := range jobv1.Job_ServiceDesc.Streams {
+		methods = append(methods, s.StreamName)
+	}
+	for _, name := range methods {
+		userRole.AllowedMethods = append(userRole.AllowedMethods, &rbacv1.AllowedMethod{
+			Name:  name,
+			Scope: rbacv1.Scope_CURRENT_USER.Enum(),
+		})
+		adminRole.AllowedMethods = append(adminRole.AllowedMethods, &rbacv1.AllowedMethod{
+			Name:  name,
+			Scope: rbacv1.Scope_ALL_USERS.Enum(),
+		})
+	}
+	return &rbacv1.Config{
+		Roles: []*rbacv1.Role{userRole, adminRole},
+		RoleBindings: []*rbacv1.RoleBinding{
+			{Id: "users", RoleId: "user", Users: []string{testUser, otherUser}},
+			{Id: "admins", RoleId: "admin", Users: []string{testAdmin}},
+		},
+	}
+}
+
+// contextForMethod returns a context for a call to the given method by the
+// given user, as it would be seen by the server after authentication and
+// authorization.
+func contextForMethod(config *rbacv1.Config, user auth.AuthenticatedUser, method string) context.Context {
+	ctx := grpc.NewContextWithServerTransportStream(context.Background(), &testServerTransportStream{
+		method: "/" + jobv1.Job_ServiceDesc.ServiceName + "/" + method,
+	})
+	for _, m := range []auth.Middleware{
+		auth.NewMiddleware(&testAuthenticator{user: user}),
+		rbac.NewAllowedMethodsMiddleware(config),
+	} {
+		var err error
+		ctx, err = m.Eval(ctx)
+		Expect(err).NotTo(HaveOccurred())
+	}
+	return ctx
+}
+
+// fakeProcess is a jobs.Process that runs until exit is called, or until it
+// is stopped.
+type fakeProcess struct {
+	*restoredProcess
+
+	mu   sync.Mutex
+	done chan struct{}
+}
+
+func newFakeProcess(id string, spec *jobv1.JobSpec) *fakeProcess {
+	return &fakeProcess{
+		restoredProcess: &restoredProcess{
+			id: id,
+			status: &jobv1.JobStatus{
+				State:     jobv1.State_RUNNING,
+				Spec:      spec,
+				StartTime: timestamppb.Now(),
+			},
+		},
+		done: make(chan struct{}),
+	}
+}
+
+func (p *fakeProcess) Status() *jobv1.JobStatus {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return proto.Clone(p.status).(*jobv1.JobStatus)
+}
+
+func (p *fakeProcess) Signal(event *jobv1.SignalEvent) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.status.GetState() != jobv1.State_RUNNING {
+		return jobs.ErrNotRunning
+	}
+	p.status.Signals = append(p.status.Signals, event)
+	return nil
+}
+
+func (p *fakeProcess) Stop(opts jobs.StopOptions) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.status.GetState() != jobv1.State_RUNNING {
+		return jobs.ErrNotRunning
+	}
+	signal := opts.Signal
+	if opts.Force {
+		signal = syscall.SIGKILL
+	}
+	p.exitLocked(&jobv1.TerminationStatus{Signal: int32(signal), Stopped: true})
+	return nil
+}
+
+func (p *fakeProcess) UpdateLimits(*jobv1.ResourceLimits) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.status.GetState() != jobv1.State_RUNNING {
+		return jobs.ErrNotRunning
+	}
+	return nil
+}
+
+func (p *fakeProcess) Done() <-chan struct{} {
+	return p.done
+}
+
+// exit terminates the process with the given exit code.
+func (p *fakeProcess) exit(code int32) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.exitLocked(&jobv1.TerminationStatus{ExitCode: code})
+}
+
+func (p *fakeProcess) exitLocked(term *jobv1.TerminationStatus) {
+	term.Time = timestamppb.Now()
+	p.status.State = jobv1.State_TERMINATED
+	p.status.Terminated = term
+	close(p.done)
+}
+
+var _ jobs.Process = (*fakeProcess)(nil)
+
+// fakeRuntime is a jobs.Runtime that starts fake processes.
+type fakeRuntime struct {
+	mu    sync.Mutex
+	procs map[string]*fakeProcess
+}
+
+func newFakeRuntime() *fakeRuntime {
+	return &fakeRuntime{procs: make(map[string]*fakeProcess)}
+}
+
+func (r *fakeRuntime) Execute(_ context.Context, id string, spec *jobv1.JobSpec) (jobs.Process, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	proc := newFakeProcess(id, spec)
+	r.procs[id] = proc
+	return proc, nil
+}
+
+// process returns the process started with the given id, or nil.
+func (r *fakeRuntime) process(id string) *fakeProcess {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.procs[id]
+}
+
+var _ jobs.Runtime = (*fakeRuntime)(nil)
+
+func newTestSpec(args ...string) *jobv1.JobSpec {
+	return &jobv1.JobSpec{
+		Command: &jobv1.CommandSpec{
+			Command: "/bin/true",
+			Args:    args,
+			Credential: &jobv1.Credential{
+				Uid: 1000,
+				Gid: 1000,
+			},
+		},
+	}
+}
+
+// finished returns whether the job with the given id has finished.
+func (s *Server) finished(id string) bool {
+	v, ok := s.jobs.Load(id)
+	if !ok {
+		return false
+	}
+	_, finished := v.(*jobInfo).finishedAt()
+	return finished
+}
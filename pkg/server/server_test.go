@@ -0,0 +1,79 @@
+package server
+
+import (
+	"os"
+	"time"
+
+	jobv1 "github.com/kralicky/jobserver/pkg/apis/job/v1"
+	rbacv1 "github.com/kralicky/jobserver/pkg/apis/rbac/v1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var _ = Describe("Start", func() {
+	var srv *Server
+	var config *rbacv1.Config
+	BeforeEach(func() {
+		srv = NewServer(newFakeRuntime(), Options{})
+		config = newTestRbacConfig()
+	})
+	userIdentities := func(ids ...*rbacv1.Identity) {
+		for _, role := range config.Roles {
+			if role.GetId() == "user" {
+				role.AllowedIdentities = ids
+			}
+		}
+	}
+	serverIdentity := &rbacv1.Identity{
+		Uids: []uint32{uint32(os.Getuid())},
+		Gids: []uint32{uint32(os.Getgid())},
+	}
+	withoutCredential := func() *jobv1.JobSpec {
+		spec := newTestSpec()
+		spec.Command.Credential = nil
+		return spec
+	}
+
+	It("should reject a deadline in the past", func() {
+		spec := newTestSpec()
+		spec.MaxRuntime = &jobv1.JobSpec_Deadline{Deadline: timestamppb.New(time.Now().Add(-time.Second))}
+		_, err := srv.Start(contextForMethod(config, testUser, "Start"), spec)
+		Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+	})
+
+	When("the spec has a credential", func() {
+		It("should start the job if the identity is allowed", func() {
+			_, err := srv.Start(contextForMethod(config, testUser, "Start"), newTestSpec())
+			Expect(err).NotTo(HaveOccurred())
+		})
+		It("should deny the job if the identity is not allowed", func() {
+			spec := newTestSpec()
+			spec.Command.Credential.Uid = 1001
+			_, err := srv.Start(contextForMethod(config, testUser, "Start"), spec)
+			Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+		})
+	})
+	When("the spec has no credential", func() {
+		It("should deny the job if the server's identity is not allowed", func() {
+			userIdentities(&rbacv1.Identity{
+				Uids: []uint32{uint32(os.Getuid()) + 1},
+				Gids: []uint32{uint32(os.Getgid()) + 1},
+			})
+			_, err := srv.Start(contextForMethod(config, testUser, "Start"), withoutCredential())
+			Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+		})
+		It("should start the job if the server's identity is allowed", func() {
+			userIdentities(serverIdentity)
+			_, err := srv.Start(contextForMethod(config, testUser, "Start"), withoutCredential())
+			Expect(err).NotTo(HaveOccurred())
+		})
+		It("should start the job if the user's roles don't allow any identities", func() {
+			userIdentities()
+			_, err := srv.Start(contextForMethod(config, testUser, "Start"), withoutCredential())
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})
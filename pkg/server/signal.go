@@ -0,0 +1,37 @@
+package server
+
+import (
+	"context"
+	"errors"
+
+	jobv1 "github.com/kralicky/jobserver/pkg/apis/job/v1"
+	"github.com/kralicky/jobserver/pkg/auth"
+	"github.com/kralicky/jobserver/pkg/jobs"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// Signal implements v1.JobServer.
+func (s *Server) Signal(ctx context.Context, in *jobv1.SignalRequest) (*emptypb.Empty, error) {
+	if sig := in.GetSignal(); sig <= 0 || sig > jobv1.MaxSignal {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid signal %d", sig)
+	}
+	job, err := s.lookupScoped(ctx, in.GetId())
+	if err != nil {
+		return nil, err
+	}
+	err = job.Signal(&jobv1.SignalEvent{
+		Signal:       in.GetSignal(),
+		AllProcesses: in.GetAllProcesses(),
+		User:         string(auth.AuthenticatedUserFromContext(ctx)),
+	})
+	if err != nil {
+		if errors.Is(err, jobs.ErrNotRunning) {
+			return nil, status.Errorf(codes.FailedPrecondition, "job %s is not running", job.ID())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to signal job %s: %v", job.ID(), err)
+	}
+	s.persist(ctx, job)
+	return &emptypb.Empty{}, nil
+}
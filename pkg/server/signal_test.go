@@ -0,0 +1,82 @@
+package server
+
+import (
+	jobv1 "github.com/kralicky/jobserver/pkg/apis/job/v1"
+	rbacv1 "github.com/kralicky/jobserver/pkg/apis/rbac/v1"
+	"github.com/kralicky/jobserver/pkg/auth"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ = Describe("Signal", func() {
+	var srv *Server
+	var rt *fakeRuntime
+	var config *rbacv1.Config
+	var id string
+	BeforeEach(func() {
+		rt = newFakeRuntime()
+		srv = NewServer(rt, Options{})
+		config = newTestRbacConfig()
+		resp, err := srv.Start(contextForMethod(config, testUser, "Start"), newTestSpec())
+		Expect(err).NotTo(HaveOccurred())
+		id = resp.GetId()
+	})
+
+	signal := func(user auth.AuthenticatedUser, req *jobv1.SignalRequest) error {
+		_, err := srv.Signal(contextForMethod(config, user, "Signal"), req)
+		return err
+	}
+
+	It("should send the signal to the job and record it", func() {
+		Expect(signal(testUser, &jobv1.SignalRequest{
+			Id:           &jobv1.JobId{Id: id},
+			Signal:       10,
+			AllProcesses: true,
+		})).To(Succeed())
+		signals := rt.process(id).Status().GetSignals()
+		Expect(signals).To(HaveLen(1))
+		Expect(signals[0].GetSignal()).To(BeEquivalentTo(10))
+		Expect(signals[0].GetAllProcesses()).To(BeTrue())
+		Expect(signals[0].GetUser()).To(Equal(string(testUser)))
+	})
+
+	DescribeTable("should reject invalid signals",
+		func(sig int32) {
+			err := signal(testUser, &jobv1.SignalRequest{Id: &jobv1.JobId{Id: id}, Signal: sig})
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+			Expect(rt.process(id).Status().GetSignals()).To(BeEmpty())
+		},
+		Entry("zero", int32(0)),
+		Entry("negative", int32(-1)),
+		Entry("above the maximum", int32(jobv1.MaxSignal+1)),
+	)
+
+	It("should accept the maximum signal", func() {
+		Expect(signal(testUser, &jobv1.SignalRequest{
+			Id:     &jobv1.JobId{Id: id},
+			Signal: jobv1.MaxSignal,
+		})).To(Succeed())
+	})
+
+	It("should fail if the job is not running", func() {
+		rt.process(id).exit(0)
+		Eventually(func() bool { return srv.finished(id) }).Should(BeTrue())
+		err := signal(testUser, &jobv1.SignalRequest{Id: &jobv1.JobId{Id: id}, Signal: 15})
+		Expect(status.Code(err)).To(Equal(codes.FailedPrecondition))
+	})
+
+	It("should fail if the job doesn't exist", func() {
+		// users who may only access their own jobs are denied instead, so
+		// that they can't tell which jobs exist
+		err := signal(testAdmin, &jobv1.SignalRequest{Id: &jobv1.JobId{Id: "missing"}, Signal: 15})
+		Expect(status.Code(err)).To(Equal(codes.NotFound))
+	})
+
+	It("should not signal other users' jobs", func() {
+		err := signal(otherUser, &jobv1.SignalRequest{Id: &jobv1.JobId{Id: id}, Signal: 15})
+		Expect(err).To(HaveOccurred())
+		Expect(rt.process(id).Status().GetSignals()).To(BeEmpty())
+	})
+})
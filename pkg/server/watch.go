@@ -0,0 +1,161 @@
+package server
+
+import (
+	"sync"
+
+	jobv1 "github.com/kralicky/jobserver/pkg/apis/job/v1"
+	"github.com/kralicky/jobserver/pkg/auth"
+	"github.com/kralicky/jobserver/pkg/rbac"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const watcherBufferSize = 64
+
+type watchEvent struct {
+	owner auth.AuthenticatedUser
+	*jobv1.WatchEvent
+}
+
+func (e watchEvent) AssignedUser() auth.AuthenticatedUser {
+	return e.owner
+}
+
+type watcher struct {
+	jobId  string // if empty, all jobs are watched
+	events chan watchEvent
+
+	// closed if the watcher's buffer overflows
+	lagged     chan struct{}
+	laggedOnce sync.Once
+}
+
+type watchers struct {
+	mu     sync.Mutex
+	nextID int64
+	active map[int64]*watcher
+}
+
+func (w *watchers) add(jobId string) (*watcher, func()) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.active == nil {
+		w.active = make(map[int64]*watcher)
+	}
+	w.nextID++
+	id := w.nextID
+	wt := &watcher{
+		jobId:  jobId,
+		events: make(chan watchEvent, watcherBufferSize),
+		lagged: make(chan struct{}),
+	}
+	w.active[id] = wt
+	return wt, func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		delete(w.active, id)
+	}
+}
+
+func (w *watchers) notify(event watchEvent) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, wt := range w.active {
+		if wt.jobId != "" && wt.jobId != event.GetId().GetId() {
+			continue
+		}
+		select {
+		case wt.events <- event:
+		default:
+			wt.laggedOnce.Do(func() { close(wt.lagged) })
+		}
+	}
+}
+
+// notifyStatus sends the current status of the job to all watchers of the job.
+// It must be called each time the state of a job changes.
+func (s *Server) notifyStatus(job *jobInfo) {
+	s.watchers.notify(newWatchEvent(job))
+}
+
+func newWatchEvent(job *jobInfo) watchEvent {
+	return watchEvent{
+		owner: job.owner,
+		WatchEvent: &jobv1.WatchEvent{
+			Id:     &jobv1.JobId{Id: job.ID()},
+			Status: job.Status(),
+		},
+	}
+}
+
+func isCompleted(state jobv1.State) bool {
+	switch state {
+	case jobv1.State_FAILED, jobv1.State_TERMINATED:
+		return true
+	default:
+		return false
+	}
+}
+
+// Watch implements v1.JobServer.
+func (s *Server) Watch(req *jobv1.WatchRequest, stream jobv1.Job_WatchServer) error {
+	ctx := stream.Context()
+	jobId := s.resolveJobID(ctx, req.GetId().GetId())
+
+	// start watching before sending the initial status, so that no changes
+	// are missed in between
+	w, stop := s.watchers.add(jobId)
+	defer stop()
+
+	if jobId != "" {
+		job, err := s.lookupScoped(ctx, &jobv1.JobId{Id: jobId})
+		if err != nil {
+			return err
+		}
+		initial := newWatchEvent(job)
+		if err := stream.Send(initial.WatchEvent); err != nil {
+			return err
+		}
+		if isDone(job) {
+			return nil
+		}
+	} else {
+		var initial []watchEvent
+		s.jobs.Range(func(_, v any) bool {
+			initial = append(initial, newWatchEvent(v.(*jobInfo)))
+			return true
+		})
+		visible, err := rbac.FilterByScope(ctx, initial)
+		if err != nil {
+			return err
+		}
+		for _, event := range visible {
+			if err := stream.Send(event.WatchEvent); err != nil {
+				return err
+			}
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-w.lagged:
+			return status.Errorf(codes.ResourceExhausted, "client is not keeping up with events")
+		case event := <-w.events:
+			visible, err := rbac.FilterByScope(ctx, []watchEvent{event})
+			if err != nil {
+				return err
+			}
+			if len(visible) == 0 {
+				continue
+			}
+			if err := stream.Send(event.WatchEvent); err != nil {
+				return err
+			}
+			if jobId != "" && isCompleted(event.GetStatus().GetState()) {
+				return nil
+			}
+		}
+	}
+}
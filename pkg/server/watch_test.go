@@ -0,0 +1,140 @@
+package server
+
+import (
+	"context"
+
+	jobv1 "github.com/kralicky/jobserver/pkg/apis/job/v1"
+	rbacv1 "github.com/kralicky/jobserver/pkg/apis/rbac/v1"
+	"github.com/kralicky/jobserver/pkg/auth"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+)
+
+// testWatchServer is a jobv1.Job_WatchServer that sends events to a channel.
+type testWatchServer struct {
+	grpc.ServerStream
+
+	ctx    context.Context
+	events chan *jobv1.WatchEvent
+}
+
+func (s *testWatchServer) Context() context.Context {
+	return s.ctx
+}
+
+func (s *testWatchServer) Send(event *jobv1.WatchEvent) error {
+	select {
+	case s.events <- event:
+		return nil
+	case <-s.ctx.Done():
+		return s.ctx.Err()
+	}
+}
+
+var _ = Describe("Watch", func() {
+	var srv *Server
+	var rt *fakeRuntime
+	var config *rbacv1.Config
+	BeforeEach(func() {
+		rt = newFakeRuntime()
+		srv = NewServer(rt, Options{})
+		config = newTestRbacConfig()
+	})
+
+	start := func(user auth.AuthenticatedUser) string {
+		id, err := srv.Start(contextForMethod(config, user, "Start"), newTestSpec())
+		Expect(err).NotTo(HaveOccurred())
+		return id.GetId()
+	}
+	// starts watching in the background; the returned channel receives the
+	// error returned by Watch
+	watch := func(user auth.AuthenticatedUser, id string) (*testWatchServer, <-chan error) {
+		ctx, cancel := context.WithCancel(contextForMethod(config, user, "Watch"))
+		DeferCleanup(cancel)
+		stream := &testWatchServer{ctx: ctx, events: make(chan *jobv1.WatchEvent, 16)}
+		errC := make(chan error, 1)
+		go func() {
+			errC <- srv.Watch(&jobv1.WatchRequest{Id: &jobv1.JobId{Id: id}}, stream)
+		}()
+		return stream, errC
+	}
+	receive := func(stream *testWatchServer) *jobv1.WatchEvent {
+		var event *jobv1.WatchEvent
+		Eventually(stream.events).Should(Receive(&event))
+		return event
+	}
+
+	It("should send the status of a job until it completes", func() {
+		id := start(testUser)
+		stream, errC := watch(testUser, id)
+		event := receive(stream)
+		Expect(event.GetId().GetId()).To(Equal(id))
+		Expect(event.GetStatus().GetState()).To(Equal(jobv1.State_RUNNING))
+
+		rt.process(id).exit(0)
+		event = receive(stream)
+		Expect(event.GetStatus().GetState()).To(Equal(jobv1.State_TERMINATED))
+		Eventually(errC).Should(Receive(BeNil()))
+	})
+
+	It("should only send the initial status of a completed job", func() {
+		id := start(testUser)
+		rt.process(id).exit(0)
+		Eventually(func() bool { return srv.finished(id) }).Should(BeTrue())
+
+		stream, errC := watch(testUser, id)
+		Expect(receive(stream).GetStatus().GetState()).To(Equal(jobv1.State_TERMINATED))
+		Eventually(errC).Should(Receive(BeNil()))
+		Expect(stream.events).To(BeEmpty())
+	})
+
+	It("should not watch other users' jobs", func() {
+		id := start(otherUser)
+		_, errC := watch(testUser, id)
+		Eventually(errC).Should(Receive(HaveOccurred()))
+	})
+
+	It("should send the status of every job in the caller's scope", func() {
+		own := start(testUser)
+		start(otherUser)
+		stream, _ := watch(testUser, "")
+		Expect(receive(stream).GetId().GetId()).To(Equal(own))
+
+		// jobs started while watching are included
+		start(otherUser)
+		newJob := start(testUser)
+		event := receive(stream)
+		Expect(event.GetId().GetId()).To(Equal(newJob))
+		Expect(event.GetStatus().GetState()).To(Equal(jobv1.State_RUNNING))
+
+		rt.process(own).exit(1)
+		event = receive(stream)
+		Expect(event.GetId().GetId()).To(Equal(own))
+		Expect(event.GetStatus().GetState()).To(Equal(jobv1.State_TERMINATED))
+		Consistently(stream.events).ShouldNot(Receive())
+	})
+
+	It("should send the status of every job to admins", func() {
+		job1 := start(testUser)
+		job2 := start(otherUser)
+		stream, _ := watch(testAdmin, "")
+		Expect([]string{
+			receive(stream).GetId().GetId(),
+			receive(stream).GetId().GetId(),
+		}).To(ConsistOf(job1, job2))
+	})
+
+	It("should mark watchers that fall behind as lagged", func() {
+		id := start(testUser)
+		w, stop := srv.watchers.add(id)
+		defer stop()
+		v, _ := srv.jobs.Load(id)
+		for i := 0; i < watcherBufferSize; i++ {
+			srv.notifyStatus(v.(*jobInfo))
+		}
+		Expect(w.lagged).NotTo(BeClosed())
+		srv.notifyStatus(v.(*jobInfo))
+		Expect(w.lagged).To(BeClosed())
+	})
+})
@@ -0,0 +1,244 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	jobv1 "github.com/kralicky/jobserver/pkg/apis/job/v1"
+	"github.com/kralicky/jobserver/pkg/auth"
+	"github.com/kralicky/jobserver/pkg/jobs"
+	"github.com/kralicky/jobserver/pkg/rbac"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// workflowInfo tracks a workflow, and the state of each of its jobs.
+type workflowInfo struct {
+	id    string
+	owner auth.AuthenticatedUser
+	spec  *jobv1.WorkflowSpec
+
+	mu       sync.Mutex
+	jobs     []*jobv1.WorkflowJobStatus // in the same order as spec.Jobs
+	finished time.Time                  // zero until every job has completed or been skipped
+}
+
+// finishedAt returns the time at which the workflow completed, and whether it
+// has completed.
+func (wf *workflowInfo) finishedAt() (time.Time, bool) {
+	wf.mu.Lock()
+	defer wf.mu.Unlock()
+	return wf.finished, !wf.finished.IsZero()
+}
+
+// jobIDs returns the ids of the jobs started by the workflow.
+func (wf *workflowInfo) jobIDs() []string {
+	wf.mu.Lock()
+	defer wf.mu.Unlock()
+	var ids []string
+	for _, job := range wf.jobs {
+		if job.Id != nil {
+			ids = append(ids, job.GetId().GetId())
+		}
+	}
+	return ids
+}
+
+func (wf *workflowInfo) status() *jobv1.WorkflowStatus {
+	wf.mu.Lock()
+	defer wf.mu.Unlock()
+	status := &jobv1.WorkflowStatus{
+		State: jobv1.WorkflowState_WORKFLOW_SUCCEEDED,
+		Owner: string(wf.owner),
+	}
+	for _, job := range wf.jobs {
+		status.Jobs = append(status.Jobs, proto.Clone(job).(*jobv1.WorkflowJobStatus))
+		switch job.GetState() {
+		case jobv1.WorkflowJobState_WORKFLOW_JOB_WAITING, jobv1.WorkflowJobState_WORKFLOW_JOB_STARTED:
+			status.State = jobv1.WorkflowState_WORKFLOW_RUNNING
+		case jobv1.WorkflowJobState_WORKFLOW_JOB_FAILED, jobv1.WorkflowJobState_WORKFLOW_JOB_SKIPPED:
+			if status.State != jobv1.WorkflowState_WORKFLOW_RUNNING {
+				status.State = jobv1.WorkflowState_WORKFLOW_FAILED
+			}
+		}
+	}
+	return status
+}
+
+func (s *Server) lookupWorkflowScoped(ctx context.Context, id *jobv1.WorkflowId) (*workflowInfo, error) {
+	var user auth.AuthenticatedUser
+	// if the workflow doesn't exist, don't short circuit
+	wf, ok := s.workflows.Load(id.GetId())
+	if ok {
+		user = wf.(*workflowInfo).owner
+	}
+	if err := rbac.VerifyScopeForUser(ctx, user); err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "workflow %s not found", id.GetId())
+	}
+	return wf.(*workflowInfo), nil
+}
+
+// StartWorkflow implements v1.JobServer.
+func (s *Server) StartWorkflow(ctx context.Context, in *jobv1.WorkflowSpec) (*jobv1.WorkflowId, error) {
+	user := auth.AuthenticatedUserFromContext(ctx)
+	if err := in.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	// starting a workflow starts each of its jobs, so the user must also be
+	// allowed to start jobs directly
+	if s.RbacConfig != nil {
+		if _, err := rbac.NewContextForUser(ctx, s.RbacConfig, user, jobv1.Job_Start_FullMethodName); err != nil {
+			return nil, err
+		}
+	}
+	for _, job := range in.GetJobs() {
+		if err := verifyCredential(ctx, job.GetSpec()); err != nil {
+			return nil, err
+		}
+	}
+	wf := &workflowInfo{
+		id:    jobs.NewID(),
+		owner: user,
+		spec:  in,
+	}
+	for _, job := range in.GetJobs() {
+		wf.jobs = append(wf.jobs, &jobv1.WorkflowJobStatus{
+			Name:    job.GetName(),
+			State:   jobv1.WorkflowJobState_WORKFLOW_JOB_WAITING,
+			Message: "waiting for dependencies to complete",
+		})
+	}
+	s.workflows.Store(wf.id, wf)
+	slog.With("id", wf.id, "jobs", len(in.GetJobs())).Info("workflow started")
+	go s.runWorkflow(context.Background(), wf)
+	return &jobv1.WorkflowId{Id: wf.id}, nil
+}
+
+// GetWorkflowStatus implements v1.JobServer.
+func (s *Server) GetWorkflowStatus(ctx context.Context, id *jobv1.WorkflowId) (*jobv1.WorkflowStatus, error) {
+	wf, err := s.lookupWorkflowScoped(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return wf.status(), nil
+}
+
+type workflowJobResult struct {
+	index  int
+	status *jobv1.JobStatus
+}
+
+// runWorkflow starts each of the workflow's jobs once their dependencies have
+// completed successfully, until every job has completed or been skipped.
+func (s *Server) runWorkflow(ctx context.Context, wf *workflowInfo) {
+	doneC := make(chan workflowJobResult)
+	var running int
+	for {
+		running += s.advanceWorkflow(ctx, wf, doneC)
+		if running == 0 {
+			break
+		}
+		wf.completeJob(<-doneC)
+		running--
+	}
+	wf.mu.Lock()
+	wf.finished = time.Now()
+	wf.mu.Unlock()
+	slog.With("id", wf.id, "state", wf.status().GetState()).Info("workflow completed")
+}
+
+// advanceWorkflow starts every waiting job whose dependencies have completed
+// successfully, and skips every waiting job with a dependency that did not.
+// Once a started job is done, its final status is sent to doneC. Returns the
+// number of jobs that were started.
+func (s *Server) advanceWorkflow(ctx context.Context, wf *workflowInfo, doneC chan<- workflowJobResult) int {
+	wf.mu.Lock()
+	defer wf.mu.Unlock()
+	states := make(map[string]*jobv1.WorkflowJobStatus, len(wf.jobs))
+	for _, job := range wf.jobs {
+		states[job.GetName()] = job
+	}
+	var started int
+	// skipping a job can allow other jobs to be skipped, so repeat until
+	// nothing changes
+	for changed := true; changed; {
+		changed = false
+		for i, job := range wf.jobs {
+			if job.GetState() != jobv1.WorkflowJobState_WORKFLOW_JOB_WAITING {
+				continue
+			}
+			spec := wf.spec.GetJobs()[i]
+			ready := true
+			var unsuccessful string
+			for _, dep := range spec.GetDependsOn() {
+				switch states[dep].GetState() {
+				case jobv1.WorkflowJobState_WORKFLOW_JOB_SUCCEEDED:
+				case jobv1.WorkflowJobState_WORKFLOW_JOB_FAILED, jobv1.WorkflowJobState_WORKFLOW_JOB_SKIPPED:
+					unsuccessful = dep
+					ready = false
+				default:
+					ready = false
+				}
+			}
+			if unsuccessful != "" {
+				job.State = jobv1.WorkflowJobState_WORKFLOW_JOB_SKIPPED
+				job.Message = fmt.Sprintf("skipped because %s did not complete successfully", unsuccessful)
+				changed = true
+				continue
+			}
+			if !ready {
+				continue
+			}
+			changed = true
+			proc, err := s.startJob(ctx, wf.owner, proto.Clone(spec.GetSpec()).(*jobv1.JobSpec), "")
+			if err != nil {
+				job.State = jobv1.WorkflowJobState_WORKFLOW_JOB_FAILED
+				job.Message = fmt.Sprintf("failed to start: %v", err)
+				continue
+			}
+			slog.With("workflow", wf.id, "name", job.GetName(), "id", proc.ID()).Info("started workflow job")
+			job.State = jobv1.WorkflowJobState_WORKFLOW_JOB_STARTED
+			job.Id = &jobv1.JobId{Id: proc.ID()}
+			job.Message = "started"
+			started++
+			go func(i int) {
+				<-proc.Done()
+				doneC <- workflowJobResult{index: i, status: proc.Status()}
+			}(i)
+		}
+	}
+	return started
+}
+
+// completeJob records the final status of one of the workflow's jobs.
+func (wf *workflowInfo) completeJob(result workflowJobResult) {
+	wf.mu.Lock()
+	defer wf.mu.Unlock()
+	job := wf.jobs[result.index]
+	job.Message = result.status.GetMessage()
+	if succeeded(result.status) {
+		job.State = jobv1.WorkflowJobState_WORKFLOW_JOB_SUCCEEDED
+	} else {
+		job.State = jobv1.WorkflowJobState_WORKFLOW_JOB_FAILED
+	}
+}
+
+// succeeded returns whether a job completed successfully, i.e. exited with
+// code 0 without being stopped or killed by a signal.
+func succeeded(status *jobv1.JobStatus) bool {
+	if status.GetState() != jobv1.State_TERMINATED {
+		return false
+	}
+	term := status.GetTerminated()
+	return term.GetExitCode() == 0 &&
+		term.GetSignal() == 0 &&
+		!term.GetStopped() &&
+		!term.GetDeadlineExceeded()
+}
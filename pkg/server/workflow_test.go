@@ -0,0 +1,192 @@
+package server
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	jobv1 "github.com/kralicky/jobserver/pkg/apis/job/v1"
+	rbacv1 "github.com/kralicky/jobserver/pkg/apis/rbac/v1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ = Describe("Workflows", func() {
+	var srv *Server
+	var rt *fakeRuntime
+	var config *rbacv1.Config
+	BeforeEach(func() {
+		rt = newFakeRuntime()
+		config = newTestRbacConfig()
+		srv = NewServer(rt, Options{})
+	})
+
+	job := func(name string, dependsOn ...string) *jobv1.WorkflowJob {
+		return &jobv1.WorkflowJob{
+			Name:      name,
+			DependsOn: dependsOn,
+			Spec:      newTestSpec(),
+		}
+	}
+	newWorkflow := func(jobs ...*jobv1.WorkflowJob) *workflowInfo {
+		wf := &workflowInfo{
+			id:    "test-workflow",
+			owner: testUser,
+			spec:  &jobv1.WorkflowSpec{Jobs: jobs},
+		}
+		for _, job := range jobs {
+			wf.jobs = append(wf.jobs, &jobv1.WorkflowJobStatus{
+				Name:  job.GetName(),
+				State: jobv1.WorkflowJobState_WORKFLOW_JOB_WAITING,
+			})
+		}
+		return wf
+	}
+	states := func(wf *workflowInfo) map[string]jobv1.WorkflowJobState {
+		states := make(map[string]jobv1.WorkflowJobState)
+		for _, job := range wf.status().GetJobs() {
+			states[job.GetName()] = job.GetState()
+		}
+		return states
+	}
+
+	Describe("advanceWorkflow", func() {
+		It("should start jobs without dependencies", func() {
+			wf := newWorkflow(job("a"), job("b", "a"), job("c"))
+			Expect(srv.advanceWorkflow(context.Background(), wf, make(chan workflowJobResult, 3))).To(Equal(2))
+			Expect(states(wf)).To(Equal(map[string]jobv1.WorkflowJobState{
+				"a": jobv1.WorkflowJobState_WORKFLOW_JOB_STARTED,
+				"b": jobv1.WorkflowJobState_WORKFLOW_JOB_WAITING,
+				"c": jobv1.WorkflowJobState_WORKFLOW_JOB_STARTED,
+			}))
+		})
+		It("should start jobs once all of their dependencies have succeeded", func() {
+			wf := newWorkflow(job("a"), job("b"), job("c", "a", "b"))
+			wf.jobs[0].State = jobv1.WorkflowJobState_WORKFLOW_JOB_SUCCEEDED
+			wf.jobs[1].State = jobv1.WorkflowJobState_WORKFLOW_JOB_STARTED
+			Expect(srv.advanceWorkflow(context.Background(), wf, make(chan workflowJobResult, 1))).To(BeZero())
+			Expect(wf.jobs[2].GetState()).To(Equal(jobv1.WorkflowJobState_WORKFLOW_JOB_WAITING))
+
+			wf.jobs[1].State = jobv1.WorkflowJobState_WORKFLOW_JOB_SUCCEEDED
+			Expect(srv.advanceWorkflow(context.Background(), wf, make(chan workflowJobResult, 1))).To(Equal(1))
+			Expect(wf.jobs[2].GetState()).To(Equal(jobv1.WorkflowJobState_WORKFLOW_JOB_STARTED))
+			Expect(rt.process(wf.jobs[2].GetId().GetId())).NotTo(BeNil())
+		})
+		It("should propagate skips through every dependent job, regardless of order", func() {
+			// d depends on c, which depends on b, which depends on a; e only
+			// depends on a job that succeeded
+			wf := newWorkflow(job("d", "c"), job("c", "b"), job("e", "f"), job("b", "a"), job("a"), job("f"))
+			wf.jobs[4].State = jobv1.WorkflowJobState_WORKFLOW_JOB_FAILED
+			wf.jobs[5].State = jobv1.WorkflowJobState_WORKFLOW_JOB_SUCCEEDED
+			Expect(srv.advanceWorkflow(context.Background(), wf, make(chan workflowJobResult, 1))).To(Equal(1))
+			Expect(states(wf)).To(Equal(map[string]jobv1.WorkflowJobState{
+				"a": jobv1.WorkflowJobState_WORKFLOW_JOB_FAILED,
+				"b": jobv1.WorkflowJobState_WORKFLOW_JOB_SKIPPED,
+				"c": jobv1.WorkflowJobState_WORKFLOW_JOB_SKIPPED,
+				"d": jobv1.WorkflowJobState_WORKFLOW_JOB_SKIPPED,
+				"e": jobv1.WorkflowJobState_WORKFLOW_JOB_STARTED,
+				"f": jobv1.WorkflowJobState_WORKFLOW_JOB_SUCCEEDED,
+			}))
+			Expect(wf.jobs[0].GetMessage()).To(Equal("skipped because c did not complete successfully"))
+			Expect(wf.jobs[3].GetMessage()).To(Equal("skipped because a did not complete successfully"))
+			Expect(wf.status().GetState()).To(Equal(jobv1.WorkflowState_WORKFLOW_RUNNING))
+		})
+	})
+
+	It("should deny the workflow if the user is not allowed to start jobs", func() {
+		for _, role := range config.Roles {
+			if role.GetId() == "user" {
+				role.AllowedMethods = slices.DeleteFunc(role.AllowedMethods, func(m *rbacv1.AllowedMethod) bool {
+					return m.GetName() == "Start"
+				})
+			}
+		}
+		srv.RbacConfig = config
+		_, err := srv.StartWorkflow(contextForMethod(config, testUser, "StartWorkflow"), &jobv1.WorkflowSpec{
+			Jobs: []*jobv1.WorkflowJob{job("a")},
+		})
+		Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+		Expect(rt.procs).To(BeEmpty())
+
+		srv.RbacConfig = newTestRbacConfig()
+		_, err = srv.StartWorkflow(contextForMethod(config, testUser, "StartWorkflow"), &jobv1.WorkflowSpec{
+			Jobs: []*jobv1.WorkflowJob{job("a")},
+		})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should skip the jobs that depend on a failed job, and then complete", func() {
+		ctx := contextForMethod(config, testUser, "StartWorkflow")
+		id, err := srv.StartWorkflow(ctx, &jobv1.WorkflowSpec{
+			Jobs: []*jobv1.WorkflowJob{job("a"), job("b", "a"), job("c", "a"), job("d", "b", "c"), job("e")},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		statusCtx := contextForMethod(config, testUser, "GetWorkflowStatus")
+		jobID := func(i int) string {
+			var jobID string
+			Eventually(func() string {
+				status, err := srv.GetWorkflowStatus(statusCtx, id)
+				Expect(err).NotTo(HaveOccurred())
+				jobID = status.GetJobs()[i].GetId().GetId()
+				return jobID
+			}).ShouldNot(BeEmpty())
+			return jobID
+		}
+
+		rt.process(jobID(4)).exit(0)
+		rt.process(jobID(0)).exit(1)
+		Eventually(func() jobv1.WorkflowState {
+			status, _ := srv.GetWorkflowStatus(statusCtx, id)
+			return status.GetState()
+		}).Should(Equal(jobv1.WorkflowState_WORKFLOW_FAILED))
+		status, err := srv.GetWorkflowStatus(statusCtx, id)
+		Expect(err).NotTo(HaveOccurred())
+		var states []jobv1.WorkflowJobState
+		for _, job := range status.GetJobs() {
+			states = append(states, job.GetState())
+		}
+		Expect(states).To(Equal([]jobv1.WorkflowJobState{
+			jobv1.WorkflowJobState_WORKFLOW_JOB_FAILED,
+			jobv1.WorkflowJobState_WORKFLOW_JOB_SKIPPED,
+			jobv1.WorkflowJobState_WORKFLOW_JOB_SKIPPED,
+			jobv1.WorkflowJobState_WORKFLOW_JOB_SKIPPED,
+			jobv1.WorkflowJobState_WORKFLOW_JOB_SUCCEEDED,
+		}))
+	})
+
+	It("should be deleted by the retention policy along with the last of its jobs", func() {
+		srv.Retention = RetentionPolicy{MaxCompletedPerUser: 1}
+		ctx := contextForMethod(config, testUser, "StartWorkflow")
+		id, err := srv.StartWorkflow(ctx, &jobv1.WorkflowSpec{
+			Jobs: []*jobv1.WorkflowJob{job("a"), job("b", "a")},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		value, ok := srv.workflows.Load(id.GetId())
+		Expect(ok).To(BeTrue())
+		wf := value.(*workflowInfo)
+
+		Eventually(wf.jobIDs).Should(HaveLen(1))
+		rt.process(wf.jobIDs()[0]).exit(0)
+		Eventually(wf.jobIDs).Should(HaveLen(2))
+		srv.enforceRetention(context.Background(), time.Now())
+		_, ok = srv.workflows.Load(id.GetId())
+		Expect(ok).To(BeTrue(), "the workflow is still running")
+
+		rt.process(wf.jobIDs()[1]).exit(0)
+		Eventually(func() bool {
+			_, finished := wf.finishedAt()
+			return finished
+		}).Should(BeTrue())
+		// only the newest job is kept
+		srv.enforceRetention(context.Background(), time.Now())
+		_, ok = srv.workflows.Load(id.GetId())
+		Expect(ok).To(BeTrue(), "one of the workflow's jobs still exists")
+
+		Expect(srv.deleteJob(context.Background(), wf.jobIDs()[1])).To(Succeed())
+		srv.enforceRetention(context.Background(), time.Now())
+		_, ok = srv.workflows.Load(id.GetId())
+		Expect(ok).To(BeFalse())
+	})
+})
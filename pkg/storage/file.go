@@ -0,0 +1,197 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	storagev1 "github.com/kralicky/jobserver/pkg/apis/storage/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+const recordFileExt = ".pb"
+
+// FileStore is a JobStore that keeps each record in its own file within a
+// local directory. Records are written atomically, so a crash in the middle
+// of a write will never leave a partially written record behind.
+type FileStore struct {
+	records fileRecords[*storagev1.JobRecord]
+}
+
+var _ JobStore = (*FileStore)(nil)
+
+// NewFileStore returns a FileStore that keeps its records in the 'jobs'
+// subdirectory of dataDir. The directory is created if it does not exist.
+func NewFileStore(dataDir string) (*FileStore, error) {
+	records, err := newFileRecords[*storagev1.JobRecord](filepath.Join(dataDir, "jobs"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create job store directory: %w", err)
+	}
+	return &FileStore{records: records}, nil
+}
+
+// Put implements JobStore.
+func (s *FileStore) Put(_ context.Context, record *storagev1.JobRecord) error {
+	return s.records.put(record.GetId(), record)
+}
+
+// Get implements JobStore.
+func (s *FileStore) Get(_ context.Context, id string) (*storagev1.JobRecord, error) {
+	return s.records.get(id)
+}
+
+// List implements JobStore.
+func (s *FileStore) List(_ context.Context) ([]*storagev1.JobRecord, error) {
+	return s.records.list()
+}
+
+// Delete implements JobStore.
+func (s *FileStore) Delete(_ context.Context, id string) error {
+	return s.records.delete(id)
+}
+
+// FileScheduleStore is a ScheduleStore that keeps each record in its own file
+// within a local directory, in the same way as FileStore.
+type FileScheduleStore struct {
+	records fileRecords[*storagev1.ScheduleRecord]
+}
+
+var _ ScheduleStore = (*FileScheduleStore)(nil)
+
+// NewFileScheduleStore returns a FileScheduleStore that keeps its records in
+// the 'schedules' subdirectory of dataDir. The directory is created if it
+// does not exist.
+func NewFileScheduleStore(dataDir string) (*FileScheduleStore, error) {
+	records, err := newFileRecords[*storagev1.ScheduleRecord](filepath.Join(dataDir, "schedules"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create schedule store directory: %w", err)
+	}
+	return &FileScheduleStore{records: records}, nil
+}
+
+// Put implements ScheduleStore.
+func (s *FileScheduleStore) Put(_ context.Context, record *storagev1.ScheduleRecord) error {
+	return s.records.put(record.GetId(), record)
+}
+
+// List implements ScheduleStore.
+func (s *FileScheduleStore) List(_ context.Context) ([]*storagev1.ScheduleRecord, error) {
+	return s.records.list()
+}
+
+// Delete implements ScheduleStore.
+func (s *FileScheduleStore) Delete(_ context.Context, id string) error {
+	return s.records.delete(id)
+}
+
+// fileRecords stores records of a single type in a directory, one file per
+// record.
+type fileRecords[T proto.Message] struct {
+	dir string
+}
+
+func newFileRecords[T proto.Message](dir string) (fileRecords[T], error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fileRecords[T]{}, err
+	}
+	return fileRecords[T]{dir: dir}, nil
+}
+
+func (r fileRecords[T]) put(id string, record T) error {
+	path, err := r.recordPath(id)
+	if err != nil {
+		return err
+	}
+	data, err := proto.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal record: %w", err)
+	}
+	f, err := os.CreateTemp(r.dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		return errors.Join(err, f.Close(), os.Remove(f.Name()))
+	}
+	if err := f.Sync(); err != nil {
+		return errors.Join(err, f.Close(), os.Remove(f.Name()))
+	}
+	if err := f.Close(); err != nil {
+		return errors.Join(err, os.Remove(f.Name()))
+	}
+	if err := os.Rename(f.Name(), path); err != nil {
+		return errors.Join(err, os.Remove(f.Name()))
+	}
+	return nil
+}
+
+func (r fileRecords[T]) get(id string) (T, error) {
+	path, err := r.recordPath(id)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return readRecord[T](path)
+}
+
+func (r fileRecords[T]) list() ([]T, error) {
+	entries, err := os.ReadDir(r.dir)
+	if err != nil {
+		return nil, err
+	}
+	records := make([]T, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != recordFileExt {
+			continue
+		}
+		record, err := readRecord[T](filepath.Join(r.dir, entry.Name()))
+		if err != nil {
+			if errors.Is(err, ErrNotFound) {
+				continue // deleted concurrently
+			}
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+func (r fileRecords[T]) delete(id string) error {
+	path, err := r.recordPath(id)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return ErrNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (r fileRecords[T]) recordPath(id string) (string, error) {
+	if id == "" || strings.ContainsAny(id, `/\.`) {
+		return "", fmt.Errorf("invalid record id %q", id)
+	}
+	return filepath.Join(r.dir, id+recordFileExt), nil
+}
+
+func readRecord[T proto.Message](path string) (T, error) {
+	var record T
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return record, ErrNotFound
+		}
+		return record, err
+	}
+	record = record.ProtoReflect().Type().New().Interface().(T)
+	if err := proto.Unmarshal(data, record); err != nil {
+		return record, fmt.Errorf("failed to unmarshal record %s: %w", path, err)
+	}
+	return record, nil
+}
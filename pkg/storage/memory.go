@@ -0,0 +1,122 @@
+package storage
+
+import (
+	"context"
+	"sync"
+
+	storagev1 "github.com/kralicky/jobserver/pkg/apis/storage/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// MemoryStore is a JobStore that keeps all records in memory. Records are
+// lost when the server exits.
+type MemoryStore struct {
+	records *memoryRecords[*storagev1.JobRecord]
+}
+
+var _ JobStore = (*MemoryStore)(nil)
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		records: newMemoryRecords[*storagev1.JobRecord](),
+	}
+}
+
+// Put implements JobStore.
+func (s *MemoryStore) Put(_ context.Context, record *storagev1.JobRecord) error {
+	s.records.put(record.GetId(), record)
+	return nil
+}
+
+// Get implements JobStore.
+func (s *MemoryStore) Get(_ context.Context, id string) (*storagev1.JobRecord, error) {
+	return s.records.get(id)
+}
+
+// List implements JobStore.
+func (s *MemoryStore) List(_ context.Context) ([]*storagev1.JobRecord, error) {
+	return s.records.list(), nil
+}
+
+// Delete implements JobStore.
+func (s *MemoryStore) Delete(_ context.Context, id string) error {
+	return s.records.delete(id)
+}
+
+// MemoryScheduleStore is a ScheduleStore that keeps all records in memory.
+// Records are lost when the server exits.
+type MemoryScheduleStore struct {
+	records *memoryRecords[*storagev1.ScheduleRecord]
+}
+
+var _ ScheduleStore = (*MemoryScheduleStore)(nil)
+
+func NewMemoryScheduleStore() *MemoryScheduleStore {
+	return &MemoryScheduleStore{
+		records: newMemoryRecords[*storagev1.ScheduleRecord](),
+	}
+}
+
+// Put implements ScheduleStore.
+func (s *MemoryScheduleStore) Put(_ context.Context, record *storagev1.ScheduleRecord) error {
+	s.records.put(record.GetId(), record)
+	return nil
+}
+
+// List implements ScheduleStore.
+func (s *MemoryScheduleStore) List(_ context.Context) ([]*storagev1.ScheduleRecord, error) {
+	return s.records.list(), nil
+}
+
+// Delete implements ScheduleStore.
+func (s *MemoryScheduleStore) Delete(_ context.Context, id string) error {
+	return s.records.delete(id)
+}
+
+// memoryRecords stores copies of records of a single type in memory.
+type memoryRecords[T proto.Message] struct {
+	mu      sync.RWMutex
+	records map[string]T
+}
+
+func newMemoryRecords[T proto.Message]() *memoryRecords[T] {
+	return &memoryRecords[T]{
+		records: make(map[string]T),
+	}
+}
+
+func (r *memoryRecords[T]) put(id string, record T) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.records[id] = proto.Clone(record).(T)
+}
+
+func (r *memoryRecords[T]) get(id string) (T, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	record, ok := r.records[id]
+	if !ok {
+		return record, ErrNotFound
+	}
+	return proto.Clone(record).(T), nil
+}
+
+func (r *memoryRecords[T]) list() []T {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	records := make([]T, 0, len(r.records))
+	for _, record := range r.records {
+		records = append(records, proto.Clone(record).(T))
+	}
+	return records
+}
+
+func (r *memoryRecords[T]) delete(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.records[id]; !ok {
+		return ErrNotFound
+	}
+	delete(r.records, id)
+	return nil
+}
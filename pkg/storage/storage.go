@@ -0,0 +1,47 @@
+package storage
+
+import (
+	"context"
+	"errors"
+
+	storagev1 "github.com/kralicky/jobserver/pkg/apis/storage/v1"
+)
+
+// ErrNotFound is returned by JobStore methods when the requested record
+// does not exist.
+var ErrNotFound = errors.New("record not found")
+
+// JobStore persists the jobs known to the server, so that they can be
+// restored after the server is restarted.
+//
+// Implementations must be safe to call concurrently from multiple goroutines.
+// Records passed to and returned from a JobStore must not be modified by the
+// store or by the caller after the call returns; implementations are expected
+// to make copies where necessary.
+type JobStore interface {
+	// Creates or replaces the record for the job with the record's id.
+	Put(ctx context.Context, record *storagev1.JobRecord) error
+	// Returns the record for the job with the given id, or ErrNotFound if
+	// no such record exists.
+	Get(ctx context.Context, id string) (*storagev1.JobRecord, error)
+	// Returns all stored records. No guarantees are made about the order of
+	// records in the list.
+	List(ctx context.Context) ([]*storagev1.JobRecord, error)
+	// Deletes the record for the job with the given id. Returns ErrNotFound
+	// if no such record exists.
+	Delete(ctx context.Context, id string) error
+}
+
+// ScheduleStore persists the schedules known to the server, so that they can
+// be restored after the server is restarted. The same rules apply as for
+// JobStore.
+type ScheduleStore interface {
+	// Creates or replaces the record for the schedule with the record's id.
+	Put(ctx context.Context, record *storagev1.ScheduleRecord) error
+	// Returns all stored records. No guarantees are made about the order of
+	// records in the list.
+	List(ctx context.Context) ([]*storagev1.ScheduleRecord, error)
+	// Deletes the record for the schedule with the given id. Returns
+	// ErrNotFound if no such record exists.
+	Delete(ctx context.Context, id string) error
+}
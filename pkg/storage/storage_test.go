@@ -0,0 +1,132 @@
+package storage_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/types"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	jobv1 "github.com/kralicky/jobserver/pkg/apis/job/v1"
+	storagev1 "github.com/kralicky/jobserver/pkg/apis/storage/v1"
+	"github.com/kralicky/jobserver/pkg/storage"
+)
+
+func equalProto(expected proto.Message) types.GomegaMatcher {
+	return WithTransform(func(actual proto.Message) bool {
+		return proto.Equal(expected, actual)
+	}, BeTrue())
+}
+
+var _ = DescribeTable("JobStore",
+	func(ctx SpecContext, newStore func() storage.JobStore) {
+		store := newStore()
+		sampleRecord := &storagev1.JobRecord{
+			Id:    "abc123",
+			Owner: "user1",
+			Status: &jobv1.JobStatus{
+				State: jobv1.State_RUNNING,
+				Spec: &jobv1.JobSpec{
+					Command: &jobv1.CommandSpec{Command: "sleep", Args: []string{"10"}},
+				},
+				Pid: 1234,
+			},
+		}
+
+		By("storing a record")
+		Expect(store.Put(ctx, sampleRecord)).To(Succeed())
+		Expect(store.Get(ctx, "abc123")).To(equalProto(sampleRecord))
+
+		By("replacing a record")
+		updated := proto.Clone(sampleRecord).(*storagev1.JobRecord)
+		updated.Status.State = jobv1.State_TERMINATED
+		Expect(store.Put(ctx, updated)).To(Succeed())
+		Expect(store.Get(ctx, "abc123")).To(equalProto(updated))
+
+		By("listing records")
+		other := &storagev1.JobRecord{Id: "def456", Owner: "user2"}
+		Expect(store.Put(ctx, other)).To(Succeed())
+		records, err := store.List(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(records).To(ConsistOf(equalProto(updated), equalProto(other)))
+
+		By("deleting a record")
+		Expect(store.Delete(ctx, "abc123")).To(Succeed())
+		_, err = store.Get(ctx, "abc123")
+		Expect(err).To(MatchError(storage.ErrNotFound))
+		Expect(store.Delete(ctx, "abc123")).To(MatchError(storage.ErrNotFound))
+		records, err = store.List(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(records).To(ConsistOf(equalProto(other)))
+	},
+	Entry("MemoryStore", func() storage.JobStore {
+		return storage.NewMemoryStore()
+	}),
+	Entry("FileStore", func() storage.JobStore {
+		store, err := storage.NewFileStore(GinkgoT().TempDir())
+		Expect(err).NotTo(HaveOccurred())
+		return store
+	}),
+)
+
+var _ = DescribeTable("ScheduleStore",
+	func(ctx SpecContext, newStore func() storage.ScheduleStore) {
+		store := newStore()
+		sampleRecord := &storagev1.ScheduleRecord{
+			Id:    "abc123",
+			Owner: "user1",
+			Spec: &jobv1.ScheduleSpec{
+				Cron: "@hourly",
+				JobTemplate: &jobv1.JobSpec{
+					Command: &jobv1.CommandSpec{Command: "sleep", Args: []string{"10"}},
+				},
+			},
+		}
+
+		By("storing a record")
+		Expect(store.Put(ctx, sampleRecord)).To(Succeed())
+		Expect(store.List(ctx)).To(ConsistOf(equalProto(sampleRecord)))
+
+		By("replacing a record")
+		updated := proto.Clone(sampleRecord).(*storagev1.ScheduleRecord)
+		updated.LastScheduleTime = timestamppb.Now()
+		Expect(store.Put(ctx, updated)).To(Succeed())
+		Expect(store.List(ctx)).To(ConsistOf(equalProto(updated)))
+
+		By("deleting a record")
+		other := &storagev1.ScheduleRecord{Id: "def456", Owner: "user2"}
+		Expect(store.Put(ctx, other)).To(Succeed())
+		Expect(store.Delete(ctx, "abc123")).To(Succeed())
+		Expect(store.Delete(ctx, "abc123")).To(MatchError(storage.ErrNotFound))
+		Expect(store.List(ctx)).To(ConsistOf(equalProto(other)))
+	},
+	Entry("MemoryScheduleStore", func() storage.ScheduleStore {
+		return storage.NewMemoryScheduleStore()
+	}),
+	Entry("FileScheduleStore", func() storage.ScheduleStore {
+		store, err := storage.NewFileScheduleStore(GinkgoT().TempDir())
+		Expect(err).NotTo(HaveOccurred())
+		return store
+	}),
+)
+
+var _ = Describe("FileStore", func() {
+	It("should persist records across instances", func(ctx SpecContext) {
+		dir := GinkgoT().TempDir()
+		store, err := storage.NewFileStore(dir)
+		Expect(err).NotTo(HaveOccurred())
+		record := &storagev1.JobRecord{Id: "abc123", Owner: "user1"}
+		Expect(store.Put(ctx, record)).To(Succeed())
+
+		store, err = storage.NewFileStore(dir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(store.Get(ctx, "abc123")).To(equalProto(record))
+	})
+	It("should reject ids that are not valid file names", func(ctx SpecContext) {
+		store, err := storage.NewFileStore(GinkgoT().TempDir())
+		Expect(err).NotTo(HaveOccurred())
+		for _, id := range []string{"", "../foo", "foo/bar", "."} {
+			Expect(store.Put(ctx, &storagev1.JobRecord{Id: id})).NotTo(Succeed())
+		}
+	})
+})
@@ -1,10 +1,11 @@
 package util
 
 import (
-	"container/list"
 	"context"
 	"io"
+	"sort"
 	"sync"
+	"time"
 )
 
 // StreamBuffer is an in-memory buffer that can simultaneously be written to
@@ -27,9 +28,24 @@ import (
 // more writes are expected, because the stream channels are expected to be
 // read from until they are closed. Leaving the buffer open will cause all
 // readers to block indefinitely.
+//
+// A buffer created with NewBoundedStreamBuffer only retains the most recently
+// written data, and discards the oldest chunks once its size limit is reached.
+// Streams that fall behind, or are created after data has been discarded,
+// skip ahead to the oldest retained chunk. Use NewChunkStream() to be notified
+// of how much data was skipped.
+//
+// Data can optionally be tagged with a Source when it is written, using
+// WriteFrom(). Each chunk received from NewChunkStream() contains data from a
+// single source, along with the time at which it was written. Consecutive
+// writes from the same source within a short window (segmentMergeWindow) are
+// stored together, and may be received as a single chunk.
 type StreamBuffer struct {
 	chunksMu sync.RWMutex
-	chunks   *list.List
+	head     *chunk // oldest retained chunk
+	tail     *chunk // chunk currently being written to
+	retained int64  // total size of all retained chunks
+	maxSize  int64  // if > 0, the maximum number of bytes to retain
 	closed   bool
 
 	// A list of channels that the buffer will attempt to write to whenever
@@ -42,20 +58,62 @@ type StreamBuffer struct {
 	rtMu sync.RWMutex
 }
 
+// Source identifies where the data written to a buffer came from, for
+// example the file descriptor of a process's stdout or stderr. Data written
+// using Write() has source 0.
+type Source uint8
+
+// StreamChunk is a single piece of data received from a stream.
+type StreamChunk struct {
+	// A portion of the data written to the buffer.
+	Data []byte
+	// The offset of the start of the data within the buffer, i.e. the number
+	// of bytes written to the buffer before it (including discarded data).
+	Offset int64
+	// The source that the data was written from.
+	Source Source
+	// The time at which the data was written to the buffer. If the chunk
+	// contains data from several writes, this is the time of the first one.
+	Time time.Time
+	// The number of bytes that were discarded by the buffer before they could
+	// be read by the stream. If non-zero, the discarded data immediately
+	// precedes this chunk's data (which may be empty).
+	Dropped int64
+}
+
 const maxChunkSize = 4096
 
+// Consecutive writes from the same source are merged into a single segment if
+// they are written within this long of the first write in the segment, so
+// that frequent small writes don't each need their own segment.
+const segmentMergeWindow = 10 * time.Millisecond
+
 var _ io.WriteCloser = (*StreamBuffer)(nil)
 
 func NewStreamBuffer() *StreamBuffer {
-	buf := &StreamBuffer{
-		chunks:    list.New(),
+	return NewBoundedStreamBuffer(0)
+}
+
+// NewBoundedStreamBuffer creates a StreamBuffer that retains at most maxSize
+// bytes of the most recently written data. The chunk currently being written
+// to is never discarded, so the buffer may briefly hold up to one additional
+// chunk's worth of data. If maxSize is 0, the buffer is unbounded.
+func NewBoundedStreamBuffer(maxSize int64) *StreamBuffer {
+	c := newChunk(0)
+	return &StreamBuffer{
+		head:      c,
+		tail:      c,
+		maxSize:   max(maxSize, 0),
 		notifiers: make(map[int64]chan<- struct{}),
 	}
-	buf.chunks.PushBack(newChunk())
-	return buf
 }
 
 func (b *StreamBuffer) Write(p []byte) (n int, err error) {
+	return b.WriteFrom(0, p)
+}
+
+// WriteFrom writes p to the buffer, tagged with the given source.
+func (b *StreamBuffer) WriteFrom(source Source, p []byte) (n int, err error) {
 	b.chunksMu.Lock()
 	defer b.chunksMu.Unlock()
 
@@ -63,22 +121,26 @@ func (b *StreamBuffer) Write(p []byte) (n int, err error) {
 		return 0, io.ErrClosedPipe
 	}
 	lenP := len(p)
+	now := time.Now()
 
 	// if necessary, split p across multiple chunks
 	for len(p) > 0 {
-		tail := b.acquireLastChunkLocked()
-		chunk := tail.Value.(*chunk)
+		chunk := b.acquireLastChunkLocked()
 		b.rtMu.Lock()
 		// check if p would fit in the current chunk without overflowing
 		remainingSpace := cap(chunk.buf) - len(chunk.buf)
-		if len(p) <= remainingSpace {
-			chunk.buf = append(chunk.buf, p...)
-			p = nil
-		} else {
-			chunk.buf = append(chunk.buf, p[:remainingSpace]...)
-			p = p[remainingSpace:]
+		written := min(len(p), remainingSpace)
+		if n := len(chunk.segments); n == 0 || !chunk.segments[n-1].canMerge(source, now) {
+			chunk.segments = append(chunk.segments, segment{
+				start:  len(chunk.buf),
+				source: source,
+				time:   now,
+			})
 		}
+		chunk.buf = append(chunk.buf, p[:written]...)
+		p = p[written:]
 		b.rtMu.Unlock()
+		b.retained += int64(written)
 		for _, nc := range b.notifiers {
 			select {
 			case nc <- struct{}{}:
@@ -86,6 +148,7 @@ func (b *StreamBuffer) Write(p []byte) (n int, err error) {
 			}
 		}
 	}
+	b.evictLocked()
 
 	return lenP, nil
 }
@@ -97,16 +160,58 @@ func (b *StreamBuffer) Close() error {
 		return nil
 	}
 	b.closed = true
-	close(b.chunks.Back().Value.(*chunk).sealed)
+	close(b.tail.sealed)
 	return nil
 }
 
+// NewStream returns a channel that receives the contents of the buffer as
+// described above. Any data discarded by a bounded buffer is silently skipped.
 func (b *StreamBuffer) NewStream(ctx context.Context) <-chan []byte {
+	rc := make(chan []byte, 1)
+	b.stream(ctx, 0, false, func() { close(rc) }, func(sc StreamChunk) {
+		if len(sc.Data) > 0 {
+			rc <- sc.Data
+		}
+	})
+	return rc
+}
+
+// NewChunkStream is like NewStream, but additionally reports the amount of
+// data that was skipped by the stream because it was discarded by the buffer.
+// Each chunk contains data from a single source, along with the time at which
+// it was written. Consecutive writes from the same source may be received as
+// a single chunk if they were written within segmentMergeWindow of each other.
+func (b *StreamBuffer) NewChunkStream(ctx context.Context) <-chan StreamChunk {
+	return b.NewChunkStreamFrom(ctx, 0)
+}
+
+// NewChunkStreamFrom is like NewChunkStream, but the stream starts at the
+// given offset (i.e. the number of bytes written to the buffer before the
+// data the stream should start with). If the offset is larger than the size
+// of the buffer, the stream starts at the end of the buffer.
+func (b *StreamBuffer) NewChunkStreamFrom(ctx context.Context, offset int64) <-chan StreamChunk {
+	rc := make(chan StreamChunk, 1)
+	b.stream(ctx, offset, true, func() { close(rc) }, func(sc StreamChunk) {
+		rc <- sc
+	})
+	return rc
+}
+
+// Size returns the total number of bytes written to the buffer, including
+// any data that has since been discarded.
+func (b *StreamBuffer) Size() int64 {
+	b.chunksMu.RLock()
+	defer b.chunksMu.RUnlock()
+	return b.tail.offset + int64(b.tail.Len(&b.rtMu))
+}
+
+// If split is true, data is sent separately for each write, so that each
+// chunk can be tagged with its source and time. Otherwise, data from
+// consecutive writes may be sent together, and chunks are not tagged.
+func (b *StreamBuffer) stream(ctx context.Context, offset int64, split bool, done func(), send func(StreamChunk)) {
 	b.chunksMu.Lock()
 	defer b.chunksMu.Unlock()
 
-	rc := make(chan []byte, 1)
-
 	notifier := make(chan struct{}, 1)
 	b.nextID++
 	id := b.nextID
@@ -120,15 +225,22 @@ func (b *StreamBuffer) NewStream(ctx context.Context) <-chan []byte {
 	})
 
 	go func() {
-		defer close(rc)
-		for elem := b.firstChunk(); elem != nil; elem = b.nextChunkUnsafe(elem) {
-			off := 0
-			c := elem.Value.(*chunk)
+		defer done()
+		c, off, dropped := b.firstChunk(offset)
+		for c != nil {
+			if dropped > 0 {
+				send(StreamChunk{Offset: c.offset + int64(off), Dropped: dropped})
+			}
 		CHUNK:
 			for {
-				data, stat := c.Next(ctx, off, &b.rtMu, notifier)
+				data, seg, stat := c.Next(ctx, off, &b.rtMu, notifier, split)
 				if len(data) > 0 {
-					rc <- data
+					send(StreamChunk{
+						Data:   data,
+						Offset: c.offset + int64(off),
+						Source: seg.source,
+						Time:   seg.time,
+					})
 					off += len(data)
 				}
 				switch stat {
@@ -140,47 +252,142 @@ func (b *StreamBuffer) NewStream(ctx context.Context) <-chan []byte {
 					return
 				}
 			}
+			c, dropped = b.nextChunk(c)
+			off = 0
 		}
 	}()
-	return rc
 }
 
-func (b *StreamBuffer) firstChunk() *list.Element {
+// Returns the retained chunk containing the given offset along with the
+// position of the offset within the chunk, and the number of bytes between
+// the offset and the start of the chunk that were discarded. If the offset
+// was discarded, the oldest retained chunk is returned.
+func (b *StreamBuffer) firstChunk(offset int64) (*chunk, int, int64) {
 	b.chunksMu.RLock()
 	defer b.chunksMu.RUnlock()
-	return b.chunks.Front()
+	if offset <= b.head.offset {
+		return b.head, 0, b.head.offset - offset
+	}
+	c := b.head
+	for c.next != nil && c.next.offset <= offset {
+		c = c.next
+	}
+	return c, int(min(offset-c.offset, int64(c.Len(&b.rtMu)))), 0
 }
 
-// NB: this method *does not lock* and imposes the following input constraint:
-// For any input 'elem', elem.Next() must always return the same result. It
-// can return nil, but only in the case where no more chunks will ever be
-// added to the buffer (i.e. the buffer is closed).
-//
-// The buffer ensures this by pushing a new chunk onto the end of the list
-// before sealing the previous chunk, which is the only scenario in which this
-// method is called by stream readers.
-func (b *StreamBuffer) nextChunkUnsafe(elem *list.Element) *list.Element {
-	return elem.Next()
+// Returns the chunk following c, which must be sealed, and the number of bytes
+// that were discarded between the end of c and the start of the next chunk.
+// If c has since been discarded from the buffer, the oldest retained chunk is
+// returned instead. Returns nil if c is the last chunk (i.e. the buffer is
+// closed).
+func (b *StreamBuffer) nextChunk(c *chunk) (*chunk, int64) {
+	b.chunksMu.RLock()
+	defer b.chunksMu.RUnlock()
+	if c.evicted {
+		return b.head, b.head.offset - (c.offset + int64(len(c.buf)))
+	}
+	return c.next, 0
+}
+
+// ReadChunksBackward calls fn with the data written to the buffer before the
+// given offset, newest first, until fn returns false or the oldest retained
+// data has been read. Chunks are split in the same way as those received from
+// NewChunkStream. Data written after ReadChunksBackward is called
+// is not read. The returned error is always nil.
+func (b *StreamBuffer) ReadChunksBackward(end int64, fn func(StreamChunk) bool) error {
+	c := b.lastChunkBefore(end)
+	for c != nil {
+		b.rtMu.RLock()
+		buf, segments := c.buf, c.segments
+		b.rtMu.RUnlock()
+		for i := len(segments) - 1; i >= 0; i-- {
+			start := int64(segments[i].start)
+			if c.offset+start >= end {
+				continue
+			}
+			stop := int64(len(buf))
+			if i+1 < len(segments) {
+				stop = int64(segments[i+1].start)
+			}
+			stop = min(stop, end-c.offset)
+			if !fn(StreamChunk{
+				Data:   buf[start:stop],
+				Offset: c.offset + start,
+				Source: segments[i].source,
+				Time:   segments[i].time,
+			}) {
+				return nil
+			}
+		}
+		c = b.prevChunk(c)
+	}
+	return nil
 }
 
-func (b *StreamBuffer) acquireLastChunkLocked() *list.Element {
-	end := b.chunks.Back()
-	if end == nil {
-		panic("bug: chunks list is empty")
+// Returns the retained chunk containing the data immediately before the
+// given offset, or nil if there is no such data.
+func (b *StreamBuffer) lastChunkBefore(offset int64) *chunk {
+	b.chunksMu.RLock()
+	defer b.chunksMu.RUnlock()
+	c := b.tail
+	for c != nil && c.offset >= offset {
+		c = c.prev
+	}
+	return c
+}
+
+// Returns the chunk preceding c, or nil if c is (or was) the oldest retained
+// chunk.
+func (b *StreamBuffer) prevChunk(c *chunk) *chunk {
+	b.chunksMu.RLock()
+	defer b.chunksMu.RUnlock()
+	if c.evicted {
+		return nil
 	}
+	return c.prev
+}
 
-	endChunk := end.Value.(*chunk)
+func (b *StreamBuffer) acquireLastChunkLocked() *chunk {
+	endChunk := b.tail
 	if endChunk.Len(&b.rtMu) >= maxChunkSize {
-		nc := b.chunks.PushBack(newChunk())
+		nc := newChunk(endChunk.offset + int64(len(endChunk.buf)))
+		endChunk.next = nc
+		nc.prev = endChunk
+		b.tail = nc
 		close(endChunk.sealed)
 		return nc
 	}
-	return end
+	return endChunk
+}
+
+// Discards the oldest chunks until the buffer is within its size limit. The
+// chunk currently being written to is never discarded.
+func (b *StreamBuffer) evictLocked() {
+	if b.maxSize == 0 {
+		return
+	}
+	for b.retained > b.maxSize && b.head != b.tail {
+		c := b.head
+		b.head = c.next
+		b.head.prev = nil
+		b.retained -= int64(len(c.buf))
+		// unlink the chunk so that it can be garbage collected as soon as any
+		// streams currently reading from it are finished with it
+		c.evicted = true
+		c.next = nil
+	}
 }
 
 type chunk struct {
-	buf    []byte
-	sealed chan struct{}
+	buf      []byte
+	segments []segment // in order, see segment
+	sealed   chan struct{}
+	offset   int64 // offset of the start of the chunk within the stream
+
+	// guarded by StreamBuffer.chunksMu
+	next    *chunk
+	prev    *chunk // nil for the oldest retained chunk
+	evicted bool
 }
 
 func (c *chunk) Len(rtMu *sync.RWMutex) int {
@@ -189,6 +396,32 @@ func (c *chunk) Len(rtMu *sync.RWMutex) int {
 	return len(c.buf)
 }
 
+// segment describes the data written to a chunk by a single write, or by
+// consecutive writes from the same source within segmentMergeWindow.
+type segment struct {
+	start  int // offset of the start of the segment within the chunk
+	source Source
+	time   time.Time // the time of the first write in the segment
+}
+
+// canMerge returns whether data written from the given source at the given
+// time can be added to the end of the segment.
+func (s segment) canMerge(source Source, now time.Time) bool {
+	return s.source == source && now.Sub(s.time) < segmentMergeWindow
+}
+
+// Returns the segment containing the given offset within the chunk, and the
+// offset of the end of that segment. The offset must be less than len(c.buf).
+func (c *chunk) segmentAt(offset int) (segment, int) {
+	i := sort.Search(len(c.segments), func(i int) bool {
+		return c.segments[i].start > offset
+	}) - 1
+	if i+1 < len(c.segments) {
+		return c.segments[i], c.segments[i+1].start
+	}
+	return c.segments[i], len(c.buf)
+}
+
 type status int
 
 const (
@@ -197,10 +430,13 @@ const (
 	Canceled
 )
 
-func (c *chunk) Next(ctx context.Context, offset int, rtMu *sync.RWMutex, rtWait <-chan struct{}) ([]byte, status) {
+// Returns the data in the chunk following the given offset. If split is
+// true, only the data up to the end of the segment containing the offset is
+// returned, along with the segment.
+func (c *chunk) Next(ctx context.Context, offset int, rtMu *sync.RWMutex, rtWait <-chan struct{}, split bool) ([]byte, segment, status) {
 	select {
 	case <-c.sealed:
-		return c.nextSealed(offset) // fast path for sealed chunks, no locking required
+		return c.nextSealed(offset, split) // fast path for sealed chunks, no locking required
 	default:
 		rtMu.RLock()
 		switch {
@@ -208,29 +444,41 @@ func (c *chunk) Next(ctx context.Context, offset int, rtMu *sync.RWMutex, rtWait
 			panic("bug: offset out of range")
 		case offset < len(c.buf):
 			defer rtMu.RUnlock()
-			return c.buf[offset:], ReadAgain
+			if !split {
+				return c.buf[offset:], segment{}, ReadAgain
+			}
+			seg, end := c.segmentAt(offset)
+			return c.buf[offset:end], seg, ReadAgain
 		default: // offset == len(c.buf)
 			rtMu.RUnlock()
 			select {
 			case <-ctx.Done():
-				return nil, Canceled
+				return nil, segment{}, Canceled
 			case <-c.sealed:
-				return c.nextSealed(offset)
+				return c.nextSealed(offset, split)
 			case <-rtWait:
-				return nil, ReadAgain
+				return nil, segment{}, ReadAgain
 			}
 		}
 	}
 }
 
-func (c *chunk) nextSealed(offset int) ([]byte, status) {
-	return c.buf[offset:], ReadComplete
+func (c *chunk) nextSealed(offset int, split bool) ([]byte, segment, status) {
+	if !split || offset == len(c.buf) {
+		return c.buf[offset:], segment{}, ReadComplete
+	}
+	seg, end := c.segmentAt(offset)
+	if end < len(c.buf) {
+		return c.buf[offset:end], seg, ReadAgain
+	}
+	return c.buf[offset:], seg, ReadComplete
 }
 
-func newChunk() *chunk {
+func newChunk(offset int64) *chunk {
 	c := &chunk{
 		buf:    make([]byte, 0, maxChunkSize),
 		sealed: make(chan struct{}),
+		offset: offset,
 	}
 	return c
 }
@@ -1,6 +1,7 @@
 package util_test
 
 import (
+	"bytes"
 	"context"
 	"crypto/rand"
 	"fmt"
@@ -300,4 +301,232 @@ var _ = Describe("StreamBuffer", func() {
 			})
 		})
 	})
+	When("writing from multiple sources", func() {
+		It("should tag each chunk with its source and time", func(ctx SpecContext) {
+			buf := util.NewStreamBuffer()
+			before := time.Now()
+			Expect(buf.WriteFrom(1, []byte("out1"))).To(Equal(4))
+			Expect(buf.WriteFrom(2, []byte("err1"))).To(Equal(4))
+			Expect(buf.WriteFrom(1, []byte("out2"))).To(Equal(4))
+			after := time.Now()
+			Expect(buf.Close()).To(Succeed())
+
+			var chunks []util.StreamChunk
+			for sc := range buf.NewChunkStream(ctx) {
+				chunks = append(chunks, sc)
+			}
+			Expect(chunks).To(HaveLen(3))
+			for i, expected := range []struct {
+				data   string
+				source util.Source
+			}{{"out1", 1}, {"err1", 2}, {"out2", 1}} {
+				Expect(string(chunks[i].Data)).To(Equal(expected.data))
+				Expect(chunks[i].Source).To(Equal(expected.source))
+				Expect(chunks[i].Time).To(BeTemporally(">=", before))
+				Expect(chunks[i].Time).To(BeTemporally("<=", after))
+			}
+		})
+		It("should merge consecutive writes from the same source", func(ctx SpecContext) {
+			buf := util.NewStreamBuffer()
+			before := time.Now()
+			Expect(buf.WriteFrom(1, []byte("out1"))).To(Equal(4))
+			Expect(buf.WriteFrom(1, []byte("out2"))).To(Equal(4))
+			Expect(buf.WriteFrom(2, []byte("err1"))).To(Equal(4))
+			Expect(buf.WriteFrom(1, []byte("out3"))).To(Equal(4))
+			time.Sleep(20 * time.Millisecond)
+			Expect(buf.WriteFrom(1, []byte("out4"))).To(Equal(4))
+			Expect(buf.Close()).To(Succeed())
+
+			var chunks []util.StreamChunk
+			for sc := range buf.NewChunkStream(ctx) {
+				chunks = append(chunks, sc)
+			}
+			var data []string
+			for _, sc := range chunks {
+				data = append(data, string(sc.Data))
+			}
+			Expect(data).To(Equal([]string{"out1out2", "err1", "out3", "out4"}))
+			Expect(chunks[0].Time).To(BeTemporally(">=", before))
+			Expect(chunks[0].Time).To(BeTemporally("<", chunks[3].Time))
+			Expect(chunks[3].Time.Sub(chunks[2].Time)).To(BeNumerically(">=", 20*time.Millisecond))
+		})
+		It("should tag writes that span multiple chunks", func(ctx SpecContext) {
+			buf := util.NewStreamBuffer()
+			stream := buf.NewChunkStream(ctx)
+			large := bytes.Repeat([]byte("a"), 10000)
+			Expect(buf.WriteFrom(1, []byte("b"))).To(Equal(1))
+			Expect(buf.WriteFrom(2, large)).To(Equal(len(large)))
+			Expect(buf.WriteFrom(1, []byte("c"))).To(Equal(1))
+			Expect(buf.Close()).To(Succeed())
+
+			received := map[util.Source][]byte{}
+			for sc := range stream {
+				received[sc.Source] = append(received[sc.Source], sc.Data...)
+			}
+			Expect(received[1]).To(Equal([]byte("bc")))
+			Expect(received[2]).To(Equal(large))
+		})
+	})
+	When("streaming from an offset", func() {
+		It("should start at the given offset, and report the offset of each chunk", func(ctx SpecContext) {
+			buf := util.NewStreamBuffer()
+			contents := make([]byte, 3*4096+100)
+			Expect(rand.Read(contents)).To(Equal(len(contents)))
+			for i := 0; i < len(contents); i += 1000 {
+				data := contents[i:min(i+1000, len(contents))]
+				Expect(buf.WriteFrom(1, data)).To(Equal(len(data)))
+			}
+			Expect(buf.Size()).To(BeEquivalentTo(len(contents)))
+			Expect(buf.Close()).To(Succeed())
+
+			for _, start := range []int64{0, 500, 4096, 5000, int64(len(contents))} {
+				var recv []byte
+				expectedOffset := start
+				for sc := range buf.NewChunkStreamFrom(ctx, start) {
+					Expect(sc.Dropped).To(BeZero())
+					Expect(sc.Offset).To(Equal(expectedOffset))
+					expectedOffset += int64(len(sc.Data))
+					recv = append(recv, sc.Data...)
+				}
+				Expect(recv).To(Equal(contents[start:]))
+			}
+		})
+		It("should report discarded data before the oldest retained chunk", func(ctx SpecContext) {
+			buf := util.NewBoundedStreamBuffer(8 * 1024)
+			for i := 0; i < 16; i++ {
+				Expect(buf.Write(bytes.Repeat([]byte{byte(i)}, 1024))).To(Equal(1024))
+			}
+			Expect(buf.Close()).To(Succeed())
+			Expect(buf.Size()).To(BeEquivalentTo(16 * 1024))
+
+			stream := buf.NewChunkStreamFrom(ctx, 1024)
+			var first util.StreamChunk
+			Eventually(stream).Should(Receive(&first))
+			Expect(first.Dropped).To(BeEquivalentTo(7 * 1024))
+			Expect(first.Offset).To(BeEquivalentTo(8 * 1024))
+		})
+	})
+	When("reading backward", func() {
+		It("should read each write in reverse order, up to the given offset", func() {
+			buf := util.NewStreamBuffer()
+			contents := make([]byte, 3*4096+100)
+			Expect(rand.Read(contents)).To(Equal(len(contents)))
+			for i := 0; i < len(contents); i += 1000 {
+				data := contents[i:min(i+1000, len(contents))]
+				Expect(buf.WriteFrom(util.Source(i/1000), data)).To(Equal(len(data)))
+			}
+
+			for _, end := range []int64{0, 500, 4096, 5000, int64(len(contents)), int64(len(contents)) + 1} {
+				var recv []byte
+				expectedEnd := min(end, int64(len(contents)))
+				Expect(buf.ReadChunksBackward(end, func(sc util.StreamChunk) bool {
+					Expect(sc.Offset + int64(len(sc.Data))).To(Equal(expectedEnd))
+					Expect(sc.Source).To(BeEquivalentTo(sc.Offset / 1000))
+					expectedEnd = sc.Offset
+					recv = append(sc.Data[:len(sc.Data):len(sc.Data)], recv...)
+					return true
+				})).To(Succeed())
+				Expect(expectedEnd).To(BeZero())
+				Expect(recv).To(Equal(contents[:min(end, int64(len(contents)))]))
+			}
+		})
+		It("should stop when the callback returns false", func() {
+			buf := util.NewStreamBuffer()
+			buf.WriteFrom(1, []byte("a"))
+			buf.WriteFrom(2, []byte("b"))
+			buf.WriteFrom(1, []byte("c"))
+			var recv []string
+			Expect(buf.ReadChunksBackward(buf.Size(), func(sc util.StreamChunk) bool {
+				recv = append(recv, string(sc.Data))
+				return len(recv) < 2
+			})).To(Succeed())
+			Expect(recv).To(Equal([]string{"c", "b"}))
+		})
+		It("should stop at the oldest retained chunk", func() {
+			buf := util.NewBoundedStreamBuffer(8 * 1024)
+			for i := 0; i < 16; i++ {
+				Expect(buf.Write(bytes.Repeat([]byte{byte(i)}, 1024))).To(Equal(1024))
+			}
+			var oldest int64 = -1
+			Expect(buf.ReadChunksBackward(buf.Size(), func(sc util.StreamChunk) bool {
+				oldest = sc.Offset
+				return true
+			})).To(Succeed())
+			Expect(oldest).To(BeEquivalentTo(8 * 1024))
+		})
+	})
+	When("the buffer is bounded", func() {
+		readAll := func(stream <-chan util.StreamChunk) (data []byte, dropped int64) {
+			for sc := range stream {
+				data = append(data, sc.Data...)
+				dropped += sc.Dropped
+			}
+			return
+		}
+		It("should only retain the most recently written data", func(ctx SpecContext) {
+			buf := util.NewBoundedStreamBuffer(16 * 1024)
+			contents := make([]byte, 100*1024)
+			Expect(rand.Read(contents)).To(Equal(len(contents)))
+			Expect(buf.Write(contents)).To(Equal(len(contents)))
+			Expect(buf.Close()).To(Succeed())
+
+			data, dropped := readAll(buf.NewChunkStream(ctx))
+			Expect(len(data)).To(BeNumerically("<=", 16*1024))
+			Expect(dropped + int64(len(data))).To(BeEquivalentTo(len(contents)))
+			Expect(data).To(Equal(contents[dropped:]))
+		})
+		It("should start late readers at the oldest retained chunk", func(ctx SpecContext) {
+			buf := util.NewBoundedStreamBuffer(8 * 1024)
+			for i := 0; i < 16; i++ {
+				Expect(buf.Write(bytes.Repeat([]byte{byte(i)}, 1024))).To(Equal(1024))
+			}
+			stream := buf.NewChunkStream(ctx)
+			var first util.StreamChunk
+			Eventually(stream).Should(Receive(&first))
+			Expect(first.Dropped).To(BeEquivalentTo(8 * 1024))
+			Expect(first.Data).To(BeEmpty())
+
+			Expect(buf.Close()).To(Succeed())
+			data, dropped := readAll(stream)
+			Expect(dropped).To(BeZero())
+			Expect(data).To(HaveLen(8 * 1024))
+			Expect(data[0]).To(Equal(byte(8)))
+		})
+		It("should skip ahead for readers that fall behind", func(ctx SpecContext) {
+			buf := util.NewBoundedStreamBuffer(8 * 1024)
+			stream := buf.NewChunkStream(ctx)
+			contents := make([]byte, 1024*1024)
+			Expect(rand.Read(contents)).To(Equal(len(contents)))
+			go func() {
+				defer GinkgoRecover()
+				for i := 0; i < len(contents); i += 1024 {
+					Expect(buf.Write(contents[i : i+1024])).To(Equal(1024))
+				}
+				Expect(buf.Close()).To(Succeed())
+			}()
+
+			var offset int64
+			var totalDropped int64
+			for sc := range stream {
+				offset += sc.Dropped
+				totalDropped += sc.Dropped
+				Expect(sc.Data).To(Equal(contents[offset : offset+int64(len(sc.Data))]))
+				offset += int64(len(sc.Data))
+				time.Sleep(10 * time.Microsecond)
+			}
+			Expect(offset).To(BeEquivalentTo(len(contents)))
+			fmt.Fprintln(GinkgoWriter, "slow reader skipped", totalDropped, "bytes")
+		})
+		It("should not report dropped data through NewStream", func(ctx SpecContext) {
+			buf := util.NewBoundedStreamBuffer(4096)
+			Expect(buf.Write(bytes.Repeat([]byte("a"), 8192))).To(Equal(8192))
+			Expect(buf.Write([]byte("b"))).To(Equal(1))
+			Expect(buf.Close()).To(Succeed())
+			var recv []byte
+			for b := range buf.NewStream(ctx) {
+				recv = append(recv, b...)
+			}
+			Expect(recv).To(Equal([]byte("b")))
+		})
+	})
 })
@@ -0,0 +1,446 @@
+package util
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// FileStreamBuffer is a file-backed alternative to StreamBuffer, with the
+// same semantics: it can be written to by a single writer and simultaneously
+// read from by multiple readers, such that all readers see the same data.
+//
+// All data written to the buffer is appended to a file on disk instead of
+// being kept in memory. Each stream returned by NewStream() reads from its
+// own file handle, so readers only hold a small, fixed-size read buffer in
+// memory regardless of how much data has been written.
+//
+// Each write is stored in the file as a record consisting of a small header
+// (containing the write's source, time, and length) followed by the data, so
+// that chunks received from NewChunkStream() can be tagged in the same way as
+// those of a StreamBuffer.
+//
+// The file is not removed when the buffer is closed. A closed buffer can
+// be re-opened from an existing file with OpenFileStreamBuffer.
+type FileStreamBuffer struct {
+	path string
+
+	mu       sync.Mutex
+	file     *os.File // nil after the buffer is closed
+	size     int64    // size of the file
+	dataSize int64    // total size of the data in all records
+	closed   bool
+
+	// A sparse index mapping data offsets to the file offsets of the records
+	// starting at them, used to quickly find the start of a stream.
+	index        []fileIndexEntry
+	indexRecords int // number of records written since the last index entry
+
+	// A list of channels that the buffer will attempt to write to whenever
+	// new data is written to the buffer, or when the buffer is closed.
+	notifiers map[int64]chan<- struct{}
+	nextID    int64 // notifier ID counter
+}
+
+const fileReadSize = 32 * 1024
+
+// Each record header contains the source (1 byte), the time of the write in
+// nanoseconds since the unix epoch (8 bytes), and the length of the data that
+// follows (4 bytes).
+const recordHeaderSize = 1 + 8 + 4
+
+// The maximum amount of data stored in a single record. Larger writes are
+// split into multiple records.
+const maxRecordSize = math.MaxInt32
+
+// An index entry is added after every indexInterval bytes of data, or every
+// indexRecordInterval records, whichever comes first.
+const (
+	indexInterval       = 1024 * 1024
+	indexRecordInterval = 1024
+)
+
+type fileIndexEntry struct {
+	dataOffset int64
+	fileOffset int64
+}
+
+var _ io.WriteCloser = (*FileStreamBuffer)(nil)
+
+// NewFileStreamBuffer creates a new buffer that writes to the file at the
+// given path. If the file already exists, it is truncated.
+func NewFileStreamBuffer(path string) (*FileStreamBuffer, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	return &FileStreamBuffer{
+		path:      path,
+		file:      f,
+		index:     []fileIndexEntry{{}},
+		notifiers: make(map[int64]chan<- struct{}),
+	}, nil
+}
+
+// OpenFileStreamBuffer opens an existing file previously written to by a
+// FileStreamBuffer. The returned buffer is already closed; streams created
+// from it will receive the full contents of the file. If the file ends with
+// an incomplete record (e.g. because a write failed), the incomplete record
+// is ignored.
+func OpenFileStreamBuffer(path string) (*FileStreamBuffer, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if !info.Mode().IsRegular() {
+		return nil, fmt.Errorf("%s is not a regular file", path)
+	}
+	b := &FileStreamBuffer{
+		path:      path,
+		closed:    true,
+		index:     []fileIndexEntry{{}},
+		notifiers: make(map[int64]chan<- struct{}),
+	}
+	header := make([]byte, recordHeaderSize)
+	for b.size+recordHeaderSize <= info.Size() {
+		if _, err := f.ReadAt(header, b.size); err != nil {
+			return nil, err
+		}
+		length := int64(binary.BigEndian.Uint32(header[9:13]))
+		if b.size+recordHeaderSize+length > info.Size() {
+			break
+		}
+		b.size += recordHeaderSize + length
+		b.dataSize += length
+		b.indexLocked()
+	}
+	return b, nil
+}
+
+func (b *FileStreamBuffer) Write(p []byte) (n int, err error) {
+	return b.WriteFrom(0, p)
+}
+
+// WriteFrom writes p to the buffer, tagged with the given source.
+func (b *FileStreamBuffer) WriteFrom(source Source, p []byte) (n int, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return 0, io.ErrClosedPipe
+	}
+	now := time.Now().UnixNano()
+	for len(p) > 0 {
+		data := p[:min(len(p), maxRecordSize)]
+		record := make([]byte, recordHeaderSize+len(data))
+		record[0] = byte(source)
+		binary.BigEndian.PutUint64(record[1:9], uint64(now))
+		binary.BigEndian.PutUint32(record[9:13], uint32(len(data)))
+		copy(record[recordHeaderSize:], data)
+
+		if _, err := b.file.Write(record); err != nil {
+			// discard any incomplete record, so that readers never see it
+			// and subsequent writes start at a record boundary
+			b.file.Truncate(b.size)
+			b.file.Seek(b.size, io.SeekStart)
+			return n, err
+		}
+		b.size += int64(len(record))
+		b.dataSize += int64(len(data))
+		b.indexLocked()
+		b.notifyLocked()
+		n += len(data)
+		p = p[len(data):]
+	}
+	return n, nil
+}
+
+func (b *FileStreamBuffer) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return nil
+	}
+	b.closed = true
+	err := b.file.Close()
+	b.file = nil
+	b.notifyLocked()
+	return err
+}
+
+// Adds an index entry for the end of the last record, if necessary.
+func (b *FileStreamBuffer) indexLocked() {
+	b.indexRecords++
+	last := b.index[len(b.index)-1]
+	if b.dataSize-last.dataOffset >= indexInterval || b.indexRecords >= indexRecordInterval {
+		b.index = append(b.index, fileIndexEntry{dataOffset: b.dataSize, fileOffset: b.size})
+		b.indexRecords = 0
+	}
+}
+
+// Size returns the total number of bytes of data written to the buffer, not
+// including the record headers.
+func (b *FileStreamBuffer) Size() int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.dataSize
+}
+
+// Returns the index entry at or before the given data offset.
+func (b *FileStreamBuffer) indexEntry(offset int64) fileIndexEntry {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	i := sort.Search(len(b.index), func(i int) bool {
+		return b.index[i].dataOffset > offset
+	})
+	return b.index[i-1]
+}
+
+// Returns the last index entry before the given data offset, if any.
+func (b *FileStreamBuffer) indexEntryBefore(offset int64) (fileIndexEntry, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	i := sort.Search(len(b.index), func(i int) bool {
+		return b.index[i].dataOffset >= offset
+	})
+	if i == 0 {
+		return fileIndexEntry{}, false
+	}
+	return b.index[i-1], true
+}
+
+// ReadChunksBackward is equivalent to StreamBuffer.ReadChunksBackward. Records
+// are located using the buffer's index, so only the records between the
+// index entry preceding each chunk and the chunk itself are read. Large
+// records are read in several chunks.
+func (b *FileStreamBuffer) ReadChunksBackward(end int64, fn func(StreamChunk) bool) error {
+	f, err := os.Open(b.path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	b.mu.Lock()
+	size := b.size
+	end = min(end, b.dataSize)
+	b.mu.Unlock()
+
+	type record struct {
+		chunk      StreamChunk // without data
+		fileOffset int64       // offset of the record's data in the file
+		length     int64
+	}
+	header := make([]byte, recordHeaderSize)
+	for {
+		entry, ok := b.indexEntryBefore(end)
+		if !ok {
+			return nil
+		}
+		// read the headers of all records between the index entry and end
+		var records []record
+		off, dataOff := entry.fileOffset, entry.dataOffset
+		for dataOff < end && off+recordHeaderSize <= size {
+			if _, err := f.ReadAt(header, off); err != nil {
+				return err
+			}
+			length := int64(binary.BigEndian.Uint32(header[9:13]))
+			records = append(records, record{
+				chunk: StreamChunk{
+					Offset: dataOff,
+					Source: Source(header[0]),
+					Time:   time.Unix(0, int64(binary.BigEndian.Uint64(header[1:9]))),
+				},
+				fileOffset: off + recordHeaderSize,
+				length:     min(length, end-dataOff),
+			})
+			off += recordHeaderSize + length
+			dataOff += length
+		}
+		for i := len(records) - 1; i >= 0; i-- {
+			r := records[i]
+			for stop := r.length; stop > 0; {
+				start := max(stop-fileReadSize, 0)
+				data := make([]byte, stop-start)
+				if _, err := f.ReadAt(data, r.fileOffset+start); err != nil {
+					return err
+				}
+				chunk := r.chunk
+				chunk.Data = data
+				chunk.Offset += start
+				if !fn(chunk) {
+					return nil
+				}
+				stop = start
+			}
+		}
+		end = entry.dataOffset
+	}
+}
+
+func (b *FileStreamBuffer) notifyLocked() {
+	for _, nc := range b.notifiers {
+		select {
+		case nc <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Returns the current size of the file and whether the buffer is closed.
+func (b *FileStreamBuffer) state() (int64, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.size, b.closed
+}
+
+func (b *FileStreamBuffer) NewStream(ctx context.Context) <-chan []byte {
+	rc := make(chan []byte, 1)
+	b.stream(ctx, 0, false, func() { close(rc) }, func(sc StreamChunk) bool {
+		select {
+		case rc <- sc.Data:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	})
+	return rc
+}
+
+// NewChunkStream is equivalent to StreamBuffer.NewChunkStream. Since a
+// FileStreamBuffer never discards data, chunks will never report any
+// dropped bytes.
+func (b *FileStreamBuffer) NewChunkStream(ctx context.Context) <-chan StreamChunk {
+	return b.NewChunkStreamFrom(ctx, 0)
+}
+
+// NewChunkStreamFrom is equivalent to StreamBuffer.NewChunkStreamFrom.
+func (b *FileStreamBuffer) NewChunkStreamFrom(ctx context.Context, offset int64) <-chan StreamChunk {
+	rc := make(chan StreamChunk, 1)
+	b.stream(ctx, offset, true, func() { close(rc) }, func(sc StreamChunk) bool {
+		select {
+		case rc <- sc:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	})
+	return rc
+}
+
+// If split is true, data is sent separately for each record, so that each
+// chunk can be tagged with its source and time. Otherwise, data from
+// consecutive records is sent together, and chunks are not tagged.
+func (b *FileStreamBuffer) stream(ctx context.Context, offset int64, split bool, done func(), send func(StreamChunk) bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	notifier := make(chan struct{}, 1)
+	b.nextID++
+	id := b.nextID
+	b.notifiers[id] = notifier
+
+	go func() {
+		defer done()
+		defer func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			delete(b.notifiers, id)
+		}()
+
+		f, err := os.Open(b.path)
+		if err != nil {
+			return
+		}
+		defer f.Close()
+
+		offset = min(offset, b.Size())
+		entry := b.indexEntry(offset)
+		off := entry.fileOffset     // offset in the file
+		dataOff := entry.dataOffset // offset in the data
+		skip := offset - dataOff    // data to skip before sending
+		var remaining int64         // remaining data in the current record
+		var current StreamChunk
+		var pending []byte // unsent data, if split is false
+		flush := func() bool {
+			if len(pending) == 0 {
+				return true
+			}
+			data := pending
+			pending = nil
+			return send(StreamChunk{Data: data})
+		}
+		for {
+			size, closed := b.state()
+			if remaining == 0 && off+recordHeaderSize <= size {
+				header := make([]byte, recordHeaderSize)
+				if _, err := f.ReadAt(header, off); err != nil {
+					return
+				}
+				off += recordHeaderSize
+				remaining = int64(binary.BigEndian.Uint32(header[9:13]))
+				current = StreamChunk{
+					Source: Source(header[0]),
+					Time:   time.Unix(0, int64(binary.BigEndian.Uint64(header[1:9]))),
+				}
+				continue
+			}
+			if remaining > 0 && skip > 0 {
+				n := min(skip, remaining)
+				off += n
+				dataOff += n
+				remaining -= n
+				skip -= n
+				continue
+			}
+			if remaining > 0 && off < size {
+				data := make([]byte, min(remaining, size-off, fileReadSize))
+				n, err := f.ReadAt(data, off)
+				if n > 0 {
+					chunkOffset := dataOff
+					off += int64(n)
+					dataOff += int64(n)
+					remaining -= int64(n)
+					if split {
+						chunk := current
+						chunk.Data = data[:n]
+						chunk.Offset = chunkOffset
+						if !send(chunk) {
+							return
+						}
+					} else {
+						pending = append(pending, data[:n]...)
+						if len(pending) >= fileReadSize && !flush() {
+							return
+						}
+					}
+				}
+				if err != nil && (n == 0 || !errors.Is(err, io.EOF)) {
+					return
+				}
+				continue
+			}
+			// no complete data is available; send anything that is pending
+			// before waiting for more
+			if !flush() || closed {
+				return
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-notifier:
+			}
+		}
+	}()
+}
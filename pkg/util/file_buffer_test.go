@@ -0,0 +1,214 @@
+package util_test
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/kralicky/jobserver/pkg/util"
+)
+
+var _ = Describe("FileStreamBuffer", func() {
+	var path string
+	BeforeEach(func() {
+		path = filepath.Join(GinkgoT().TempDir(), "output.log")
+	})
+
+	It("should duplicate writes to all streams", func(ctx SpecContext) {
+		buf, err := util.NewFileStreamBuffer(path)
+		Expect(err).NotTo(HaveOccurred())
+		results := make([][]byte, 10)
+		var wg sync.WaitGroup
+		wg.Add(10)
+		for i := 0; i < 10; i++ {
+			i := i
+			reader := buf.NewStream(ctx)
+			go func() {
+				defer wg.Done()
+				for b := range reader {
+					results[i] = append(results[i], b...)
+				}
+			}()
+		}
+		Expect(buf.Write([]byte("hello"))).To(Equal(5))
+		Expect(buf.Write([]byte(" "))).To(Equal(1))
+		Expect(buf.Write([]byte("world"))).To(Equal(5))
+		Expect(buf.Close()).To(Succeed())
+		wg.Wait()
+		for _, result := range results {
+			Expect(result).To(Equal([]byte("hello world")))
+		}
+	})
+	It("should stream large writes in order", func(ctx SpecContext) {
+		buf, err := util.NewFileStreamBuffer(path)
+		Expect(err).NotTo(HaveOccurred())
+		reader := buf.NewStream(ctx)
+		contents := make([]byte, 1024*1024) // 1MB
+		Expect(rand.Read(contents)).To(Equal(len(contents)))
+		go func() {
+			defer GinkgoRecover()
+			Expect(buf.Write(contents)).To(Equal(len(contents)))
+			Expect(buf.Close()).To(Succeed())
+		}()
+		var recv []byte
+		for b := range reader {
+			recv = append(recv, b...)
+		}
+		Expect(recv).To(Equal(contents))
+	})
+	It("should tag each chunk with its source and time", func(ctx SpecContext) {
+		buf, err := util.NewFileStreamBuffer(path)
+		Expect(err).NotTo(HaveOccurred())
+		before := time.Now()
+		Expect(buf.WriteFrom(1, []byte("out"))).To(Equal(3))
+		Expect(buf.WriteFrom(2, []byte("err"))).To(Equal(3))
+		after := time.Now()
+		Expect(buf.Close()).To(Succeed())
+
+		reopened, err := util.OpenFileStreamBuffer(path)
+		Expect(err).NotTo(HaveOccurred())
+		for _, b := range []*util.FileStreamBuffer{buf, reopened} {
+			var chunks []util.StreamChunk
+			for sc := range b.NewChunkStream(ctx) {
+				chunks = append(chunks, sc)
+			}
+			Expect(chunks).To(HaveLen(2))
+			Expect(string(chunks[0].Data)).To(Equal("out"))
+			Expect(chunks[0].Source).To(BeEquivalentTo(1))
+			Expect(string(chunks[1].Data)).To(Equal("err"))
+			Expect(chunks[1].Source).To(BeEquivalentTo(2))
+			for _, sc := range chunks {
+				Expect(sc.Time).To(BeTemporally(">=", before))
+				Expect(sc.Time).To(BeTemporally("<=", after))
+			}
+		}
+	})
+	It("should stream from an offset", func(ctx SpecContext) {
+		buf, err := util.NewFileStreamBuffer(path)
+		Expect(err).NotTo(HaveOccurred())
+		// enough writes to create several index entries
+		var contents []byte
+		for i := 0; i < 5000; i++ {
+			data := []byte(fmt.Sprintf("line %d\n", i))
+			Expect(buf.WriteFrom(util.Source(i%2+1), data)).To(Equal(len(data)))
+			contents = append(contents, data...)
+		}
+		Expect(buf.Size()).To(BeEquivalentTo(len(contents)))
+		Expect(buf.Close()).To(Succeed())
+
+		reopened, err := util.OpenFileStreamBuffer(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reopened.Size()).To(BeEquivalentTo(len(contents)))
+		for _, b := range []*util.FileStreamBuffer{buf, reopened} {
+			for _, start := range []int64{0, 3, 12345, int64(len(contents)) - 1, int64(len(contents))} {
+				var recv []byte
+				expectedOffset := start
+				for sc := range b.NewChunkStreamFrom(ctx, start) {
+					Expect(sc.Offset).To(Equal(expectedOffset))
+					expectedOffset += int64(len(sc.Data))
+					recv = append(recv, sc.Data...)
+				}
+				Expect(string(recv)).To(Equal(string(contents[start:])))
+			}
+		}
+	})
+	It("should read records in reverse order, up to the given offset", func() {
+		buf, err := util.NewFileStreamBuffer(path)
+		Expect(err).NotTo(HaveOccurred())
+		// enough records to span several index entries, followed by a record
+		// larger than the read size
+		var contents []byte
+		for i := 0; i < 3000; i++ {
+			data := []byte(fmt.Sprintf("%d,", i))
+			Expect(buf.WriteFrom(util.Source(i%2), data)).To(Equal(len(data)))
+			contents = append(contents, data...)
+		}
+		large := make([]byte, 100*1024)
+		Expect(rand.Read(large)).To(Equal(len(large)))
+		Expect(buf.WriteFrom(2, large)).To(Equal(len(large)))
+		contents = append(contents, large...)
+
+		for _, end := range []int64{0, 10, 5000, int64(len(contents)) - 1, int64(len(contents))} {
+			var recv []byte
+			expectedEnd := end
+			Expect(buf.ReadChunksBackward(end, func(sc util.StreamChunk) bool {
+				Expect(sc.Offset + int64(len(sc.Data))).To(Equal(expectedEnd))
+				expectedEnd = sc.Offset
+				recv = append(sc.Data[:len(sc.Data):len(sc.Data)], recv...)
+				return true
+			})).To(Succeed())
+			Expect(expectedEnd).To(BeZero())
+			Expect(recv).To(Equal(contents[:end]))
+		}
+
+		var sources []util.Source
+		Expect(buf.ReadChunksBackward(buf.Size(), func(sc util.StreamChunk) bool {
+			sources = append(sources, sc.Source)
+			return len(sources) < 5
+		})).To(Succeed())
+		Expect(sources).To(Equal([]util.Source{2, 2, 2, 2, 1}))
+	})
+
+	It("should stream new data in real time", func(ctx SpecContext) {
+		buf, err := util.NewFileStreamBuffer(path)
+		Expect(err).NotTo(HaveOccurred())
+		reader := buf.NewStream(ctx)
+
+		Expect(buf.Write([]byte("hello"))).To(Equal(5))
+		var recv []byte
+		Eventually(reader).Should(Receive(&recv))
+		Expect(recv).To(Equal([]byte("hello")))
+
+		Expect(buf.Write([]byte("world"))).To(Equal(5))
+		Eventually(reader).Should(Receive(&recv))
+		Expect(recv).To(Equal([]byte("world")))
+
+		Expect(buf.Close()).To(Succeed())
+		Eventually(reader).Should(BeClosed())
+	})
+	When("canceling the context of a stream", func() {
+		It("should stop receiving data", func(ctx SpecContext) {
+			buf, err := util.NewFileStreamBuffer(path)
+			Expect(err).NotTo(HaveOccurred())
+			cctx, ca := context.WithCancel(ctx)
+			reader := buf.NewStream(cctx)
+			ca()
+			Eventually(reader).Should(BeClosed())
+			Expect(buf.Close()).To(Succeed())
+		})
+	})
+	When("closing the buffer", func() {
+		It("should return an error on subsequent writes", func() {
+			buf, err := util.NewFileStreamBuffer(path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(buf.Close()).To(Succeed())
+			_, err = buf.Write([]byte("hello"))
+			Expect(err).To(MatchError(io.ErrClosedPipe))
+			Expect(buf.Close()).To(Succeed())
+		})
+		It("should be possible to re-open the buffer from its file", func(ctx SpecContext) {
+			buf, err := util.NewFileStreamBuffer(path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(buf.Write([]byte("hello world"))).To(Equal(11))
+			Expect(buf.Close()).To(Succeed())
+
+			reopened, err := util.OpenFileStreamBuffer(path)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = reopened.Write([]byte("foo"))
+			Expect(err).To(MatchError(io.ErrClosedPipe))
+
+			reader := reopened.NewStream(ctx)
+			var recv []byte
+			Eventually(reader).Should(Receive(&recv))
+			Expect(recv).To(Equal([]byte("hello world")))
+			Eventually(reader).Should(BeClosed())
+		})
+	})
+})